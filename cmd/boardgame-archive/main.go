@@ -0,0 +1,248 @@
+/*
+
+boardgame-archive exports and imports whole stores in the portable format of
+storage/archive, for moving between storage backends, for example from bolt
+to mysql.
+
+Storage managers are configured the same way as for the server, via
+StorageConfig in config.SECRET.json. For bolt and filesystem the config is
+the path of the database, and defaults to ".database" if omitted.
+
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/jkomoros/boardgame/server/config"
+	"github.com/jkomoros/boardgame/storage/archive"
+	"github.com/jkomoros/boardgame/storage/bolt"
+	"github.com/jkomoros/boardgame/storage/filesystem"
+	"github.com/jkomoros/boardgame/storage/mysql"
+	"github.com/jkomoros/boardgame/storage/sqlite"
+	"log"
+	"os"
+	"strings"
+)
+
+const defaultDatabasePath = ".database"
+
+type appOptions struct {
+	Help    bool
+	Prod    bool
+	flagSet *flag.FlagSet
+}
+
+func defineFlags(options *appOptions) {
+	options.flagSet.BoolVar(&options.Help, "help", false, "If true, will print help and exit.")
+	options.flagSet.BoolVar(&options.Prod, "prod", false, "If true will operate on prod. If omitted will default to dev")
+}
+
+func getOptions(flagSet *flag.FlagSet, flagArguments []string) *appOptions {
+	options := &appOptions{flagSet: flagSet}
+	defineFlags(options)
+	flagSet.Parse(flagArguments)
+	return options
+}
+
+func main() {
+	flagSet := flag.CommandLine
+	//process returns rather than exiting itself, so that the storage manager
+	//is closed (and e.g. the filesystem lock released) before exiting.
+	if err := process(getOptions(flagSet, os.Args[1:])); err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+}
+
+func process(options *appOptions) error {
+
+	if options.Help {
+		doHelp()
+		return nil
+	}
+
+	if options.flagSet.NArg() != 3 {
+		doHelp()
+		return errors.New("Expected COMMAND STORAGE FILE")
+	}
+
+	cfg, err := config.Get()
+
+	if err != nil {
+		return errors.New("invalid config: " + err.Error())
+	}
+
+	configToUse := cfg.Dev
+
+	if options.Prod {
+		configToUse = cfg.Prod
+	}
+
+	command := strings.ToLower(options.flagSet.Arg(0))
+	storageName := strings.ToLower(options.flagSet.Arg(1))
+	fileName := options.flagSet.Arg(2)
+
+	storage, err := getStorage(storageName, configToUse.StorageConfig[storageName])
+
+	if err != nil {
+		return err
+	}
+
+	defer storage.Close()
+
+	switch command {
+	case "export":
+		return doExport(storage, fileName)
+	case "import":
+		if !prodConfirm(options.Prod) {
+			return errors.New("Import cancelled")
+		}
+		return doImport(storage, fileName)
+	case "verify":
+		return doVerify(storage, fileName)
+	}
+
+	doHelp()
+	return errors.New("Unknown command: " + command)
+
+}
+
+//getStorage returns a connected storage manager of the given type.
+func getStorage(name string, storageConfig string) (archive.Storage, error) {
+
+	var storage archive.Storage
+
+	switch name {
+	case "bolt":
+		if storageConfig == "" {
+			storageConfig = defaultDatabasePath
+		}
+		storage = bolt.NewStorageManager(storageConfig)
+	case "filesystem":
+		if storageConfig == "" {
+			storageConfig = defaultDatabasePath
+		}
+		fsStorage, err := filesystem.NewStorageManager(storageConfig)
+		if err != nil {
+			return nil, errors.New("Couldn't open " + storageConfig + ": " + err.Error())
+		}
+		storage = fsStorage
+	case "mysql":
+		storage = mysql.NewStorageManager(false)
+	case "sqlite":
+		storage = sqlite.NewStorageManager(false)
+	default:
+		return nil, errors.New("Unknown storage type: " + name)
+	}
+
+	if err := storage.Connect(storageConfig); err != nil {
+		return nil, errors.New("Couldn't connect to " + name + ": " + err.Error())
+	}
+
+	return storage, nil
+}
+
+func prodConfirm(isProd bool) bool {
+	if !isProd {
+		return true
+	}
+	log.Println("You have selected a destructive action on prod. Are you sure? (y/N)")
+	var response string
+	fmt.Scanln(&response)
+	yesResponses := []string{"Yes", "Y", "yes"}
+	for _, responseToTest := range yesResponses {
+		if response == responseToTest {
+			return true
+		}
+	}
+	return false
+}
+
+func doHelp() {
+	help := `Usage: boardgame-archive [-prod] COMMAND STORAGE FILE
+
+STORAGE is one of 'bolt', 'filesystem', 'mysql' or 'sqlite'.
+
+Commands:
+* 'export' = Write everything in STORAGE to the archive FILE
+* 'import' = Save everything in the archive FILE to STORAGE, which should be empty, and then verify it
+* 'verify' = Check that STORAGE holds exactly the records in the archive FILE`
+	log.Println(help)
+}
+
+func doExport(storage archive.Storage, fileName string) error {
+
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		return errors.New("Couldn't create file: " + err.Error())
+	}
+
+	summary, err := archive.Export(storage, f)
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return errors.New("Export failed: " + err.Error())
+	}
+
+	log.Println("Exported " + summary.String())
+
+	return nil
+}
+
+func doImport(storage archive.Storage, fileName string) error {
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return errors.New("Couldn't open file: " + err.Error())
+	}
+
+	defer f.Close()
+
+	summary, err := archive.Import(storage, f)
+
+	if err != nil {
+		return errors.New("Import failed: " + err.Error())
+	}
+
+	log.Println("Imported " + summary.String())
+
+	if err := archive.Verify(storage, summary); err != nil {
+		return errors.New("Verification failed: " + err.Error())
+	}
+
+	log.Println("Verified")
+
+	return nil
+}
+
+func doVerify(storage archive.Storage, fileName string) error {
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return errors.New("Couldn't open file: " + err.Error())
+	}
+
+	defer f.Close()
+
+	expected, err := archive.SummarizeArchive(f)
+
+	if err != nil {
+		return errors.New("Couldn't read archive: " + err.Error())
+	}
+
+	if err := archive.Verify(storage, expected); err != nil {
+		return errors.New("Verification failed: " + err.Error())
+	}
+
+	log.Println("Verified " + expected.String())
+
+	return nil
+}
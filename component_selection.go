@@ -0,0 +1,117 @@
+package boardgame
+
+import (
+	"github.com/jkomoros/boardgame/errors"
+	"strconv"
+)
+
+//ComponentSelection describes which components of the manager's
+//ComponentChest take part in a specific game. The chest is fixed for every
+//game of a given type, so games with expansions, short-game variants, or
+//decks that depend on the number of players should put every component that
+//might ever be used in the chest, and then select the subset to use for each
+//game in GameDelegate.SelectComponents (or, in BeginSetUp, with
+//game.SetComponentSelection).
+//
+//The keys are deck names. A deck that is not in the map does not take part
+//in the game at all. A deck whose value is nil takes part in its entirety.
+//Otherwise, only the components at the given DeckIndexes take part.
+//Components keep their DeckIndex from the chest, so the indexes of a deck
+//that only partially takes part will have gaps in them. A nil
+//ComponentSelection means every component in the chest takes part, which is
+//the default.
+type ComponentSelection map[string][]int
+
+//Includes returns true if the component at the given index in the given deck
+//takes part in the game.
+func (c ComponentSelection) Includes(deckName string, index int) bool {
+	if c == nil {
+		return true
+	}
+	indexes, ok := c[deckName]
+	if !ok {
+		return false
+	}
+	if indexes == nil {
+		return true
+	}
+	for _, i := range indexes {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+//DeckNames returns the names of the decks in chest that have at least one
+//component taking part in the game, in the same order as chest.DeckNames().
+func (c ComponentSelection) DeckNames(chest *ComponentChest) []string {
+	if c == nil {
+		return chest.DeckNames()
+	}
+	var result []string
+	for _, name := range chest.DeckNames() {
+		if indexes, ok := c[name]; ok && (indexes == nil || len(indexes) > 0) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+//Components returns the components in deck that take part in the game, in
+//DeckIndex order.
+func (c ComponentSelection) Components(deck *Deck) []*Component {
+	if c == nil {
+		return deck.Components()
+	}
+	var result []*Component
+	for _, component := range deck.Components() {
+		if c.Includes(deck.Name(), component.DeckIndex) {
+			result = append(result, component)
+		}
+	}
+	return result
+}
+
+//Valid returns an error if the selection refers to decks that aren't in the
+//chest, or to indexes that are out of bounds or repeated.
+func (c ComponentSelection) Valid(chest *ComponentChest) error {
+	for deckName, indexes := range c {
+		deck := chest.Deck(deckName)
+		if deck == nil {
+			return errors.New("The component selection refers to a deck that isn't in the chest: " + deckName)
+		}
+		seen := make(map[int]bool, len(indexes))
+		for _, index := range indexes {
+			if index < 0 || index >= len(deck.Components()) {
+				return errors.New("The component selection for deck " + deckName + " had an index out of bounds: " + strconv.Itoa(index))
+			}
+			if seen[index] {
+				return errors.New("The component selection for deck " + deckName + " included an index more than once: " + strconv.Itoa(index))
+			}
+			seen[index] = true
+		}
+	}
+	return nil
+}
+
+//validStack returns an error if the stack contains any components that don't
+//take part in the game. Generic components from sanitized stacks are allowed.
+func (c ComponentSelection) validStack(stack Stack) error {
+	if c == nil {
+		return nil
+	}
+	deck := stack.deck()
+	if deck == nil {
+		return nil
+	}
+	for i, component := range stack.Components() {
+		if component == nil || component.DeckIndex < 0 {
+			continue
+		}
+		if !c.Includes(deck.Name(), component.DeckIndex) {
+			return errors.New("Slot " + strconv.Itoa(i) + " contained component " + strconv.Itoa(component.DeckIndex) + " from deck " + deck.Name() + ", which isn't part of this game's component selection")
+		}
+	}
+	return nil
+}
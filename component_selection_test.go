@@ -0,0 +1,140 @@
+package boardgame
+
+import (
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+type selectingGameDelegate struct {
+	testGameDelegate
+	selection ComponentSelection
+}
+
+func (s *selectingGameDelegate) SelectComponents(config GameConfig, numPlayers int) (ComponentSelection, error) {
+	return s.selection, nil
+}
+
+func newSelectingGameManager(t *testing.T, selection ComponentSelection) *GameManager {
+	moveInstaller := func(manager *GameManager) *MoveTypeConfigBundle {
+		return NewMoveTypeConfigBundle().AddMoves(
+			&testMoveConfig,
+			&testMoveIncrementCardInHandConfig,
+			&testMoveDrawCardConfig,
+			&testMoveAdvanceCurrentPlayerConfig,
+			&testMoveInvalidPlayerIndexConfig,
+		)
+	}
+
+	delegate := &selectingGameDelegate{
+		testGameDelegate: testGameDelegate{moveInstaller: moveInstaller},
+		selection:        selection,
+	}
+
+	manager, err := NewGameManager(delegate, newTestGameChest(), newTestStorageManager())
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	return manager
+}
+
+func TestComponentSelectionIncludes(t *testing.T) {
+
+	chest := newTestGameChest()
+
+	tests := []struct {
+		description string
+		selection   ComponentSelection
+		index       int
+		included    bool
+		deckNames   []string
+	}{
+		{
+			"Nil selection",
+			nil,
+			3,
+			true,
+			[]string{"test"},
+		},
+		{
+			"Entire deck",
+			ComponentSelection{"test": nil},
+			3,
+			true,
+			[]string{"test"},
+		},
+		{
+			"Partial deck included",
+			ComponentSelection{"test": {1, 3}},
+			3,
+			true,
+			[]string{"test"},
+		},
+		{
+			"Partial deck excluded",
+			ComponentSelection{"test": {1, 2}},
+			3,
+			false,
+			[]string{"test"},
+		},
+		{
+			"Deck not selected",
+			ComponentSelection{},
+			0,
+			false,
+			nil,
+		},
+	}
+
+	for i, test := range tests {
+		assert.For(t, i, test.description).ThatActual(test.selection.Includes("test", test.index)).Equals(test.included)
+		assert.For(t, i, test.description).ThatActual(test.selection.DeckNames(chest)).Equals(test.deckNames)
+		assert.For(t, i, test.description).ThatActual(test.selection.Valid(chest)).IsNil()
+	}
+
+	assert.For(t).ThatActual(ComponentSelection{"missing": nil}.Valid(chest)).IsNotNil()
+	assert.For(t).ThatActual(ComponentSelection{"test": {4}}.Valid(chest)).IsNotNil()
+	assert.For(t).ThatActual(ComponentSelection{"test": {1, 1}}.Valid(chest)).IsNotNil()
+
+}
+
+func TestComponentSelectionSetUp(t *testing.T) {
+
+	selection := ComponentSelection{"test": {0, 2}}
+
+	manager := newSelectingGameManager(t, selection)
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	assert.For(t).ThatActual(game.ComponentSelection()).Equals(selection)
+
+	gameState, _ := concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(gameState.DrawDeck.NumComponents()).Equals(2)
+
+	for _, c := range gameState.DrawDeck.Components() {
+		assert.For(t, c.DeckIndex).ThatActual(selection.Includes("test", c.DeckIndex)).IsTrue()
+	}
+
+	assert.For(t).ThatActual(game.SetComponentSelection(nil)).IsNotNil()
+
+	refried := manager.Game(game.Id())
+
+	assert.For(t).ThatActual(refried).IsNotNil()
+	assert.For(t).ThatActual(refried.ComponentSelection()).Equals(selection)
+	assert.For(t).ThatActual(refried.CurrentState()).IsNotNil()
+
+	record, err := manager.Storage().State(game.Id(), game.Version())
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	_, err = manager.stateFromRecord(record, ComponentSelection{"test": {0}})
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+	badGame := newSelectingGameManager(t, ComponentSelection{"test": {7}}).NewGame()
+
+	assert.For(t).ThatActual(badGame.SetUp(0, nil, nil)).IsNotNil()
+
+}
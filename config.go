@@ -0,0 +1,219 @@
+package boardgame
+
+import (
+	"github.com/jkomoros/boardgame/errors"
+	"sort"
+	"strconv"
+)
+
+//ConfigType denotes what kind of values a given ConfigOption accepts.
+type ConfigType int
+
+const (
+	//ConfigTypeEnum options must have one of the values in
+	//ConfigOption.Values. This is the only kind of option that Configs()
+	//can express.
+	ConfigTypeEnum ConfigType = iota
+	//ConfigTypeInt options must be an integer, optionally between
+	//ConfigOption.Min and ConfigOption.Max.
+	ConfigTypeInt
+	//ConfigTypeBool options must be "true" or "false".
+	ConfigTypeBool
+)
+
+func (c ConfigType) String() string {
+	switch c {
+	case ConfigTypeEnum:
+		return "enum"
+	case ConfigTypeInt:
+		return "int"
+	case ConfigTypeBool:
+		return "bool"
+	}
+	return "illegal"
+}
+
+//ConfigOption describes a single key that may be set in a GameConfig: what
+//kind of values it accepts, what it's called in user interfaces, its default,
+//and which other option it depends on.
+type ConfigOption struct {
+	//Key is the key in the GameConfig this option is stored in.
+	Key         string
+	DisplayName string
+	Description string
+	Type        ConfigType
+	//Values is the list of legal values, for ConfigTypeEnum.
+	Values []string
+	//If HasRange is true, ConfigTypeInt values must be between Min and Max,
+	//inclusive.
+	HasRange bool
+	Min      int
+	Max      int
+	//Default is the value the key will be set to if the GameConfig passed to
+	//SetUp doesn't set it. If it is "" the key is left unset.
+	Default string
+	//DependsOn, if not "", is the key of another option that must have the
+	//value DependsOnValue for this option to be set. For example, an option
+	//that only makes sense if a bool option "expansion" is turned on would
+	//have DependsOn "expansion" and DependsOnValue "true".
+	DependsOn      string
+	DependsOnValue string
+}
+
+//ConfigSchema is the typed description of all of the config options a game
+//type supports, as returned by GameDelegate.ConfigSchema.
+type ConfigSchema []*ConfigOption
+
+//ValidValue returns nil if val is a legal value for this option (without
+//regard to any dependencies), or a descriptive error otherwise.
+func (c *ConfigOption) ValidValue(val string) error {
+
+	displayName := c.DisplayName
+
+	if displayName == "" {
+		displayName = c.Key
+	}
+
+	switch c.Type {
+	case ConfigTypeEnum:
+		for _, allowedVal := range c.Values {
+			if val == allowedVal {
+				return nil
+			}
+		}
+		return errors.New("configuration's " + displayName + " property had a value that wasn't allowed: " + val)
+	case ConfigTypeInt:
+		intVal, err := strconv.Atoi(val)
+		if err != nil {
+			return errors.New("configuration's " + displayName + " property must be a number, not " + val)
+		}
+		if c.HasRange && (intVal < c.Min || intVal > c.Max) {
+			return errors.New("configuration's " + displayName + " property must be between " + strconv.Itoa(c.Min) + " and " + strconv.Itoa(c.Max))
+		}
+		return nil
+	case ConfigTypeBool:
+		if val != "true" && val != "false" {
+			return errors.New("configuration's " + displayName + " property must be true or false, not " + val)
+		}
+		return nil
+	}
+
+	return errors.New("configuration's " + displayName + " property has an unknown type")
+}
+
+//Option returns the option with the given key, or nil if there isn't one.
+func (c ConfigSchema) Option(key string) *ConfigOption {
+	for _, option := range c {
+		if option.Key == key {
+			return option
+		}
+	}
+	return nil
+}
+
+//Validate returns nil if every key in config is an option in the schema, has
+//a valid value, and has its dependency met, or a descriptive error otherwise.
+//Dependencies are checked after defaults have been applied. The keys that
+//Match sets on its games, ConfigKeyMatchGame and ConfigKeyMatchSeatRotation,
+//are always allowed.
+func (c ConfigSchema) Validate(config GameConfig) error {
+
+	withDefaults := c.WithDefaults(config)
+
+	//Go through keys in a stable order so the same config always produces
+	//the same error.
+	keys := make([]string, 0, len(config))
+
+	for key := range config {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val := config[key]
+
+		if matchConfigKeys[key] {
+			//Set by Match on each of its games; not part of any schema.
+			continue
+		}
+
+		option := c.Option(key)
+
+		if option == nil {
+			return errors.New("configuration had a property called " + key + " that isn't expected")
+		}
+
+		if err := option.ValidValue(val); err != nil {
+			return err
+		}
+
+		if option.DependsOn == "" {
+			continue
+		}
+
+		if withDefaults[option.DependsOn] != option.DependsOnValue {
+			displayName := option.DisplayName
+			if displayName == "" {
+				displayName = option.Key
+			}
+			return errors.New("configuration's " + displayName + " property can only be set if " + option.DependsOn + " is " + option.DependsOnValue)
+		}
+	}
+
+	return nil
+
+}
+
+//WithDefaults returns a copy of config with every option that isn't set but
+//has a Default set to its default, as long as the option's dependency (if
+//any) is met.
+func (c ConfigSchema) WithDefaults(config GameConfig) GameConfig {
+
+	result := make(GameConfig, len(config))
+
+	for key, val := range config {
+		result[key] = val
+	}
+
+	//Options may depend on options that come after them in the schema, so
+	//keep going until nothing changes.
+	changed := true
+
+	for changed {
+		changed = false
+		for _, option := range c {
+			if option.Default == "" {
+				continue
+			}
+			if _, ok := result[option.Key]; ok {
+				continue
+			}
+			if option.DependsOn != "" && result[option.DependsOn] != option.DependsOnValue {
+				continue
+			}
+			result[option.Key] = option.Default
+			changed = true
+		}
+	}
+
+	return result
+}
+
+//Int returns the value of key as an int. It's designed to be used in
+//BeginSetUp for options of ConfigTypeInt.
+func (g GameConfig) Int(key string) (int, error) {
+	val, ok := g[key]
+
+	if !ok {
+		return 0, errors.New("Config has no key " + key)
+	}
+
+	return strconv.Atoi(val)
+}
+
+//Bool returns the value of key as a bool. It's designed to be used in
+//BeginSetUp for options of ConfigTypeBool. Keys that aren't set are false.
+func (g GameConfig) Bool(key string) bool {
+	return g[key] == "true"
+}
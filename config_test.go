@@ -0,0 +1,160 @@
+package boardgame
+
+import (
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+func testConfigSchema() ConfigSchema {
+	return ConfigSchema{
+		{
+			Key:    "color",
+			Type:   ConfigTypeEnum,
+			Values: []string{"blue", "red"},
+		},
+		{
+			Key:      "rounds",
+			Type:     ConfigTypeInt,
+			HasRange: true,
+			Min:      1,
+			Max:      10,
+			Default:  "3",
+		},
+		{
+			Key:            "expansioncards",
+			Type:           ConfigTypeInt,
+			Default:        "5",
+			DependsOn:      "expansion",
+			DependsOnValue: "true",
+		},
+		{
+			Key:     "expansion",
+			Type:    ConfigTypeBool,
+			Default: "false",
+		},
+	}
+}
+
+func TestConfigSchemaValidate(t *testing.T) {
+
+	schema := testConfigSchema()
+
+	tests := []struct {
+		description string
+		config      GameConfig
+		legal       bool
+	}{
+		{
+			"Nil config",
+			nil,
+			true,
+		},
+		{
+			"Legal enum",
+			GameConfig{"color": "red"},
+			true,
+		},
+		{
+			"Illegal enum",
+			GameConfig{"color": "green"},
+			false,
+		},
+		{
+			"Unknown key",
+			GameConfig{"size": "big"},
+			false,
+		},
+		{
+			"Int in range",
+			GameConfig{"rounds": "10"},
+			true,
+		},
+		{
+			"Int out of range",
+			GameConfig{"rounds": "11"},
+			false,
+		},
+		{
+			"Not an int",
+			GameConfig{"rounds": "three"},
+			false,
+		},
+		{
+			"Illegal bool",
+			GameConfig{"expansion": "yes"},
+			false,
+		},
+		{
+			"Dependency met",
+			GameConfig{"expansion": "true", "expansioncards": "7"},
+			true,
+		},
+		{
+			"Dependency not met",
+			GameConfig{"expansioncards": "7"},
+			false,
+		},
+	}
+
+	for i, test := range tests {
+		err := schema.Validate(test.config)
+		if test.legal {
+			assert.For(t, i, test.description).ThatActual(err).IsNil()
+		} else {
+			assert.For(t, i, test.description).ThatActual(err).IsNotNil()
+		}
+	}
+
+}
+
+func TestConfigSchemaWithDefaults(t *testing.T) {
+
+	schema := testConfigSchema()
+
+	config := schema.WithDefaults(nil)
+
+	assert.For(t).ThatActual(config).Equals(GameConfig{
+		"rounds":    "3",
+		"expansion": "false",
+	})
+
+	input := GameConfig{"expansion": "true", "rounds": "4"}
+
+	config = schema.WithDefaults(input)
+
+	assert.For(t).ThatActual(config).Equals(GameConfig{
+		"rounds":         "4",
+		"expansion":      "true",
+		"expansioncards": "5",
+	})
+
+	//WithDefaults should not modify the config it was passed.
+	assert.For(t).ThatActual(len(input)).Equals(2)
+
+	rounds, err := config.Int("rounds")
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(rounds).Equals(4)
+
+	_, err = config.Int("missing")
+	assert.For(t).ThatActual(err).IsNotNil()
+
+	assert.For(t).ThatActual(config.Bool("expansion")).IsTrue()
+	assert.For(t).ThatActual(config.Bool("missing")).IsFalse()
+
+}
+
+func TestDefaultConfigSchema(t *testing.T) {
+
+	game := testGame(t)
+
+	schema := game.Manager().Delegate().ConfigSchema()
+
+	assert.For(t).ThatActual(len(schema)).Equals(1)
+
+	option := schema.Option("color")
+
+	assert.For(t).ThatActual(option).IsNotNil()
+	assert.For(t).ThatActual(option.Type).Equals(ConfigTypeEnum)
+	assert.For(t).ThatActual(option.Values).Equals([]string{"blue", "red"})
+
+}
@@ -101,6 +101,7 @@ import (
 	"errors"
 	"math"
 	"math/rand"
+	"sort"
 	"strconv"
 )
 
@@ -126,6 +127,8 @@ type Enum interface {
 	RandomValue() int
 	//Valid returns whether the given value is a valid member of this enum.
 	Valid(val int) bool
+	//Values returns every valid value of this enum, in ascending order.
+	Values() []int
 	//String returns the string value associated with the given value.
 	String(val int) string
 	//Name returns the name of this enum; if set is the set this enum is part of,
@@ -345,6 +348,15 @@ func (e *enum) Valid(val int) bool {
 	return ok
 }
 
+func (e *enum) Values() []int {
+	result := make([]int, 0, len(e.values))
+	for key := range e.values {
+		result = append(result, key)
+	}
+	sort.Ints(result)
+	return result
+}
+
 func (e *enum) String(val int) string {
 	return e.values[val]
 }
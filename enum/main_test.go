@@ -48,6 +48,8 @@ func TestEnum(t *testing.T) {
 
 	assert.For(t).ThatActual(colorEnum.String(125)).Equals("")
 
+	assert.For(t).ThatActual(colorEnum.Values()).Equals([]int{ColorBlue, ColorGreen, ColorRed})
+
 	_, err = enums.Add("Color", map[int]string{
 		ColorBlue: "Blue",
 	})
@@ -0,0 +1,90 @@
+package boardgame
+
+import (
+	"github.com/jkomoros/boardgame/errors"
+)
+
+//The codes of the errors returned by the engine. Each is attached along with
+//an errors.Category; see the errors package for how to match them.
+const (
+	//CodeGameNotSetUp is returned when a move is proposed on a game that
+	//hasn't been set up. Its category is Conflict.
+	CodeGameNotSetUp errors.Code = "game_not_set_up"
+	//CodeGameFinished is returned when a move is proposed on a game that is
+	//already finished. Its category is Conflict.
+	CodeGameFinished errors.Code = "game_finished"
+	//CodeMoveNotInstalled is returned when a move is proposed that isn't
+	//installed on the game. Its category is IllegalMove.
+	CodeMoveNotInstalled errors.Code = "move_not_installed"
+	//CodeInvalidProposer is returned when a move is proposed by a player
+	//who may not make moves. Its category is IllegalMove.
+	CodeInvalidProposer errors.Code = "invalid_proposer"
+	//CodeInvalidMoveField is returned when a move's fields don't satisfy its
+	//constraint struct tags. Its category is IllegalMove.
+	CodeInvalidMoveField errors.Code = "invalid_move_field"
+	//CodeIllegalMove is returned when a move's Legal method returns an
+	//error that doesn't have a category of its own. Its category is
+	//IllegalMove.
+	CodeIllegalMove errors.Code = "illegal_move"
+	//CodeMoveApplyFailed is returned when a legal move couldn't be applied.
+	//Its category is Internal.
+	CodeMoveApplyFailed errors.Code = "move_apply_failed"
+	//CodeTooManyFixUps is the code of ErrTooManyFixUps. Its category is
+	//Internal.
+	CodeTooManyFixUps errors.Code = "too_many_fix_ups"
+	//CodeStorageFailed is returned when storage fails for a reason that
+	//doesn't have a category of its own. Its category is Internal.
+	CodeStorageFailed errors.Code = "storage_failed"
+	//CodeGameNotFound is the code of ErrGameNotFound. Its category is
+	//NotFound.
+	CodeGameNotFound errors.Code = "game_not_found"
+	//CodeVersionNotFound is the code of ErrVersionNotFound. Its category is
+	//NotFound.
+	CodeVersionNotFound errors.Code = "version_not_found"
+	//CodeVersionConflict is the code of ErrVersionConflict. Its category is
+	//Conflict.
+	CodeVersionConflict errors.Code = "version_conflict"
+	//CodeMatchNotFound is the code of ErrMatchNotFound. Its category is
+	//NotFound.
+	CodeMatchNotFound errors.Code = "match_not_found"
+)
+
+//ErrGameNotFound should be returned, possibly with a more specific message
+//set via WithError, by StorageManagers when the requested game doesn't exist.
+var ErrGameNotFound = errors.New("No such game").WithCode(CodeGameNotFound, errors.NotFound)
+
+//ErrVersionNotFound should be returned, possibly with a more specific
+//message set via WithError, by StorageManagers when the game exists but the
+//requested version of it doesn't.
+var ErrVersionNotFound = errors.New("No such version for that game").WithCode(CodeVersionNotFound, errors.NotFound)
+
+//ErrVersionConflict should be returned, possibly with a more specific
+//message set via WithError, by StorageManagers from
+//SaveGameAndCurrentState when a state for that version was already stored,
+//typically because another move was saved first.
+var ErrVersionConflict = errors.New("There was already a version for that game stored").WithCode(CodeVersionConflict, errors.Conflict)
+
+//illegalMoveError returns err, which was returned from a move's Legal
+//method, as a Friendly error whose friendly message is the error's message.
+//Errors that already have a category (like moves.CurrentPlayer's NotYourTurn)
+//or a message id keep them; anything else is given CodeIllegalMove.
+func illegalMoveError(err error, code errors.Code) *errors.Friendly {
+	friendly, ok := err.(*errors.Friendly)
+	if !ok || (friendly.MessageID() == "" && friendly.Category() == errors.Uncategorized) {
+		friendly = errors.NewFriendly(err.Error())
+	}
+	if friendly.Category() == errors.Uncategorized {
+		friendly = friendly.WithCode(code, errors.IllegalMove)
+	}
+	return friendly
+}
+
+//storageError returns baseErr with msg, keeping the category and code of
+//err if it has one and otherwise marking it CodeStorageFailed.
+func storageError(baseErr *errors.Friendly, err error, msg string) *errors.Friendly {
+	result := baseErr.WithError(msg + err.Error())
+	if category := errors.CategoryOf(err); category != errors.Uncategorized {
+		return result.WithCode(errors.CodeOf(err), category)
+	}
+	return result.WithCode(CodeStorageFailed, errors.Internal)
+}
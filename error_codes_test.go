@@ -0,0 +1,49 @@
+package boardgame
+
+import (
+	"github.com/jkomoros/boardgame/errors"
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+func TestErrorCodes(t *testing.T) {
+
+	game := testGame(t)
+
+	unsetUpErr := <-game.ProposeMove(game.PlayerMoveByName("Test"), AdminPlayerIndex)
+
+	assert.For(t).ThatActual(errors.Is(unsetUpErr, CodeGameNotSetUp)).IsTrue()
+	assert.For(t).ThatActual(errors.CategoryOf(unsetUpErr)).Equals(errors.Conflict)
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	move := game.PlayerMoveByName("Test")
+
+	//Player 1 is not the current player, so the move's Legal will fail.
+	move.(*testMove).TargetPlayerIndex = 1
+
+	err := <-game.ProposeMove(move, 1)
+
+	assert.For(t).ThatActual(err).IsNotNil()
+	assert.For(t).ThatActual(errors.Is(err, errors.IllegalMove)).IsTrue()
+	assert.For(t).ThatActual(errors.CodeOf(err)).Equals(CodeIllegalMove)
+
+	friendly, ok := err.(*errors.Friendly)
+
+	assert.For(t).ThatActual(ok).IsTrue()
+	assert.For(t).ThatActual(friendly.FriendlyError()).Equals("The target player is not hte current player")
+
+	err = <-game.ProposeMove(game.PlayerMoveByName("Test"), ObserverPlayerIndex)
+
+	assert.For(t).ThatActual(errors.CodeOf(err)).Equals(CodeInvalidProposer)
+
+	_, err = game.Manager().Storage().State(game.Id(), game.Version()+10)
+
+	assert.For(t).ThatActual(errors.Is(err, ErrVersionNotFound)).IsTrue()
+	assert.For(t).ThatActual(errors.Is(err, errors.NotFound)).IsTrue()
+
+	_, err = game.Manager().Storage().Game("missing")
+
+	assert.For(t).ThatActual(errors.Is(err, ErrGameNotFound)).IsTrue()
+
+}
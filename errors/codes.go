@@ -0,0 +1,141 @@
+package errors
+
+import (
+	stderrors "errors"
+)
+
+//Category is a coarse, machine-readable classification of an error that
+//clients can branch on, for example to decide whether to show the error
+//next to a move form or to retry. Categories implement error so they can be
+//used as targets for Is:
+//
+//	if errors.Is(err, errors.NotYourTurn) {
+//		//...
+//	}
+type Category int
+
+const (
+	//Uncategorized is the category of errors that haven't been given one.
+	Uncategorized Category = iota
+	//IllegalMove means that a proposed move isn't legal right now.
+	IllegalMove
+	//NotYourTurn means that a proposed move would be legal, but not for the
+	//player who proposed it.
+	NotYourTurn
+	//NotFound means that the game, version or other record that was asked
+	//for doesn't exist.
+	NotFound
+	//Conflict means that the request conflicts with the current state of
+	//the game, for example because it is already finished or another move
+	//was stored first.
+	Conflict
+	//Internal means that something went wrong that isn't the requester's
+	//fault.
+	Internal
+)
+
+//String returns the name of the category, e.g. "NotYourTurn".
+func (c Category) String() string {
+	switch c {
+	case IllegalMove:
+		return "IllegalMove"
+	case NotYourTurn:
+		return "NotYourTurn"
+	case NotFound:
+		return "NotFound"
+	case Conflict:
+		return "Conflict"
+	case Internal:
+		return "Internal"
+	}
+	return "Uncategorized"
+}
+
+//Error returns the same as String, so a Category can be used as the target
+//of Is.
+func (c Category) Error() string {
+	return c.String()
+}
+
+//Code is a stable, machine-readable identifier for a specific kind of
+//error, like "game_finished". Codes are defined by the packages that return
+//them. Codes implement error so they can be used as targets for Is.
+type Code string
+
+//Error returns the code itself.
+func (c Code) Error() string {
+	return string(c)
+}
+
+//WithCode returns a copy of err with the given code and category.
+func (f *Friendly) WithCode(code Code, category Category) *Friendly {
+	result := f.copy()
+	result.code = code
+	result.category = category
+	return result
+}
+
+//Code returns the code set with WithCode, or "" if there isn't one.
+func (f *Friendly) Code() Code {
+	return f.code
+}
+
+//Category returns the category set with WithCode, or Uncategorized if there
+//isn't one.
+func (f *Friendly) Category() Category {
+	return f.category
+}
+
+//Unwrap returns the error this one was created from with NewWrapped or
+//Extend, if it wasn't a Friendly.
+func (f *Friendly) Unwrap() error {
+	return f.cause
+}
+
+//Is reports whether this error matches target: a Category or Code matches
+//if the error has that category or code, and another *Friendly matches if
+//it has the same non-empty code. This is what allows the errors.Is in the
+//standard library (and Is in this package) to match a Friendly even after
+//it has been copied with Extend or one of the With* methods.
+func (f *Friendly) Is(target error) bool {
+	switch target := target.(type) {
+	case Category:
+		return target != Uncategorized && f.category == target
+	case Code:
+		return target != "" && f.code == target
+	case *Friendly:
+		return target.code != "" && f.code == target.code
+	}
+	return false
+}
+
+//Is is a convenience wrapper around the standard library's errors.Is, so
+//packages that import this one don't also have to import that one.
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+//As is a convenience wrapper around the standard library's errors.As.
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
+
+//CategoryOf returns the category of the first *Friendly in err's chain, or
+//Uncategorized if there isn't one.
+func CategoryOf(err error) Category {
+	var f *Friendly
+	if !As(err, &f) {
+		return Uncategorized
+	}
+	return f.Category()
+}
+
+//CodeOf returns the code of the first *Friendly in err's chain, or "" if
+//there isn't one.
+func CodeOf(err error) Code {
+	var f *Friendly
+	if !As(err, &f) {
+		return ""
+	}
+	return f.Code()
+}
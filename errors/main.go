@@ -13,6 +13,15 @@ the given changes. This allows a pattern where a method defines a default
 FriendlyError with the friendly error message to use and then when it needs to
 return an error uses WithError() to add the specific error message.
 
+A FriendlyError may also carry a message id and parameters (see WithMessage),
+which allow the friendly message to be translated into other languages with a
+message catalog, like the one in the i18n package.
+
+Finally, a FriendlyError may carry a machine-readable Code and Category (see
+WithCode) that clients can branch on. They survive Extend and the With*
+methods, and can be matched with Is, either this package's or the standard
+library's.
+
 */
 package errors
 
@@ -25,6 +34,11 @@ type Friendly struct {
 	friendlyMsg string
 	secureMsg   string
 	fields      Fields
+	messageID   string
+	params      Fields
+	code        Code
+	category    Category
+	cause       error
 }
 
 //New creates a new errors.Friendly with the given msg.
@@ -58,7 +72,9 @@ func NewWrapped(err error, fields ...Fields) error {
 	if err == nil {
 		return nil
 	}
-	return New(err.Error(), fields...)
+	result := New(err.Error(), fields...)
+	result.cause = err
+	return result
 }
 
 func combineFields(fields ...Fields) Fields {
@@ -111,8 +127,9 @@ func (f *Friendly) Fields() Fields {
 
 //Extend returns a new FriendlyError where the Error() message is prepended
 //with this new message and a delimiter. If err is an *errors.Friendly then
-//the secure message, friendly message, and fields will all be included
-//unchanged.
+//the secure message, friendly message, code, category and fields will all be
+//included unchanged. Otherwise err is kept as the cause, so Is and As can
+//still find it.
 func Extend(err error, msg string, fields ...Fields) *Friendly {
 
 	var base *Friendly
@@ -123,43 +140,68 @@ func Extend(err error, msg string, fields ...Fields) *Friendly {
 
 	if base == nil {
 		base = New(err.Error())
+		base.cause = err
 	}
 
-	return &Friendly{
-		secureMsg:   base.secureMsg,
-		friendlyMsg: base.friendlyMsg,
-		msg:         msg + " : " + base.Error(),
-		fields:      combineFields(append([]Fields{base.fields}, fields...)...),
-	}
+	result := base.copy(fields...)
+	result.msg = msg + " : " + base.Error()
+	return result
 }
 
-//WithFriendly returns a copy of err where the friendlyMsg is set to friendlyMsg
+//copy returns a copy of f with fields combined into its fields.
+func (f *Friendly) copy(fields ...Fields) *Friendly {
+	result := *f
+	result.fields = combineFields(append([]Fields{f.fields}, fields...)...)
+	return &result
+}
+
+//WithFriendly returns a copy of err where the friendlyMsg is set to
+//friendlyMsg. Any message id set with WithMessage is dropped, since it no
+//longer describes the friendly message.
 func (f *Friendly) WithFriendly(friendlyMsg string, fields ...Fields) *Friendly {
-	return &Friendly{
-		secureMsg:   f.secureMsg,
-		msg:         f.msg,
-		friendlyMsg: friendlyMsg,
-		fields:      combineFields(append([]Fields{f.fields}, fields...)...),
-	}
+	result := f.copy(fields...)
+	result.friendlyMsg = friendlyMsg
+	result.messageID = ""
+	result.params = nil
+	return result
 }
 
 //WithError returns a copy of err where the Error() is set to msg. See a;so
 //Extend, which prepends a new message to the front of the existing message.
 func (f *Friendly) WithError(msg string, fields ...Fields) *Friendly {
-	return &Friendly{
-		secureMsg:   f.secureMsg,
-		msg:         msg,
-		friendlyMsg: f.friendlyMsg,
-		fields:      combineFields(append([]Fields{f.fields}, fields...)...),
-	}
+	result := f.copy(fields...)
+	result.msg = msg
+	return result
 }
 
 //WithSecure returns a copy of err where the SecureError() is set to secureMsg.
 func (f *Friendly) WithSecure(secureMsg string, fields ...Fields) *Friendly {
-	return &Friendly{
-		secureMsg:   secureMsg,
-		msg:         f.msg,
-		friendlyMsg: f.friendlyMsg,
-		fields:      combineFields(append([]Fields{f.fields}, fields...)...),
-	}
+	result := f.copy(fields...)
+	result.secureMsg = secureMsg
+	return result
+}
+
+//WithMessage returns a copy of err that records that its FriendlyError() is
+//the message identified by messageID in a message catalog, with the given
+//params interpolated into it. FriendlyError() itself is unchanged, and should
+//already be the message in the default language; a server that knows the
+//user's preferred language can use MessageID() and MessageParams() to show a
+//translation instead.
+func (f *Friendly) WithMessage(messageID string, params Fields) *Friendly {
+	result := f.copy()
+	result.messageID = messageID
+	result.params = params
+	return result
+}
+
+//MessageID returns the id of the FriendlyError() message in a message
+//catalog, or "" if none was set with WithMessage.
+func (f *Friendly) MessageID() string {
+	return f.messageID
+}
+
+//MessageParams returns the parameters to interpolate into the message
+//identified by MessageID.
+func (f *Friendly) MessageParams() Fields {
+	return f.params
 }
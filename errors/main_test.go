@@ -2,6 +2,7 @@ package errors
 
 import (
 	"github.com/workfit/tester/assert"
+	"io"
 	"testing"
 )
 
@@ -40,3 +41,57 @@ func TestFriendly(t *testing.T) {
 	assert.For(t).ThatActual(friendly.Fields()).Equals(fields)
 
 }
+
+func TestFriendlyMessage(t *testing.T) {
+
+	params := Fields{"phase": "Draw"}
+
+	f := NewFriendly("Move is not legal in phase Draw").WithMessage("illegal_phase", params)
+
+	assert.For(t).ThatActual(f.MessageID()).Equals("illegal_phase")
+	assert.For(t).ThatActual(f.MessageParams()).Equals(params)
+	assert.For(t).ThatActual(f.FriendlyError()).Equals("Move is not legal in phase Draw")
+
+	extended := Extend(f, "Couldn't apply move")
+
+	assert.For(t).ThatActual(extended.MessageID()).Equals("illegal_phase")
+	assert.For(t).ThatActual(extended.WithError("Other").MessageID()).Equals("illegal_phase")
+
+	assert.For(t).ThatActual(f.WithFriendly("Something else").MessageID()).Equals("")
+
+}
+
+func TestCodes(t *testing.T) {
+
+	const testCode Code = "test_code"
+
+	f := New("Not found").WithCode(testCode, NotFound)
+
+	assert.For(t).ThatActual(f.Code()).Equals(testCode)
+	assert.For(t).ThatActual(f.Category()).Equals(NotFound)
+
+	extended := Extend(f, "Couldn't load game")
+
+	assert.For(t).ThatActual(Is(extended, NotFound)).IsTrue()
+	assert.For(t).ThatActual(Is(extended, Conflict)).IsFalse()
+	assert.For(t).ThatActual(Is(extended, testCode)).IsTrue()
+	assert.For(t).ThatActual(Is(extended, f)).IsTrue()
+	assert.For(t).ThatActual(Is(New("Other"), Uncategorized)).IsFalse()
+
+	assert.For(t).ThatActual(CategoryOf(extended.WithFriendly("Missing"))).Equals(NotFound)
+	assert.For(t).ThatActual(CodeOf(extended)).Equals(testCode)
+	assert.For(t).ThatActual(CategoryOf(io.EOF)).Equals(Uncategorized)
+
+	var target *Friendly
+
+	assert.For(t).ThatActual(As(extended, &target)).IsTrue()
+	assert.For(t).ThatActual(target.Category()).Equals(NotFound)
+
+	wrapped := Extend(io.EOF, "Couldn't read")
+
+	assert.For(t).ThatActual(Is(wrapped, io.EOF)).IsTrue()
+	assert.For(t).ThatActual(Is(NewWrapped(io.EOF), io.EOF)).IsTrue()
+
+	assert.For(t).ThatActual(NotYourTurn.String()).Equals("NotYourTurn")
+
+}
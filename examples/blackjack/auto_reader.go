@@ -2597,6 +2597,7 @@ var __gameStateReaderProps map[string]boardgame.PropertyType = map[string]boardg
 	"Phase":           boardgame.TypeEnum,
 	"RRHasStarted":    boardgame.TypeBool,
 	"RRLastPlayer":    boardgame.TypePlayerIndex,
+	"RRLastPosition":  boardgame.TypeInt,
 	"RRRoundCount":    boardgame.TypeInt,
 	"RRStarterPlayer": boardgame.TypePlayerIndex,
 	"UnusedCards":     boardgame.TypeStack,
@@ -2874,6 +2875,8 @@ func (g *__gameStateReader) MutableEnumProp(name string) (enum.MutableVal, error
 func (g *__gameStateReader) IntProp(name string) (int, error) {
 
 	switch name {
+	case "RRLastPosition":
+		return g.data.RRLastPosition, nil
 	case "RRRoundCount":
 		return g.data.RRRoundCount, nil
 
@@ -2886,6 +2889,9 @@ func (g *__gameStateReader) IntProp(name string) (int, error) {
 func (g *__gameStateReader) SetIntProp(name string, value int) error {
 
 	switch name {
+	case "RRLastPosition":
+		g.data.RRLastPosition = value
+		return nil
 	case "RRRoundCount":
 		g.data.RRRoundCount = value
 		return nil
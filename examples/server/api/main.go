@@ -3,10 +3,18 @@
 multi is a server that loads up multiple games on one server to demonstrate
 how that works. It is also possible to have a server with only one game.
 
+By default it stores games with the bolt backend, in .database, because it's
+easier to get set up quickly. Normally your server would use
+api.NewDefaultStorageManager, which uses the MySQL backend. Pass
+-storage=filesystem to store everything as plain files in .database instead,
+so that each game's states and moves can be read in an editor while
+debugging.
+
 */
 package main
 
 import (
+	"flag"
 	"github.com/jkomoros/boardgame/examples/blackjack"
 	"github.com/jkomoros/boardgame/examples/debuganimations"
 	"github.com/jkomoros/boardgame/examples/memory"
@@ -14,15 +22,49 @@ import (
 	"github.com/jkomoros/boardgame/examples/tictactoe"
 	"github.com/jkomoros/boardgame/server/api"
 	"github.com/jkomoros/boardgame/storage/bolt"
+	"github.com/jkomoros/boardgame/storage/filesystem"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
+const databasePath = ".database"
+
+var storageType = flag.String("storage", "bolt", "The storage backend to use: 'bolt' or 'filesystem'")
+
 func main() {
 
-	//This example uses the bolt db backend because it's easier to get set up
-	//quickly. Normall your server would use api.NewDefaultStorageManager
-	//here, which would use the MySQL backend.
-	storage := api.NewServerStorageManager(bolt.NewStorageManager(".database"))
+	flag.Parse()
+
+	var backend api.StorageManager
+
+	switch *storageType {
+	case "bolt":
+		backend = bolt.NewStorageManager(databasePath)
+	case "filesystem":
+		fsStorage, err := filesystem.NewStorageManager(databasePath)
+		if err != nil {
+			log.Fatalln("Couldn't open filesystem storage: " + err.Error())
+		}
+		backend = fsStorage
+	default:
+		log.Fatalln("Unknown storage type: " + *storageType)
+	}
+
+	storage := api.NewServerStorageManager(backend)
 	defer storage.Close()
+
+	//Start blocks until the process is killed, so the deferred Close above
+	//never runs on Ctrl-C; close the storage on the way out instead.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		storage.Close()
+		os.Exit(0)
+	}()
+
 	api.NewServer(storage,
 		api.MustNewManager(blackjack.NewManager(storage)),
 		api.MustNewManager(tictactoe.NewManager(storage)),
@@ -18,7 +18,7 @@ const selfInitiatorSentinel = -1
 //ErrTooManyFixUps is returned from game.ProposeMove if too many fix up moves
 //are applied, which implies that there is a FixUp move configured to always
 //be legal, and is evidence of a serious error in your game logic.
-var ErrTooManyFixUps = errors.New("We recursed deeply in fixup, which implies that ProposeFixUp has a move that is always legal.")
+var ErrTooManyFixUps = errors.New("We recursed deeply in fixup, which implies that ProposeFixUp has a move that is always legal.").WithCode(CodeTooManyFixUps, errors.Internal)
 
 //A Game represents a specific game between a collection of Players. Create a
 //new one with NewGame().
@@ -29,6 +29,8 @@ type Game struct {
 
 	winners []PlayerIndex
 
+	result *GameResult
+
 	agents []string
 
 	//The current version of State.
@@ -63,6 +65,13 @@ type Game struct {
 
 	created time.Time
 
+	//components is the subset of the chest that takes part in this game. nil
+	//means every component does.
+	components ComponentSelection
+
+	//match is the id of the Match this game is part of, or "".
+	match string
+
 	//TODO: HistoricalState(index int) and HistoryLen() int
 
 	//TODO: an array of Player objects.
@@ -109,6 +118,13 @@ func (g *Game) Winners() []PlayerIndex {
 	return g.winners
 }
 
+//Result is the full outcome of the game, as returned by the delegate's
+//GameResult when the game finished: the reason it ended and each player's
+//rank and score. nil if the game isn't finished.
+func (g *Game) Result() *GameResult {
+	return g.result
+}
+
 //Finished is whether the came has been completed. If it is over, the
 //Winners will be set.
 func (g *Game) Finished() bool {
@@ -143,7 +159,7 @@ func (g *Game) JSONForPlayer(player PlayerIndex, state State) interface{} {
 
 	//We deliberately never include SecretSalt in the JSON blobs we create.
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"Name":               g.Name(),
 		"Finished":           g.Finished(),
 		"Winners":            g.Winners(),
@@ -155,6 +171,12 @@ func (g *Game) JSONForPlayer(player PlayerIndex, state State) interface{} {
 		"Agents":             g.Agents(),
 		"Version":            g.Version(),
 	}
+
+	if g.Result() != nil {
+		result["Result"] = g.Result()
+	}
+
+	return result
 }
 
 func (g *Game) MarshalJSON() ([]byte, error) {
@@ -169,12 +191,15 @@ func (g *Game) StorageRecord() *GameStorageRecord {
 		Name:       g.Manager().Delegate().Name(),
 		Version:    g.Version(),
 		Winners:    g.Winners(),
+		Result:     g.Result(),
 		Finished:   g.Finished(),
 		Created:    g.Created(),
 		Id:         g.Id(),
 		SecretSalt: g.SecretSalt(),
 		NumPlayers: g.NumPlayers(),
 		Agents:     g.Agents(),
+		Components: g.ComponentSelection(),
+		Match:      g.MatchId(),
 	}
 }
 
@@ -194,6 +219,12 @@ func (g *Game) Agents() []string {
 	return g.agents
 }
 
+//MatchId returns the id of the Match this game is part of, or "" if it
+//isn't part of one.
+func (g *Game) MatchId() string {
+	return g.match
+}
+
 //Version returns the version number of the highest State that is stored for
 //this game. This number will increase by one every time a move (either Player
 //or FixUp) is applied.
@@ -224,7 +255,7 @@ func (g *Game) State(version int) State {
 		return nil
 	}
 
-	result, err := g.manager.stateFromRecord(record)
+	result, err := g.manager.stateFromRecord(record, g.ComponentSelection())
 
 	if err != nil {
 		g.manager.Logger().Error("StateFromBlob failed: " + err.Error())
@@ -388,6 +419,8 @@ func (g *Game) SetUp(numPlayers int, config GameConfig, agentNames []string) err
 		return errors.NewFriendly("That configuration is not legal for this game: " + err.Error())
 	}
 
+	config = g.manager.Delegate().ConfigSchema().WithDefaults(config)
+
 	if agentNames != nil && len(agentNames) != numPlayers {
 		return baseErr.WithError("If agentNames is not nil, it must have length equivalent to numPlayers.")
 	}
@@ -406,13 +439,25 @@ func (g *Game) SetUp(numPlayers int, config GameConfig, agentNames []string) err
 		return errors.Extend(err, "Couldn't get starter state")
 	}
 
+	selection, err := g.manager.Delegate().SelectComponents(config, numPlayers)
+
+	if err != nil {
+		return baseErr.WithError("Couldn't select components: " + err.Error())
+	}
+
+	if err := g.SetComponentSelection(selection); err != nil {
+		return baseErr.WithError(err.Error())
+	}
+
 	g.manager.delegate.BeginSetUp(stateCopy, config)
 
-	//Distribute all components to their starter locations
+	//Distribute all components that take part in this game to their starter
+	//locations
 
-	for _, name := range g.Chest().DeckNames() {
+	for _, name := range g.components.DeckNames(g.Chest()) {
 		deck := g.Chest().Deck(name)
-		for i, component := range deck.Components() {
+		for _, component := range g.components.Components(deck) {
+			i := component.DeckIndex
 			stack, err := g.manager.Delegate().DistributeComponentToStarterStack(stateCopy, component)
 			if err != nil {
 				return baseErr.WithError("Distributing components failed for deck " + name + ":" + strconv.Itoa(i) + ":" + err.Error())
@@ -481,7 +526,7 @@ func (g *Game) SetUp(numPlayers int, config GameConfig, agentNames []string) err
 		//applied.
 		if err := g.applyMove(move, AdminPlayerIndex, true, 0, selfInitiatorSentinel); err != nil {
 
-			if err == ErrTooManyFixUps {
+			if errors.Is(err, ErrTooManyFixUps) {
 				return err
 			}
 
@@ -595,11 +640,35 @@ func (g *Game) FixUpMoveByName(name string) Move {
 
 }
 
-//Chest is the ComponentChest in use for this game.
+//Chest is the ComponentChest in use for this game. Note that not every
+//component in the chest necessarily takes part in this game; see
+//ComponentSelection.
 func (g *Game) Chest() *ComponentChest {
 	return g.manager.Chest()
 }
 
+//ComponentSelection returns the subset of the Chest that takes part in this
+//game. A nil result means every component in the chest takes part.
+func (g *Game) ComponentSelection() ComponentSelection {
+	return g.components
+}
+
+//SetComponentSelection sets the subset of the Chest that will take part in
+//this game. It may only be called before the game is set up; it is designed
+//to be called from your delegate's BeginSetUp, via state.Game(), if the
+//selection depends on more than the config and number of players passed to
+//SelectComponents.
+func (g *Game) SetComponentSelection(selection ComponentSelection) error {
+	if g.initalized {
+		return errors.New("The component selection can't be changed after the game is set up")
+	}
+	if err := selection.Valid(g.Chest()); err != nil {
+		return err
+	}
+	g.components = selection
+	return nil
+}
+
 //Refresh goes and sets this game object to reflect the current state of the
 //underlying game in Storage. Basically, when you call manager.Game() you get
 //a snapshot of the game in storage at that moment. If you believe that the
@@ -615,6 +684,7 @@ func (g *Game) Refresh() {
 	g.version = freshGame.Version()
 	g.finished = freshGame.Finished()
 	g.winners = freshGame.Winners()
+	g.result = freshGame.Result()
 
 }
 
@@ -649,7 +719,7 @@ func (g *Game) ProposeMove(move Move, proposer PlayerIndex) DelayedError {
 
 	if !g.initalized {
 		//The channel isn't even ready to send one.
-		errChan <- errors.New("Proposed a move before the game had been successfully set-up.")
+		errChan <- errors.New("Proposed a move before the game had been successfully set-up.").WithCode(CodeGameNotSetUp, errors.Conflict)
 		return errChan
 	}
 
@@ -672,6 +742,11 @@ func (g *Game) triggerAgents() error {
 			continue
 		}
 
+		//Eliminated players don't get to make any more moves.
+		if PlayerIndex(i).Eliminated(g.CurrentState()) {
+			continue
+		}
+
 		agent := g.Manager().AgentByName(name)
 
 		if agent == nil {
@@ -730,24 +805,24 @@ func (g *Game) applyMove(move Move, proposer PlayerIndex, isFixUp bool, recurseC
 	versionToSet := g.version + 1
 
 	if !g.initalized {
-		return baseErr.WithError("The game has not been initalized.")
+		return baseErr.WithError("The game has not been initalized.").WithCode(CodeGameNotSetUp, errors.Conflict)
 	}
 
 	if g.finished {
-		return errors.NewFriendly("Game was already finished")
+		return errors.NewFriendly("Game was already finished").WithCode(CodeGameFinished, errors.Conflict)
 	}
 
 	if isFixUp {
 
 		if g.FixUpMoveByName(move.Info().Type().Name()) == nil {
-			return baseErr.WithError("That move is not configured as a Fix Up move for this game.")
+			return baseErr.WithError("That move is not configured as a Fix Up move for this game.").WithCode(CodeMoveNotInstalled, errors.IllegalMove)
 		}
 
 	} else {
 
 		//Verify that the Move is actually configured to be part of this game.
 		if g.PlayerMoveByName(move.Info().Type().Name()) == nil {
-			return baseErr.WithError("That move is not configured as a Player move for this game.")
+			return baseErr.WithError("That move is not configured as a Player move for this game.").WithCode(CodeMoveNotInstalled, errors.IllegalMove)
 		}
 	}
 
@@ -761,20 +836,24 @@ func (g *Game) applyMove(move Move, proposer PlayerIndex, isFixUp bool, recurseC
 	currentState := g.CurrentState().(*state)
 
 	if !proposer.Valid(currentState) {
-		return baseErr.WithError("The proposer was not valid.")
+		return baseErr.WithError("The proposer was not valid.").WithCode(CodeInvalidProposer, errors.IllegalMove)
 	}
 
 	if proposer == ObserverPlayerIndex {
-		return baseErr.WithError("The proposer was the ObserverPlayerIndex, but observers may never make moves.")
+		return baseErr.WithError("The proposer was the ObserverPlayerIndex, but observers may never make moves.").WithCode(CodeInvalidProposer, errors.IllegalMove)
 	}
 
 	move.Info().initiator = initiator
 	move.Info().timestamp = time.Now()
 	move.Info().version = versionToSet
 
+	if err := move.Info().Type().CheckFieldConstraints(move, currentState, proposer); err != nil {
+		return illegalMoveError(err, CodeInvalidMoveField)
+	}
+
 	if err := move.Legal(currentState, proposer); err != nil {
 		//It's not legal, reject.
-		return errors.NewFriendly(err.Error())
+		return illegalMoveError(err, CodeIllegalMove)
 	}
 
 	currentPhase := g.manager.delegate.CurrentPhase(currentState)
@@ -783,11 +862,11 @@ func (g *Game) applyMove(move Move, proposer PlayerIndex, isFixUp bool, recurseC
 	newState.version = versionToSet
 
 	if err := move.Apply(newState); err != nil {
-		return baseErr.WithError("The move's apply function returned an error:" + err.Error())
+		return baseErr.WithError("The move's apply function returned an error:" + err.Error()).WithCode(CodeMoveApplyFailed, errors.Internal)
 	}
 
 	if err := newState.validatePlayerIndexes(); err != nil {
-		return baseErr.WithError("The modified state had a PlayerIndex out of bounds, so the move was not applied. " + err.Error())
+		return baseErr.WithError("The modified state had a PlayerIndex out of bounds, so the move was not applied. " + err.Error()).WithCode(CodeMoveApplyFailed, errors.Internal)
 	}
 
 	//Check to see if that move made the game finished.
@@ -797,6 +876,10 @@ func (g *Game) applyMove(move Move, proposer PlayerIndex, isFixUp bool, recurseC
 	if finished {
 		g.finished = true
 		g.winners = winners
+		g.result = g.manager.Delegate().GameResult(newState, winners)
+		if ender, ok := move.(GameEnder); ok && g.result != nil {
+			g.result.Reason = ender.EndReason()
+		}
 		//TODO: persist to database here.
 	}
 
@@ -811,7 +894,7 @@ func (g *Game) applyMove(move Move, proposer PlayerIndex, isFixUp bool, recurseC
 	//TODO: test that if we fail to save state to storage everything's fine.
 	if err := g.manager.Storage().SaveGameAndCurrentState(g.StorageRecord(), newState.StorageRecord(), moveStorageRecord); err != nil {
 		//TODO: we need to undo the temporary changes we made directly to ourselves (vesrion, finished, winners)
-		return baseErr.WithError("Storage returned an error:" + err.Error())
+		return storageError(baseErr, err, "Storage returned an error:")
 	}
 
 	//Ok, the state stuck and is now canonical--trigger the actions it was
@@ -824,6 +907,15 @@ func (g *Game) applyMove(move Move, proposer PlayerIndex, isFixUp bool, recurseC
 
 	if g.finished {
 
+		if g.match != "" {
+			//The move itself has been saved, so it succeeded either way. If
+			//the match couldn't be updated, it will be the next time it's
+			//loaded or refreshed.
+			if err := g.manager.matchGameFinished(g); err != nil {
+				g.manager.Logger().Error("The game finished, but its match couldn't be updated: " + err.Error())
+			}
+		}
+
 		if !isFixUp {
 			g.manager.Storage().PlayerMoveApplied(g.StorageRecord())
 		}
@@ -845,7 +937,7 @@ func (g *Game) applyMove(move Move, proposer PlayerIndex, isFixUp bool, recurseC
 		//applied.
 		if err := g.applyMove(move, AdminPlayerIndex, true, recurseCount+1, initiator); err != nil {
 
-			if err == ErrTooManyFixUps {
+			if errors.Is(err, ErrTooManyFixUps) {
 				return err
 			}
 
@@ -4,6 +4,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/jkomoros/boardgame/enum"
 	"github.com/jkomoros/boardgame/errors"
+	"github.com/jkomoros/boardgame/i18n"
 	"sort"
 )
 
@@ -14,7 +15,9 @@ import (
 //Delegate.LegalConfig(), and will be passed to Delegate.BeginSetup so that
 //you can set up your game in whatever way makes sense for a given Config.
 //Your Delegate defines what valid keys and values are with its return value
-//for Configs(), and how they should show to the user with ConfigDisplay.
+//for ConfigSchema() (or, for simple games, Configs()), and how they should
+//show to the user with ConfigDisplay. Values are always strings; use the Int
+//and Bool accessors for typed options.
 type GameConfig map[string]string
 
 //GameDelegate is the place that various parts of the game lifecycle can be
@@ -82,7 +85,7 @@ type GameDelegate interface {
 	//DistributeComponentToStarterStack is called during set up to establish
 	//the Deck/Stack invariant that every component in the chest is placed in
 	//precisely one Stack. Game will call this on each component in the Chest
-	//in order. This is where the logic goes to make sure each Component goes
+	//that takes part in the game (see SelectComponents) in order. This is where the logic goes to make sure each Component goes
 	//into its correct starter stack. You must return a non-nil Stack for each
 	//call, after which the given Component will be inserted into
 	//NextSlotIndex of that stack. If that is not the ordering you desire, you
@@ -92,6 +95,16 @@ type GameDelegate interface {
 	//are only provided for reference; do not modify them.
 	DistributeComponentToStarterStack(state State, c *Component) (Stack, error)
 
+	//SelectComponents is called during set up, before BeginSetUp, to decide
+	//which components of the chest take part in this game. This is where
+	//expansions, variants, and player-count-dependent decks are handled:
+	//put every component that might ever be used in the chest, and return
+	//the subset to use given the (already legal) config. Only components in
+	//the selection will be passed to DistributeComponentToStarterStack. A
+	//nil ComponentSelection means every component takes part.
+	//DefaultGameDelegate returns nil.
+	SelectComponents(config GameConfig, numPlayers int) (ComponentSelection, error)
+
 	//BeginSetup is a chance to modify the initial state object *before* the
 	//components are distributed to it. It is also where the config for your
 	//gametype will be passed (it will have already passed LegalConfig). This
@@ -118,6 +131,15 @@ type GameDelegate interface {
 	//the winners are. Called after every move is applied.
 	CheckGameFinished(state State) (finished bool, winners []PlayerIndex)
 
+	//GameResult is called once, right after CheckGameFinished reports that
+	//the game is finished, with the winners it returned. It should return
+	//the full outcome of the game: why it ended and each player's rank and
+	//score. The result is stored with the game; see Game.Result.
+	//DefaultGameDelegate's implementation is sufficient for most games. If
+	//the move that finished the game implements GameEnder (like moves.Resign
+	//and moves.TimeOut do) the result's Reason is then set from it.
+	GameResult(state State, winners []PlayerIndex) *GameResult
+
 	//ProposeFixUpMove is called after a move has been applied. It may return
 	//a FixUp move, which will be applied before any other moves are applied.
 	//If it returns nil, we may take the next move off of the queue. FixUp
@@ -165,6 +187,23 @@ type GameDelegate interface {
 	//returned by Configs().
 	ConfigValueDisplay(key, val string) (displayName, description string)
 
+	//ConfigSchema returns the typed description of every config key your
+	//game supports: its type, legal values or range, default, and which
+	//other option it depends on. DefaultGameDelegate's implementation
+	//derives an enum option for each key in Configs(), so you only need to
+	//override it if you want int or bool options, defaults, or dependent
+	//options. Defaults are applied to the config before it is passed to
+	//BeginSetUp.
+	ConfigSchema() ConfigSchema
+
+	//Messages returns the translations of your game's user-visible text:
+	//move help text and descriptions, config display names, enum display
+	//names and friendly errors, keyed by the ids returned by the helpers in
+	//the i18n package. Any message that is missing falls back on the English
+	//text in your code, so you only need to override this if you ship
+	//translations. See GameManager.Localizer.
+	Messages() *i18n.Catalog
+
 	//LegalConfig will be consulted when a new game is created. It should
 	//return nil if the provided config is a reasonable configuration for your
 	//gametype, and a descriptive error (that's reasonable to show to the end
@@ -192,15 +231,24 @@ type GameDelegate interface {
 	//used by moves.Base to generate meaningful error messages in Legal().
 	PhaseEnum() enum.Enum
 
-	//PhaseMoveProgression returns the names of the strings of moves in the
-	//given phase that must be applied in order. moves.Base's Legal() method
-	//uses this to determine if a given move is allowed to apply now. A nil
-	//return denotes that any move that is legal in this phase is legal at any
-	//time in the phase. This functionality is useful for SetUp phases where
+	//PhaseMoveProgression returns the progression of moves in the given
+	//phase that must be applied in order. moves.Base's Legal() method uses
+	//this to determine if a given move is allowed to apply now. A nil return
+	//denotes that any move that is legal in this phase is legal at any time
+	//in the phase. This functionality is useful for SetUp phases where
 	//you have many steps to apply in a row and signaling of when to apply a
 	//move can be error prone. See moves.Base's Legal method documentation for
 	//more about how to use it.
-	PhaseMoveProgression(phase int) []string
+	PhaseMoveProgression(phase int) MoveProgression
+
+	//PhaseMachine returns a declarative description of which phases exist
+	//and which phases may follow each one. If it returns non-nil,
+	//NewGameManager will verify that every phase is reachable and that every
+	//move type is only legal in declared phases, and moves.StartPhase will
+	//only make declared transitions. Its DOT method renders the game's flow
+	//for documentation. DefaultGameDelegate returns nil, which means any
+	//phase may follow any other.
+	PhaseMachine() *PhaseMachine
 
 	//SanitizationPolicy is consulted when sanitizing states. It is called for
 	//each prop in the state, including the set of groups that this player is
@@ -235,7 +283,7 @@ type GameDelegate interface {
 type PhaseMoveProgressionSetter interface {
 	//SetPhaseMoveProgression should set the values that the delegate should
 	//return for PhaseMoveProgression(phase).
-	SetPhaseMoveProgression(phase int, progression []string)
+	SetPhaseMoveProgression(phase int, progression MoveProgression)
 }
 
 //PropertyCollection is just an alias for map[string]interface{}
@@ -249,7 +297,7 @@ type PropertyCollection map[string]interface{}
 //particular game.
 type DefaultGameDelegate struct {
 	manager          *GameManager
-	moveProgressions map[int][]string
+	moveProgressions map[int]MoveProgression
 }
 
 func (d *DefaultGameDelegate) Diagram(state State) string {
@@ -330,7 +378,9 @@ func (d *DefaultGameDelegate) ProposeFixUpMove(state State) Move {
 }
 
 //CurrentPlayerIndex returns gameState.CurrentPlayer, if that is a PlayerIndex
-//property. If not, returns ObserverPlayerIndex.≈
+//property. If not, returns ObserverPlayerIndex. If that player has been
+//eliminated, it returns the next player who hasn't been (see
+//PlayerIndex.NextActive), or ObserverPlayerIndex if every player has been.
 func (d *DefaultGameDelegate) CurrentPlayerIndex(state State) PlayerIndex {
 	index, err := state.GameState().Reader().PlayerIndexProp("CurrentPlayer")
 
@@ -339,7 +389,17 @@ func (d *DefaultGameDelegate) CurrentPlayerIndex(state State) PlayerIndex {
 		return ObserverPlayerIndex
 	}
 
-	return index
+	if !index.Eliminated(state) {
+		return index
+	}
+
+	next := index.NextActive(state)
+
+	if next == index {
+		return ObserverPlayerIndex
+	}
+
+	return next
 }
 
 //CurrentPhase by default with return the value of gameState.Phase, if it is
@@ -371,23 +431,35 @@ func (d *DefaultGameDelegate) PhaseEnum() enum.Enum {
 //AddMovesForPhaseProgression during setup (or have no phases with a specific
 //progression of moves) then you likely have no reason to override this
 //method.
-func (d *DefaultGameDelegate) PhaseMoveProgression(phase int) []string {
+func (d *DefaultGameDelegate) PhaseMoveProgression(phase int) MoveProgression {
 	if d.moveProgressions == nil {
 		return nil
 	}
 	return d.moveProgressions[phase]
 }
 
+//PhaseMachine by default returns nil, which means no transitions between
+//phases are checked. Override it if you want the game's flow validated.
+func (d *DefaultGameDelegate) PhaseMachine() *PhaseMachine {
+	return nil
+}
+
 //SetPhaseMoveProgression implements PhaseMoveProgressionSetter so that
 //GameManager.AddOrderedMovesForPhase will work with any delegate that embeds
 //DefaultGameDelegate.
-func (d *DefaultGameDelegate) SetPhaseMoveProgression(phase int, progression []string) {
+func (d *DefaultGameDelegate) SetPhaseMoveProgression(phase int, progression MoveProgression) {
 	if d.moveProgressions == nil {
-		d.moveProgressions = make(map[int][]string)
+		d.moveProgressions = make(map[int]MoveProgression)
 	}
 	d.moveProgressions[phase] = progression
 }
 
+//SelectComponents by default returns nil, which means every component in the
+//chest takes part in every game.
+func (d *DefaultGameDelegate) SelectComponents(config GameConfig, numPlayers int) (ComponentSelection, error) {
+	return nil, nil
+}
+
 func (d *DefaultGameDelegate) DistributeComponentToStarterStack(state State, c *Component) (Stack, error) {
 	//The stub returns an error, because if this is called that means there
 	//was a component in the deck. And if we didn't store it in a stack, then
@@ -473,15 +545,22 @@ type defaultCheckGameFinishedDelegate interface {
 
 //CheckGameFinished by default checks delegate.GameEndConditionMet(). If true,
 //then it fetches delegate.PlayerScore() for each player and returns all
-//players who have the highest score as winners. To use this implementation
-//simply implement those methods. This is sufficient for many games, but not
-//all, so sometimes needs to be overriden.
+//players who have the highest score as winners, using TieBreakScore() to
+//narrow them down if the delegate implements TieBreaker. Eliminated players
+//are never winners, and if all but one player has been eliminated the game
+//is over and that player wins (see LastPlayerStanding). To use this
+//implementation simply implement those methods. This is sufficient for many
+//games, but not all, so sometimes needs to be overriden.
 func (d *DefaultGameDelegate) CheckGameFinished(state State) (finished bool, winners []PlayerIndex) {
 
 	if d.Manager() == nil {
 		return false, nil
 	}
 
+	if finished, winners := LastPlayerStanding(state); finished {
+		return true, winners
+	}
+
 	//Have to reach up to the manager's delegate to get the thing that embeds us.
 	checkGameFinished, ok := d.Manager().Delegate().(defaultCheckGameFinishedDelegate)
 
@@ -495,7 +574,10 @@ func (d *DefaultGameDelegate) CheckGameFinished(state State) (finished bool, win
 
 	//Game is over. What's the max score?
 	maxScore := 0
-	for _, player := range state.PlayerStates() {
+	for i, player := range state.PlayerStates() {
+		if PlayerIndex(i).Eliminated(state) {
+			continue
+		}
 		score := checkGameFinished.PlayerScore(player)
 
 		if score > maxScore {
@@ -505,6 +587,9 @@ func (d *DefaultGameDelegate) CheckGameFinished(state State) (finished bool, win
 
 	//Who has the max score?
 	for i, player := range state.PlayerStates() {
+		if PlayerIndex(i).Eliminated(state) {
+			continue
+		}
 		score := checkGameFinished.PlayerScore(player)
 
 		if score == maxScore {
@@ -512,10 +597,42 @@ func (d *DefaultGameDelegate) CheckGameFinished(state State) (finished bool, win
 		}
 	}
 
-	return true, winners
+	tieBreaker, ok := d.Manager().Delegate().(TieBreaker)
+
+	if !ok || len(winners) < 2 {
+		return true, winners
+	}
+
+	//Break the tie between the players with the max score.
+	maxTieBreak := tieBreaker.TieBreakScore(state.PlayerStates()[winners[0]])
+	for _, winner := range winners[1:] {
+		if score := tieBreaker.TieBreakScore(state.PlayerStates()[winner]); score > maxTieBreak {
+			maxTieBreak = score
+		}
+	}
+
+	var tieBrokenWinners []PlayerIndex
+	for _, winner := range winners {
+		if tieBreaker.TieBreakScore(state.PlayerStates()[winner]) == maxTieBreak {
+			tieBrokenWinners = append(tieBrokenWinners, winner)
+		}
+	}
+
+	return true, tieBrokenWinners
 
 }
 
+//GameResult by default ranks the winners first, then every other player by
+//delegate.PlayerScore() and, if the delegate implements TieBreaker, by
+//TieBreakScore(), with eliminated players last. The reason is always
+//EndReasonNormal.
+func (d *DefaultGameDelegate) GameResult(state State, winners []PlayerIndex) *GameResult {
+	return &GameResult{
+		Reason:  EndReasonNormal,
+		Players: rankPlayers(state, d.Manager().Delegate(), winners),
+	}
+}
+
 //GameEndConditionMet is used in the default CheckGameFinished implementation.
 //It should return true when the game is over and ready for scoring.
 //CheckGameFinished uses this by default; if you override CheckGameFinished
@@ -572,34 +689,55 @@ func (d *DefaultGameDelegate) ConfigValueDisplay(key, val string) (displayName,
 	return val, ""
 }
 
-//LegalConfig on DefaultGameDelegate by default verifies that each of the keys
-//and values in the Config are legal keys and values in the map returned by
-//Configs().
-func (d *DefaultGameDelegate) LegalConfig(config GameConfig) error {
+//ConfigSchema by default returns a ConfigTypeEnum option for each key in
+//Configs(), in sorted order, using ConfigKeyDisplay for the display name and
+//description. Override it if you want typed options, defaults, or
+//dependencies.
+func (d *DefaultGameDelegate) ConfigSchema() ConfigSchema {
 	//We can't call Configs on self because that might not be the right one,
 	//it might be overridden.
 	del := d.Manager().Delegate()
 
-	validConfigs := del.Configs()
-	for key, val := range config {
-		if _, ok := validConfigs[key]; !ok {
-			return errors.New("configuration had a property called " + key + " that isn't expected")
-		}
-		foundAllowedVal := false
-		for _, allowedVal := range validConfigs[key] {
-			if val == allowedVal {
-				foundAllowedVal = true
-			}
-		}
-		if !foundAllowedVal {
-			keyDisplayName, _ := del.ConfigKeyDisplay(key)
-			return errors.New("configuration's " + keyDisplayName + " property had a value that wasn't allowed: " + val)
+	configs := del.Configs()
+
+	keys := make([]string, 0, len(configs))
+
+	for key := range configs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	result := make(ConfigSchema, len(keys))
+
+	for i, key := range keys {
+		displayName, description := del.ConfigKeyDisplay(key)
+		result[i] = &ConfigOption{
+			Key:         key,
+			DisplayName: displayName,
+			Description: description,
+			Type:        ConfigTypeEnum,
+			Values:      configs[key],
 		}
 	}
 
+	return result
+}
+
+//Messages by default returns nil, which means every message is shown in
+//the English it was written in.
+func (d *DefaultGameDelegate) Messages() *i18n.Catalog {
 	return nil
 }
 
+//LegalConfig on DefaultGameDelegate by default verifies that the config is
+//valid according to ConfigSchema(): that each key is an expected option,
+//that its value is legal for that option's type, and that any dependencies
+//are met.
+func (d *DefaultGameDelegate) LegalConfig(config GameConfig) error {
+	return d.Manager().Delegate().ConfigSchema().Validate(config)
+}
+
 //ConfigureAgents by default returns nil. If you want agents in your game,
 //override this.
 func (d *DefaultGameDelegate) ConfigureAgents() []Agent {
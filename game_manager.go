@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/Sirupsen/logrus"
 	"github.com/jkomoros/boardgame/errors"
+	"github.com/jkomoros/boardgame/i18n"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,6 +34,7 @@ type GameManager struct {
 	fixUpMovesByName          map[string]*MoveType
 	playerMovesByName         map[string]*MoveType
 	agentsByName              map[string]Agent
+	messages                  *i18n.Catalog
 	modifiableGamesLock       sync.RWMutex
 	modifiableGames           map[string]*Game
 	timers                    *timerManager
@@ -41,9 +43,10 @@ type GameManager struct {
 }
 
 type moveTypeConfingBundleRun struct {
-	ordered bool
-	phase   int
-	configs []*MoveTypeConfig
+	ordered     bool
+	phase       int
+	configs     []*MoveTypeConfig
+	progression MoveProgression
 }
 
 //MoveTypeConfigBundle is a bundle of move type configs to add to a game. You
@@ -108,14 +111,25 @@ func (m *MoveTypeConfigBundle) AddMovesForPhase(phase int, config ...*MoveTypeCo
 //AddOrderedMovesForPhase is a variant around AddMovesForPhase that in
 //addition to enforcing the moves are only legal in a given phase will also
 //set a specific order. (Moves that are legal in every phase will not count in
-//the order matching). Will error if your delegate does not implement
+//the order matching). Each item may be a plain *MoveTypeConfig, or a
+//structured MoveProgression built with ProgressionSerial,
+//ProgressionAlternate, ProgressionRepeat and friends; the items must happen
+//in the order given. Every MoveTypeConfig in the progression will be
+//installed. Will error if your delegate does not implement
 //PhaseMoveProgressionSetter (DefaultGameDelegate does by default). Returns
 //the bundle itself for convenience so you can chain.
-func (m *MoveTypeConfigBundle) AddOrderedMovesForPhase(phase int, config ...*MoveTypeConfig) *MoveTypeConfigBundle {
+func (m *MoveTypeConfigBundle) AddOrderedMovesForPhase(phase int, progression ...MoveProgression) *MoveTypeConfigBundle {
+	var item MoveProgression
+	if len(progression) == 1 {
+		item = progression[0]
+	} else {
+		item = ProgressionSerial(progression...)
+	}
 	m.runs = append(m.runs, &moveTypeConfingBundleRun{
-		ordered: true,
-		phase:   phase,
-		configs: config,
+		ordered:     true,
+		phase:       phase,
+		configs:     item.MoveConfigs(),
+		progression: item,
 	})
 	return m
 }
@@ -200,6 +214,14 @@ func NewGameManager(delegate GameDelegate, chest *ComponentChest, storage Storag
 
 	}
 
+	if machine := delegate.PhaseMachine(); machine != nil {
+		if err := machine.Valid(result); err != nil {
+			return nil, errors.New("The phase machine was not valid: " + err.Error())
+		}
+	}
+
+	result.messages = delegate.Messages()
+
 	result.agentsByName = make(map[string]Agent)
 	for _, agent := range result.agents {
 		result.agentsByName[strings.ToLower(agent.Name())] = agent
@@ -246,7 +268,7 @@ func (g *GameManager) installMoveTypeConfigBundle(m *MoveTypeConfigBundle) error
 			continue
 		}
 
-		if err := g.addOrderedMovesForPhase(run.phase, run.configs...); err != nil {
+		if err := g.addOrderedMovesForPhase(run.phase, run.progression); err != nil {
 			return err
 		}
 	}
@@ -444,9 +466,12 @@ func (g *GameManager) gameFromStorageRecord(record *GameStorageRecord) *Game {
 		secretSalt: record.SecretSalt,
 		finished:   record.Finished,
 		winners:    record.Winners,
+		result:     record.Result,
 		numPlayers: record.NumPlayers,
 		created:    record.Created,
 		agents:     record.Agents,
+		components: record.Components,
+		match:      record.Match,
 		modifiable: false,
 		initalized: true,
 	}
@@ -672,9 +697,10 @@ func (g *GameManager) dynamicComponentValuesConstructor(state *state) (map[strin
 
 //StateFromBlob takes a state that was serialized in storage and reinflates
 //it. Storage sub-packages should call this to recover a real State object
-//given a serialized state blob. Note: the state that is returned does not
-//have its game property set.
-func (g *GameManager) stateFromRecord(record StateStorageRecord) (*state, error) {
+//given a serialized state blob. Every stack is verified to only contain
+//components that are part of components. Note: the state that is returned
+//does not have its game property set.
+func (g *GameManager) stateFromRecord(record StateStorageRecord, components ComponentSelection) (*state, error) {
 	//At this point, no extra state is stored in the blob other than in props.
 
 	//We can't just delegate to StateProps to unmarshal itself, because it
@@ -712,6 +738,9 @@ func (g *GameManager) stateFromRecord(record StateStorageRecord) (*state, error)
 				return nil, errors.New("Unable to inflate stack " + propName + " in game.")
 			}
 			stack.inflate(g.Chest())
+			if err := components.validStack(stack); err != nil {
+				return nil, errors.New("Stack " + propName + " in game was invalid: " + err.Error())
+			}
 		}
 	}
 
@@ -736,6 +765,9 @@ func (g *GameManager) stateFromRecord(record StateStorageRecord) (*state, error)
 					return nil, errors.New("Unable to inflate stack " + propName + " in player " + strconv.Itoa(i))
 				}
 				stack.inflate(g.Chest())
+				if err := components.validStack(stack); err != nil {
+					return nil, errors.New("Stack " + propName + " in player " + strconv.Itoa(i) + " was invalid: " + err.Error())
+				}
 			}
 		}
 
@@ -778,6 +810,9 @@ func (g *GameManager) stateFromRecord(record StateStorageRecord) (*state, error)
 						return nil, errors.New("Unable to inflate stack " + propName + " in deck " + deckName + " component " + strconv.Itoa(i))
 					}
 					stack.inflate(g.Chest())
+					if err := components.validStack(stack); err != nil {
+						return nil, errors.New("Stack " + propName + " in deck " + deckName + " component " + strconv.Itoa(i) + " was invalid: " + err.Error())
+					}
 				}
 			}
 
@@ -930,25 +965,17 @@ func (g *GameManager) addMovesForPhase(phase int, config ...*MoveTypeConfig) err
 	return nil
 }
 
-func (g *GameManager) addOrderedMovesForPhase(phase int, config ...*MoveTypeConfig) error {
+func (g *GameManager) addOrderedMovesForPhase(phase int, progression MoveProgression) error {
 	progressionSetter, ok := g.Delegate().(PhaseMoveProgressionSetter)
 	if !ok {
 		return errors.New("The delegate doest not implement PhaseMoveProgressionSetter, making this conveience method ineffective. Use AddGeneralMoves instead.")
 	}
 
-	var moveProgression []string
-
-	for _, moveConfig := range config {
-
-		moveProgression = append(moveProgression, moveConfig.Name)
-
-	}
-
-	if err := g.addMovesForPhase(phase, config...); err != nil {
+	if err := g.addMovesForPhase(phase, progression.MoveConfigs()...); err != nil {
 		return err
 	}
 
-	progressionSetter.SetPhaseMoveProgression(phase, moveProgression)
+	progressionSetter.SetPhaseMoveProgression(phase, progression)
 
 	return nil
 }
@@ -0,0 +1,219 @@
+package boardgame
+
+import (
+	"encoding/json"
+	"github.com/jkomoros/boardgame/errors"
+	"sort"
+)
+
+//EndReason is why a game finished.
+type EndReason int
+
+const (
+	//EndReasonNormal means that the game reached its normal end condition,
+	//including when all but one player was eliminated.
+	EndReasonNormal EndReason = iota
+	//EndReasonResignation means that one or more players resigned.
+	EndReasonResignation
+	//EndReasonTimeout means that a player ran out of time.
+	EndReasonTimeout
+)
+
+//GameEnder is an optional interface for moves that can finish the game for a
+//reason other than its normal end condition, like a player resigning or
+//running out of time. If applying such a move finishes the game, the Reason
+//of the game's Result is set to the move's EndReason, overriding what the
+//delegate's GameResult returned.
+type GameEnder interface {
+	EndReason() EndReason
+}
+
+var endReasonNames = map[EndReason]string{
+	EndReasonNormal:      "Normal",
+	EndReasonResignation: "Resignation",
+	EndReasonTimeout:     "Timeout",
+}
+
+//String returns the name of the reason, e.g. "Resignation".
+func (e EndReason) String() string {
+	if name, ok := endReasonNames[e]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+//MarshalJSON encodes the reason as its name, so stored results stay
+//readable and stable if more reasons are added.
+func (e EndReason) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+//UnmarshalJSON decodes a reason encoded by MarshalJSON.
+func (e *EndReason) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for reason, reasonName := range endReasonNames {
+		if reasonName == name {
+			*e = reason
+			return nil
+		}
+	}
+	return errors.New("Unknown end reason: " + name)
+}
+
+//PlayerResult is how one player placed in a finished game.
+type PlayerResult struct {
+	Player PlayerIndex
+	//Rank is the player's final place, starting at 1. Tied players share a
+	//rank, and the rank after a tie skips accordingly (1, 1, 3).
+	Rank  int
+	Score int
+	//Eliminated is true if the player had been eliminated when the game
+	//finished.
+	Eliminated bool
+	//TieBreak explains how this player's rank was decided against a player
+	//with the same score, e.g. "Most cards in hand". Empty if no tie had to
+	//be broken.
+	TieBreak string `json:",omitempty"`
+}
+
+//GameResult is the full outcome of a finished game: why it ended and where
+//each player placed. Your delegate's GameResult method produces it when the
+//game finishes, and it is stored with the game; see Game.Result.
+type GameResult struct {
+	Reason EndReason
+	//Players has one entry per player, in PlayerIndex order.
+	Players []*PlayerResult
+}
+
+//Winners returns the players with rank 1.
+func (g *GameResult) Winners() []PlayerIndex {
+	if g == nil {
+		return nil
+	}
+	var result []PlayerIndex
+	for _, player := range g.Players {
+		if player.Rank == 1 {
+			result = append(result, player.Player)
+		}
+	}
+	return result
+}
+
+//TieBreaker may be implemented by a GameDelegate to break ties between
+//players with the same PlayerScore in DefaultGameDelegate's
+//CheckGameFinished and GameResult. Players with the higher TieBreakScore
+//place higher; players whose tie-break scores are also equal stay tied.
+type TieBreaker interface {
+	TieBreakScore(player PlayerState) int
+	//TieBreakDescription describes the tie-break for players, e.g. "Most
+	//cards in hand".
+	TieBreakDescription() string
+}
+
+//playerScorer is implemented by every delegate that embeds
+//DefaultGameDelegate.
+type playerScorer interface {
+	PlayerScore(pState PlayerState) int
+}
+
+type rankedPlayer struct {
+	player     PlayerIndex
+	score      int
+	tieBreak   int
+	eliminated bool
+}
+
+//rankPlayers returns a PlayerResult for each player. Players in winners are
+//given rank 1; everyone else is ranked after them by score and then
+//tie-break score, with eliminated players after all of the players who
+//weren't.
+func rankPlayers(state State, delegate GameDelegate, winners []PlayerIndex) []*PlayerResult {
+
+	scorer, _ := delegate.(playerScorer)
+	tieBreaker, _ := delegate.(TieBreaker)
+
+	isWinner := make(map[PlayerIndex]bool, len(winners))
+	for _, winner := range winners {
+		isWinner[winner] = true
+	}
+
+	results := make([]*PlayerResult, len(state.PlayerStates()))
+
+	var others []*rankedPlayer
+
+	for i, player := range state.PlayerStates() {
+		index := PlayerIndex(i)
+		ranked := &rankedPlayer{
+			player:     index,
+			eliminated: index.Eliminated(state),
+		}
+		if scorer != nil {
+			ranked.score = scorer.PlayerScore(player)
+		}
+		if tieBreaker != nil {
+			ranked.tieBreak = tieBreaker.TieBreakScore(player)
+		}
+		results[i] = &PlayerResult{
+			Player:     index,
+			Score:      ranked.score,
+			Eliminated: ranked.eliminated,
+		}
+		if isWinner[index] {
+			results[i].Rank = 1
+			continue
+		}
+		others = append(others, ranked)
+	}
+
+	less := func(i, j *rankedPlayer) bool {
+		if i.eliminated != j.eliminated {
+			return !i.eliminated
+		}
+		if i.eliminated {
+			return false
+		}
+		if i.score != j.score {
+			return i.score > j.score
+		}
+		return i.tieBreak > j.tieBreak
+	}
+
+	sort.SliceStable(others, func(i, j int) bool {
+		return less(others[i], others[j])
+	})
+
+	rank := len(winners) + 1
+
+	for i, ranked := range others {
+		result := results[ranked.player]
+		if i > 0 && !less(others[i-1], ranked) {
+			result.Rank = results[others[i-1].player].Rank
+		} else {
+			result.Rank = rank + i
+		}
+	}
+
+	if tieBreaker == nil {
+		return results
+	}
+
+	//Record the tie-break on every player who had the same score as a player
+	//they were ranked differently from.
+	for _, result := range results {
+		if result.Eliminated {
+			continue
+		}
+		for _, other := range results {
+			if other.Eliminated || other.Score != result.Score || other.Rank == result.Rank {
+				continue
+			}
+			result.TieBreak = tieBreaker.TieBreakDescription()
+			break
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,142 @@
+package boardgame
+
+import (
+	"encoding/json"
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+type scoringGameDelegate struct {
+	testGameDelegate
+}
+
+func (s *scoringGameDelegate) PlayerScore(pState PlayerState) int {
+	return pState.(*testEliminatablePlayerState).Score
+}
+
+type tieBreakingGameDelegate struct {
+	scoringGameDelegate
+}
+
+func (t *tieBreakingGameDelegate) TieBreakScore(pState PlayerState) int {
+	return pState.(*testEliminatablePlayerState).MovesLeftThisTurn
+}
+
+func (t *tieBreakingGameDelegate) TieBreakDescription() string {
+	return "Most moves left"
+}
+
+func rankingTestState(scores []int, movesLeft []int, eliminated ...PlayerIndex) *state {
+	playerStates := make([]ConfigurablePlayerState, len(scores))
+
+	for i := range playerStates {
+		playerStates[i] = &testEliminatablePlayerState{
+			testPlayerState: &testPlayerState{
+				playerIndex:       PlayerIndex(i),
+				Score:             scores[i],
+				MovesLeftThisTurn: movesLeft[i],
+			},
+		}
+	}
+
+	for _, player := range eliminated {
+		playerStates[player].(*testEliminatablePlayerState).SetPlayerEliminated(true)
+	}
+
+	return &state{
+		playerStates: playerStates,
+	}
+}
+
+func resultRanks(results []*PlayerResult) []int {
+	var ranks []int
+	for _, result := range results {
+		ranks = append(ranks, result.Rank)
+	}
+	return ranks
+}
+
+func TestRankPlayers(t *testing.T) {
+
+	state := rankingTestState([]int{3, 7, 3, 5, 9}, []int{1, 0, 2, 0, 0}, 4)
+
+	results := rankPlayers(state, &scoringGameDelegate{}, []PlayerIndex{1})
+
+	assert.For(t).ThatActual(resultRanks(results)).Equals([]int{3, 1, 3, 2, 5})
+	assert.For(t).ThatActual(results[4].Eliminated).IsTrue()
+	assert.For(t).ThatActual(results[4].Score).Equals(9)
+	assert.For(t).ThatActual(results[0].TieBreak).Equals("")
+
+	results = rankPlayers(state, &tieBreakingGameDelegate{}, []PlayerIndex{1})
+
+	assert.For(t).ThatActual(resultRanks(results)).Equals([]int{4, 1, 3, 2, 5})
+	assert.For(t).ThatActual(results[0].TieBreak).Equals("Most moves left")
+	assert.For(t).ThatActual(results[2].TieBreak).Equals("Most moves left")
+	assert.For(t).ThatActual(results[3].TieBreak).Equals("")
+
+	result := &GameResult{Players: results}
+
+	assert.For(t).ThatActual(result.Winners()).Equals([]PlayerIndex{1})
+
+}
+
+func TestGameResultJSON(t *testing.T) {
+
+	result := &GameResult{
+		Reason: EndReasonResignation,
+		Players: []*PlayerResult{
+			{Player: 0, Rank: 1, Score: 4},
+			{Player: 1, Rank: 2, Score: 4, TieBreak: "Most moves left"},
+		},
+	}
+
+	blob, err := json.Marshal(result)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	var roundTripped GameResult
+
+	assert.For(t).ThatActual(json.Unmarshal(blob, &roundTripped)).IsNil()
+	assert.For(t).ThatActual(roundTripped.Reason).Equals(EndReasonResignation)
+	assert.For(t).ThatActual(&roundTripped).Equals(result)
+
+	var reason EndReason
+
+	assert.For(t).ThatActual(json.Unmarshal([]byte(`"Forfeit"`), &reason)).IsNotNil()
+
+}
+
+func TestGameResultRecorded(t *testing.T) {
+
+	game := testGame(t)
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+	assert.For(t).ThatActual(game.Result()).IsNil()
+
+	move := game.PlayerMoveByName("Test").(*testMove)
+
+	move.AString = "foo"
+	move.ScoreIncrement = 6
+	move.TargetPlayerIndex = 0
+	move.ABool = true
+
+	assert.For(t).ThatActual(<-game.ProposeMove(move, AdminPlayerIndex)).IsNil()
+	assert.For(t).ThatActual(game.Finished()).IsTrue()
+
+	result := game.Result()
+
+	assert.For(t).ThatActual(result).IsNotNil()
+	assert.For(t).ThatActual(result.Reason).Equals(EndReasonNormal)
+	assert.For(t).ThatActual(result.Winners()).Equals(game.Winners())
+	assert.For(t).ThatActual(len(result.Players)).Equals(len(game.CurrentState().PlayerStates()))
+
+	record, err := game.Manager().Storage().Game(game.Id())
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(record.Result).Equals(result)
+
+	refetched := game.Manager().Game(game.Id())
+
+	assert.For(t).ThatActual(refetched.Result()).Equals(result)
+
+}
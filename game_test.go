@@ -294,7 +294,7 @@ func TestApplyMove(t *testing.T) {
 		t.Error("Unexpected error", err)
 	}
 
-	wrapper, err := game.Manager().stateFromRecord(record)
+	wrapper, err := game.Manager().stateFromRecord(record, nil)
 
 	if err != nil {
 		t.Error("Error state from from blob", err)
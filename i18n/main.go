@@ -0,0 +1,351 @@
+/*
+
+i18n is a package for translating the user-visible text of a game into
+other languages.
+
+Every user-visible string in the framework (a move's help text, a config
+option's display name, an enum value's display name, a friendly error) has a
+stable message id. A Catalog maps message ids to messages for each locale.
+When a message for the requested locale is missing, the catalog falls back on
+the base language (e.g. "fr" for "fr-CA"), then on the catalog's default
+locale, and finally on the English literal in the code, so a partial
+translation never hides anything from the user.
+
+Messages may contain named parameters in braces, like "{player} drew {count}
+cards", which Interpolate fills in from a Params map.
+
+A game ships its translations by returning a Catalog from its delegate's
+Messages method:
+
+	func (g *gameDelegate) Messages() *i18n.Catalog {
+		return i18n.NewCatalog("en").AddMessages("fr", map[string]string{
+			i18n.MoveHelpTextID("Draw Card"): "Pioche une carte.",
+			i18n.EnumValueID("Color", "Red"): "Rouge",
+		})
+	}
+
+Catalogs may also be loaded from JSON files with LoadJSON. A nil *Catalog is
+valid and simply returns the fallback text for everything.
+
+*/
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jkomoros/boardgame/errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//DefaultLocale is the locale that the English literals in code are written
+//in.
+const DefaultLocale = "en"
+
+//Params are values to interpolate into a message.
+type Params map[string]interface{}
+
+//Catalog holds messages, keyed by message id, for any number of locales.
+//Create one with NewCatalog.
+type Catalog struct {
+	defaultLocale string
+	messages      map[string]map[string]string
+}
+
+//NewCatalog returns a new, empty catalog whose messages fall back on
+//defaultLocale when a message is missing in the requested locale. If
+//defaultLocale is "", DefaultLocale is used.
+func NewCatalog(defaultLocale string) *Catalog {
+	if defaultLocale == "" {
+		defaultLocale = DefaultLocale
+	}
+	return &Catalog{
+		defaultLocale: Normalize(defaultLocale),
+		messages:      make(map[string]map[string]string),
+	}
+}
+
+//Normalize returns the canonical form of a locale tag: lower case, with "-"
+//as the separator. "en_US" and "EN-us" both become "en-us".
+func Normalize(locale string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(locale), "_", "-", -1))
+}
+
+//DefaultLocale returns the locale that is used when a message is missing in
+//the requested one.
+func (c *Catalog) DefaultLocale() string {
+	if c == nil {
+		return DefaultLocale
+	}
+	return c.defaultLocale
+}
+
+//Add sets the message for id in locale. Returns itself for convenient
+//chaining.
+func (c *Catalog) Add(locale, id, message string) *Catalog {
+	locale = Normalize(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]string)
+	}
+	c.messages[locale][id] = message
+	return c
+}
+
+//AddMessages sets each of the messages, keyed by id, in locale. Returns
+//itself for convenient chaining.
+func (c *Catalog) AddMessages(locale string, messages map[string]string) *Catalog {
+	for id, message := range messages {
+		c.Add(locale, id, message)
+	}
+	return c
+}
+
+//LoadJSON reads a JSON object mapping message ids to messages from r and
+//adds them to locale.
+func (c *Catalog) LoadJSON(locale string, r io.Reader) error {
+	var messages map[string]string
+	if err := json.NewDecoder(r).Decode(&messages); err != nil {
+		return errors.New("Couldn't decode messages for " + locale + ": " + err.Error())
+	}
+	c.AddMessages(locale, messages)
+	return nil
+}
+
+//Merge adds all of the messages in other to this catalog, overwriting any
+//messages with the same locale and id. A nil other is a no-op. Returns
+//itself for convenient chaining.
+func (c *Catalog) Merge(other *Catalog) *Catalog {
+	if other == nil {
+		return c
+	}
+	for locale, messages := range other.messages {
+		c.AddMessages(locale, messages)
+	}
+	return c
+}
+
+//Locales returns every locale that has at least one message, plus the
+//default locale, sorted.
+func (c *Catalog) Locales() []string {
+	if c == nil {
+		return []string{DefaultLocale}
+	}
+	result := []string{c.defaultLocale}
+	for locale := range c.messages {
+		if locale == c.defaultLocale {
+			continue
+		}
+		result = append(result, locale)
+	}
+	sort.Strings(result)
+	return result
+}
+
+//fallbacks returns the locales to try, in order, for the given locale.
+func (c *Catalog) fallbacks(locale string) []string {
+	locale = Normalize(locale)
+	var result []string
+	if locale != "" {
+		result = append(result, locale)
+		if index := strings.Index(locale, "-"); index > 0 {
+			result = append(result, locale[:index])
+		}
+	}
+	return append(result, c.defaultLocale)
+}
+
+//Lookup returns the raw, uninterpolated message for id in locale, following
+//the fallback chain. ok is false if no locale in the chain has the message.
+func (c *Catalog) Lookup(locale, id string) (message string, ok bool) {
+	if c == nil {
+		return "", false
+	}
+	for _, candidate := range c.fallbacks(locale) {
+		if message, ok := c.messages[candidate][id]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+//Translate returns the message for id in locale with params interpolated.
+//If the catalog has no message for id, fallback (typically the English
+//literal from code) is interpolated and returned instead.
+func (c *Catalog) Translate(locale, id, fallback string, params Params) string {
+	message, ok := c.Lookup(locale, id)
+	if !ok {
+		message = fallback
+	}
+	return Interpolate(message, params)
+}
+
+//TranslateError returns the user-visible message for err in locale. If err
+//carries a message id (see errors.Friendly's WithMessage), that message is
+//translated; otherwise its FriendlyError() is returned if it has one, and
+//its Error() if it doesn't.
+func (c *Catalog) TranslateError(locale string, err error) string {
+	if err == nil {
+		return ""
+	}
+	friendly, ok := err.(*errors.Friendly)
+	if !ok {
+		return err.Error()
+	}
+	if friendly.MessageID() == "" {
+		return friendly.FriendlyError()
+	}
+	return c.Translate(locale, friendly.MessageID(), friendly.FriendlyError(), Params(friendly.MessageParams()))
+}
+
+//Negotiate returns the best of the catalog's locales for the given
+//Accept-Language header value, or the catalog's default locale if none of
+//them match.
+func (c *Catalog) Negotiate(acceptLanguage string) string {
+	return Negotiate(acceptLanguage, c.Locales(), c.DefaultLocale())
+}
+
+//Interpolate replaces every "{name}" in message with the value of name in
+//params. Placeholders without a corresponding param are left as is.
+func Interpolate(message string, params Params) string {
+	if len(params) == 0 {
+		return message
+	}
+	var replacements []string
+	for key, val := range params {
+		replacements = append(replacements, "{"+key+"}", fmt.Sprint(val))
+	}
+	return strings.NewReplacer(replacements...).Replace(message)
+}
+
+//NewError returns an errors.Friendly whose FriendlyError is message, in the
+//default language, with params interpolated, and that records id and params
+//so the message can be translated later.
+func NewError(id, message string, params Params) *errors.Friendly {
+	return errors.NewFriendly(Interpolate(message, params)).WithMessage(id, errors.Fields(params))
+}
+
+type weightedLocale struct {
+	locale string
+	weight float64
+}
+
+//ParseAcceptLanguage returns the normalized locales listed in an
+//Accept-Language header value, most preferred first. Locales with a weight
+//of 0 and the wildcard "*" are omitted.
+func ParseAcceptLanguage(header string) []string {
+	var locales []weightedLocale
+
+	for _, part := range strings.Split(header, ",") {
+		pieces := strings.Split(part, ";")
+		locale := Normalize(pieces[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+		weight := 1.0
+		for _, piece := range pieces[1:] {
+			piece = strings.TrimSpace(piece)
+			if !strings.HasPrefix(piece, "q=") {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(piece[2:], 64); err == nil {
+				weight = parsed
+			}
+		}
+		if weight <= 0 {
+			continue
+		}
+		locales = append(locales, weightedLocale{locale, weight})
+	}
+
+	sort.SliceStable(locales, func(i, j int) bool {
+		return locales[i].weight > locales[j].weight
+	})
+
+	result := make([]string, len(locales))
+	for i, locale := range locales {
+		result[i] = locale.locale
+	}
+	return result
+}
+
+//Negotiate returns the entry in available that best matches the
+//Accept-Language header value, or defaultLocale if none do. An exact match
+//is preferred; otherwise a requested "fr-ca" matches an available "fr", and
+//a requested "fr" matches an available "fr-ca".
+func Negotiate(acceptLanguage string, available []string, defaultLocale string) string {
+	normalized := make(map[string]string, len(available))
+	for _, locale := range available {
+		normalized[Normalize(locale)] = locale
+	}
+
+	for _, requested := range ParseAcceptLanguage(acceptLanguage) {
+		if locale, ok := normalized[requested]; ok {
+			return locale
+		}
+		base := requested
+		if index := strings.Index(requested, "-"); index > 0 {
+			base = requested[:index]
+		}
+		if locale, ok := normalized[base]; ok {
+			return locale
+		}
+		for _, locale := range available {
+			if strings.HasPrefix(Normalize(locale), base+"-") {
+				return locale
+			}
+		}
+	}
+
+	return defaultLocale
+}
+
+//messageIDPart normalizes a name for use in a message id: lower case, with
+//spaces replaced by underscores.
+func messageIDPart(name string) string {
+	return strings.Replace(strings.ToLower(strings.TrimSpace(name)), " ", "_", -1)
+}
+
+//MoveHelpTextID returns the message id for the help text of the move type
+//with the given name.
+func MoveHelpTextID(moveName string) string {
+	return "move." + messageIDPart(moveName) + ".help"
+}
+
+//MoveDescriptionID returns the default message id for the description of a
+//move of the type with the given name.
+func MoveDescriptionID(moveName string) string {
+	return "move." + messageIDPart(moveName) + ".description"
+}
+
+//ConfigDisplayNameID returns the message id for the display name of the
+//config option with the given key.
+func ConfigDisplayNameID(key string) string {
+	return "config." + messageIDPart(key) + ".name"
+}
+
+//ConfigDescriptionID returns the message id for the description of the
+//config option with the given key.
+func ConfigDescriptionID(key string) string {
+	return "config." + messageIDPart(key) + ".description"
+}
+
+//ConfigValueDisplayNameID returns the message id for the display name of
+//val for the config option with the given key.
+func ConfigValueDisplayNameID(key, val string) string {
+	return "config." + messageIDPart(key) + ".values." + messageIDPart(val) + ".name"
+}
+
+//ConfigValueDescriptionID returns the message id for the description of val
+//for the config option with the given key.
+func ConfigValueDescriptionID(key, val string) string {
+	return "config." + messageIDPart(key) + ".values." + messageIDPart(val) + ".description"
+}
+
+//EnumValueID returns the message id for the display name of a value in the
+//enum with the given name. value is the value's (English) string, as
+//returned by the enum's String method.
+func EnumValueID(enumName, value string) string {
+	return "enum." + messageIDPart(enumName) + "." + messageIDPart(value)
+}
@@ -0,0 +1,114 @@
+package i18n
+
+import (
+	"github.com/jkomoros/boardgame/errors"
+	"github.com/workfit/tester/assert"
+	"strings"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+
+	catalog := NewCatalog("en").AddMessages("fr", map[string]string{
+		"greeting": "Bonjour {name}",
+		"farewell": "Au revoir",
+	}).AddMessages("fr-CA", map[string]string{
+		"greeting": "Allo {name}",
+	}).Add("en", "farewell", "Goodbye")
+
+	tests := []struct {
+		description string
+		locale      string
+		id          string
+		expected    string
+	}{
+		{
+			"Exact match",
+			"fr",
+			"greeting",
+			"Bonjour Alice",
+		},
+		{
+			"Regional match",
+			"fr_CA",
+			"greeting",
+			"Allo Alice",
+		},
+		{
+			"Regional falls back on base",
+			"fr-CA",
+			"farewell",
+			"Au revoir",
+		},
+		{
+			"Unknown locale falls back on default",
+			"de",
+			"farewell",
+			"Goodbye",
+		},
+		{
+			"Missing message falls back on literal",
+			"de",
+			"greeting",
+			"Hello Alice",
+		},
+	}
+
+	for i, test := range tests {
+		assert.For(t, i, test.description).ThatActual(catalog.Translate(test.locale, test.id, "Hello {name}", Params{"name": "Alice"})).Equals(test.expected)
+	}
+
+	assert.For(t).ThatActual(catalog.Locales()).Equals([]string{"en", "fr", "fr-ca"})
+
+	var nilCatalog *Catalog
+
+	assert.For(t).ThatActual(nilCatalog.Translate("fr", "greeting", "Hello {name}", Params{"name": "Bob"})).Equals("Hello Bob")
+
+	loaded := NewCatalog("")
+
+	assert.For(t).ThatActual(loaded.LoadJSON("es", strings.NewReader(`{"greeting": "Hola {name}"}`))).IsNil()
+	assert.For(t).ThatActual(loaded.LoadJSON("es", strings.NewReader(`not json`))).IsNotNil()
+
+	catalog.Merge(loaded)
+
+	assert.For(t).ThatActual(catalog.Translate("es", "greeting", "", Params{"name": "Carol"})).Equals("Hola Carol")
+
+}
+
+func TestTranslateError(t *testing.T) {
+
+	catalog := NewCatalog("en").Add("fr", "illegal_phase", "Coup illégal dans la phase {phase}")
+
+	err := NewError("illegal_phase", "Move is not legal in phase {phase}", Params{"phase": "Draw"})
+
+	assert.For(t).ThatActual(err.FriendlyError()).Equals("Move is not legal in phase Draw")
+	assert.For(t).ThatActual(catalog.TranslateError("fr", err)).Equals("Coup illégal dans la phase Draw")
+	assert.For(t).ThatActual(catalog.TranslateError("en", err)).Equals("Move is not legal in phase Draw")
+	assert.For(t).ThatActual(catalog.TranslateError("fr", errors.NewFriendly("Plain"))).Equals("Plain")
+
+}
+
+func TestNegotiate(t *testing.T) {
+
+	available := []string{"en", "fr", "pt-BR"}
+
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"fr-CH, fr;q=0.9, en;q=0.8", "fr"},
+		{"de, en;q=0.5", "en"},
+		{"en;q=0.2, fr;q=0.7", "fr"},
+		{"pt", "pt-BR"},
+		{"de, *;q=0.1", "en"},
+		{"fr;q=0, de", "en"},
+		{"", "en"},
+	}
+
+	for i, test := range tests {
+		assert.For(t, i, test.header).ThatActual(Negotiate(test.header, available, "en")).Equals(test.expected)
+	}
+
+	assert.For(t).ThatActual(ParseAcceptLanguage("en-US;q=0.5, FR")).Equals([]string{"fr", "en-us"})
+
+}
@@ -0,0 +1,118 @@
+package boardgame
+
+import (
+	"github.com/jkomoros/boardgame/enum"
+	"github.com/jkomoros/boardgame/i18n"
+)
+
+//DescriptionMessager is an optional interface that a Move may implement to
+//make its Description translatable. DescriptionMessage returns the id of the
+//description's message in the game's catalog, and the params to interpolate
+//into it; Description should still return the English version with the
+//params already interpolated. Moves that don't implement it are looked up
+//under i18n.MoveDescriptionID, with no params.
+type DescriptionMessager interface {
+	DescriptionMessage() (id string, params i18n.Params)
+}
+
+//Localizer returns the user-visible text of a game in one locale. Get one
+//from GameManager.Localizer. Any message missing from the game's catalog
+//falls back on the English text from the code.
+type Localizer struct {
+	manager *GameManager
+	locale  string
+}
+
+//Messages returns the message catalog returned by the delegate's Messages
+//method, which may be nil.
+func (g *GameManager) Messages() *i18n.Catalog {
+	return g.messages
+}
+
+//Localizer returns a Localizer for the given locale, e.g. "fr-CA".
+func (g *GameManager) Localizer(locale string) *Localizer {
+	return &Localizer{
+		manager: g,
+		locale:  locale,
+	}
+}
+
+//Locale returns the locale that this Localizer translates into.
+func (l *Localizer) Locale() string {
+	return l.locale
+}
+
+//Translate returns the message for id, or fallback if there isn't one, with
+//params interpolated.
+func (l *Localizer) Translate(id, fallback string, params i18n.Params) string {
+	return l.manager.messages.Translate(l.locale, id, fallback, params)
+}
+
+//HelpText returns the move type's HelpText in this locale.
+func (l *Localizer) HelpText(moveType *MoveType) string {
+	return l.Translate(i18n.MoveHelpTextID(moveType.Name()), moveType.HelpText(), nil)
+}
+
+//MoveDescription returns the move's Description in this locale. If the move
+//implements DescriptionMessager that message is used. Otherwise, the
+//message at i18n.MoveDescriptionID is used, unless the move's description is
+//just its type's help text, in which case the help text is translated.
+func (l *Localizer) MoveDescription(move Move) string {
+	if messager, ok := move.(DescriptionMessager); ok {
+		id, params := messager.DescriptionMessage()
+		return l.Translate(id, move.Description(), params)
+	}
+	moveType := move.Info().Type()
+	description := move.Description()
+	if description == moveType.HelpText() {
+		if _, ok := l.manager.messages.Lookup(l.locale, i18n.MoveDescriptionID(moveType.Name())); !ok {
+			return l.HelpText(moveType)
+		}
+	}
+	return l.Translate(i18n.MoveDescriptionID(moveType.Name()), description, nil)
+}
+
+//ConfigOption returns the option's display name and description in this
+//locale.
+func (l *Localizer) ConfigOption(option *ConfigOption) (displayName, description string) {
+	displayName = l.Translate(i18n.ConfigDisplayNameID(option.Key), option.DisplayName, nil)
+	description = l.Translate(i18n.ConfigDescriptionID(option.Key), option.Description, nil)
+	return displayName, description
+}
+
+//ConfigValue returns the display name and description of val for the config
+//key in this locale, falling back on the delegate's ConfigValueDisplay.
+func (l *Localizer) ConfigValue(key, val string) (displayName, description string) {
+	displayName, description = l.manager.Delegate().ConfigValueDisplay(key, val)
+	displayName = l.Translate(i18n.ConfigValueDisplayNameID(key, val), displayName, nil)
+	description = l.Translate(i18n.ConfigValueDescriptionID(key, val), description, nil)
+	return displayName, description
+}
+
+//EnumValue returns the display name of val in e in this locale.
+func (l *Localizer) EnumValue(e enum.Enum, val int) string {
+	str := e.String(val)
+	return l.Translate(i18n.EnumValueID(e.Name(), str), str, nil)
+}
+
+//Enums returns the display name of every value of every enum in set in this
+//locale, keyed by enum name and then by the value's string, which is how
+//enum values appear in a state's JSON.
+func (l *Localizer) Enums(set *enum.Set) map[string]map[string]string {
+	result := make(map[string]map[string]string)
+	for _, name := range set.EnumNames() {
+		e := set.Enum(name)
+		values := make(map[string]string)
+		for _, val := range e.Values() {
+			values[e.String(val)] = l.EnumValue(e, val)
+		}
+		result[name] = values
+	}
+	return result
+}
+
+//Error returns the user-visible message for err in this locale. See
+//i18n.Catalog.TranslateError.
+func (l *Localizer) Error(err error) string {
+	return l.manager.messages.TranslateError(l.locale, err)
+}
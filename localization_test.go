@@ -0,0 +1,89 @@
+package boardgame
+
+import (
+	"github.com/jkomoros/boardgame/i18n"
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+type localizedGameDelegate struct {
+	testGameDelegate
+}
+
+func (l *localizedGameDelegate) Messages() *i18n.Catalog {
+	return i18n.NewCatalog("en").AddMessages("fr", map[string]string{
+		i18n.MoveHelpTextID("Draw Card"):              "Pioche une carte de la pioche.",
+		i18n.EnumValueID("color", "Red"):              "Rouge",
+		i18n.ConfigDisplayNameID("color"):             "Couleur",
+		i18n.ConfigValueDisplayNameID("color", "red"): "Rouge",
+		"test.illegal": "Coup illégal: {reason}",
+	})
+}
+
+func (l *localizedGameDelegate) Configs() map[string][]string {
+	return map[string][]string{
+		"color": {"red", "blue"},
+	}
+}
+
+func TestLocalizer(t *testing.T) {
+
+	delegate := &localizedGameDelegate{
+		testGameDelegate{moveInstaller: func(manager *GameManager) *MoveTypeConfigBundle {
+			return NewMoveTypeConfigBundle().AddMoves(
+				&testMoveConfig,
+				&testMoveDrawCardConfig,
+			)
+		}},
+	}
+
+	manager, err := NewGameManager(delegate, newTestGameChest(), newTestStorageManager())
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	fr := manager.Localizer("fr-CA")
+	de := manager.Localizer("de")
+
+	drawCard := manager.PlayerMoveTypeByName("Draw Card")
+
+	assert.For(t).ThatActual(fr.Locale()).Equals("fr-CA")
+	assert.For(t).ThatActual(fr.HelpText(drawCard)).Equals("Pioche une carte de la pioche.")
+	assert.For(t).ThatActual(de.HelpText(drawCard)).Equals(drawCard.HelpText())
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	move := game.PlayerMoveByName("Draw Card")
+
+	assert.For(t).ThatActual(fr.MoveDescription(move)).Equals("Pioche une carte de la pioche.")
+
+	assert.For(t).ThatActual(fr.EnumValue(testColorEnum, colorRed)).Equals("Rouge")
+	assert.For(t).ThatActual(de.EnumValue(testColorEnum, colorRed)).Equals("Red")
+
+	assert.For(t).ThatActual(fr.Enums(manager.Chest().Enums())["color"]).Equals(map[string]string{
+		"Red":   "Rouge",
+		"Blue":  "Blue",
+		"Green": "Green",
+	})
+
+	option := manager.Delegate().ConfigSchema().Option("color")
+
+	displayName, _ := fr.ConfigOption(option)
+
+	assert.For(t).ThatActual(displayName).Equals("Couleur")
+
+	valueName, _ := fr.ConfigValue("color", "red")
+
+	assert.For(t).ThatActual(valueName).Equals("Rouge")
+
+	valueName, _ = fr.ConfigValue("color", "blue")
+
+	assert.For(t).ThatActual(valueName).Equals("blue")
+
+	illegal := i18n.NewError("test.illegal", "Illegal move: {reason}", i18n.Params{"reason": "no"})
+
+	assert.For(t).ThatActual(fr.Error(illegal)).Equals("Coup illégal: no")
+	assert.For(t).ThatActual(de.Error(illegal)).Equals("Illegal move: no")
+
+}
@@ -0,0 +1,476 @@
+package boardgame
+
+import (
+	"github.com/jkomoros/boardgame/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//ConfigKeyMatchGame is set in the GameConfig of every game that is part of a
+//Match to the index of the game within the match, starting at 0.
+const ConfigKeyMatchGame = "match-game"
+
+//ConfigKeyMatchSeatRotation is set in the GameConfig of every game that is
+//part of a Match to how many seats play has rotated since the first game of
+//the match: the game index modulo the number of players. Players keep the
+//same PlayerIndex for the whole match, so delegates that want the starting
+//player or dealer to move around the table should offset it by this amount
+//in BeginSetUp.
+const ConfigKeyMatchSeatRotation = "match-seat-rotation"
+
+//matchConfigKeys are the keys that Match adds to each game's config. They
+//aren't part of any delegate's ConfigSchema, so Validate skips them.
+var matchConfigKeys = map[string]bool{
+	ConfigKeyMatchGame:         true,
+	ConfigKeyMatchSeatRotation: true,
+}
+
+const matchIDLength = 16
+
+//ErrMatchNotFound should be returned, possibly with a more specific message
+//set via WithError, by StorageManagers when the requested match doesn't
+//exist.
+var ErrMatchNotFound = errors.New("No such match").WithCode(CodeMatchNotFound, errors.NotFound)
+
+//MatchPolicy decides when a Match is over. Exactly one of its fields should
+//be non-zero. If your delegate needs a different rule, implement
+//MatchFinisher.
+type MatchPolicy struct {
+	//BestOf ends the match as soon as a player has won more than half of
+	//this many games, or once this many games have been played. The player
+	//with the most wins wins the match.
+	BestOf int `json:",omitempty"`
+	//Games ends the match after exactly this many games. The players with
+	//the highest cumulative score win the match.
+	Games int `json:",omitempty"`
+	//TargetScore ends the match after the first game in which any player's
+	//cumulative score reaches this many points. The players with the highest
+	//cumulative score win the match.
+	TargetScore int `json:",omitempty"`
+}
+
+//Valid returns an error if the policy doesn't describe exactly one way for
+//the match to end.
+func (m MatchPolicy) Valid() error {
+	set := 0
+	for _, val := range []int{m.BestOf, m.Games, m.TargetScore} {
+		if val < 0 {
+			return errors.New("Match policy values must not be negative")
+		}
+		if val > 0 {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("Exactly one of BestOf, Games, or TargetScore must be set on a match policy")
+	}
+	return nil
+}
+
+//MatchFinished returns whether the match is over, and if so, who won it.
+func (m MatchPolicy) MatchFinished(match *Match) (finished bool, winners []PlayerIndex) {
+
+	numGames := len(match.GameIds())
+
+	switch {
+	case m.BestOf > 0:
+		wins := match.Wins()
+		for _, count := range wins {
+			if count > m.BestOf/2 {
+				return true, leaders(wins)
+			}
+		}
+		if numGames >= m.BestOf {
+			return true, leaders(wins)
+		}
+	case m.Games > 0:
+		if numGames >= m.Games {
+			return true, leaders(match.Scores())
+		}
+	case m.TargetScore > 0:
+		scores := match.Scores()
+		for _, score := range scores {
+			if score >= m.TargetScore {
+				return true, leaders(scores)
+			}
+		}
+	}
+
+	return false, nil
+}
+
+//leaders returns the indexes of the highest values in vals.
+func leaders(vals []int) []PlayerIndex {
+	var result []PlayerIndex
+	for i, val := range vals {
+		if len(result) > 0 {
+			best := vals[result[0]]
+			if val < best {
+				continue
+			}
+			if val > best {
+				result = nil
+			}
+		}
+		result = append(result, PlayerIndex(i))
+	}
+	return result
+}
+
+//MatchFinisher may be implemented by a GameDelegate to decide when its
+//matches are over instead of the match's MatchPolicy, for example for
+//rubber bridge, where a rubber is over once a side has won two games.
+type MatchFinisher interface {
+	//CheckMatchFinished is called after each game of the match finishes and
+	//its result has been added to the match's Scores and Wins.
+	CheckMatchFinished(match *Match) (finished bool, winners []PlayerIndex)
+}
+
+//MatchStorageRecord is the record of a Match that is written to storage.
+type MatchStorageRecord struct {
+	Id string
+	//Name is the type of the games in the match, from their manager.
+	Name       string
+	NumPlayers int
+	Agents     []string
+	//Config is the config passed to every game in the match, before
+	//ConfigKeyMatchGame and ConfigKeyMatchSeatRotation are added.
+	Config GameConfig
+	Policy MatchPolicy
+	//Games is the ids of the games in the match, in the order they were
+	//played. The last one is the game currently being played, unless the
+	//match is Finished.
+	Games []string
+	//Scores is each player's cumulative score across the finished games.
+	Scores []int
+	//Wins is how many finished games each player has won.
+	Wins     []int
+	Finished bool
+	Winners  []PlayerIndex
+	Created  time.Time
+}
+
+//A Match is an ordered series of games of one manager, played by the same
+//players in the same seats, like a best-of-three of tictactoe or a rubber of
+//bridge. Each game's result is added to the match's cumulative Scores and
+//Wins when it finishes, and then either the next game is set up
+//automatically or the match finishes, as decided by the match's
+//MatchPolicy (or the delegate, if it implements MatchFinisher). Create one
+//with GameManager.NewMatch.
+type Match struct {
+	manager *GameManager
+	record  *MatchStorageRecord
+}
+
+//NewMatch creates a match and sets up its first game. numPlayers, config
+//and agentNames are treated as in Game.SetUp and are used for every game in
+//the match.
+func (g *GameManager) NewMatch(numPlayers int, config GameConfig, agentNames []string, policy MatchPolicy) (*Match, error) {
+
+	baseErr := errors.NewFriendly("Match couldn't be created")
+
+	if err := policy.Valid(); err != nil {
+		return nil, baseErr.WithError(err.Error())
+	}
+
+	if numPlayers == 0 {
+		numPlayers = g.Delegate().DefaultNumPlayers()
+	}
+
+	if config == nil {
+		config = GameConfig{}
+	}
+
+	for key := range config {
+		if matchConfigKeys[key] {
+			return nil, baseErr.WithError("The config may not set " + key + "; it is set by the match")
+		}
+	}
+
+	record := &MatchStorageRecord{
+		Id:         randomString(matchIDLength),
+		Name:       g.Delegate().Name(),
+		NumPlayers: numPlayers,
+		Agents:     agentNames,
+		Config:     config,
+		Policy:     policy,
+		Scores:     make([]int, numPlayers),
+		Wins:       make([]int, numPlayers),
+		Created:    time.Now(),
+	}
+
+	match := &Match{
+		manager: g,
+		record:  record,
+	}
+
+	if err := match.startNextGame(); err != nil {
+		return nil, errors.Extend(err, "Couldn't start the first game")
+	}
+
+	return match, nil
+}
+
+//Match fetches the match with the given id from storage, or nil if it
+//doesn't exist or is for a different type of game. If the match's current
+//game has finished but the match wasn't updated, for example because
+//storage failed at the time, it's updated now.
+func (g *GameManager) Match(id string) *Match {
+	record, err := g.storage.Match(id)
+
+	if err != nil {
+		return nil
+	}
+
+	if record.Name != g.Delegate().Name() {
+		return nil
+	}
+
+	match := &Match{
+		manager: g,
+		record:  record,
+	}
+
+	match.catchUp()
+
+	return match
+}
+
+//catchUp counts the current game's result, and finishes the match or starts
+//its next game, if the current game is finished but that hasn't happened
+//yet. Errors are logged, since the next load or Refresh will try again.
+func (m *Match) catchUp() {
+
+	if m.record.Finished || len(m.record.Games) == 0 {
+		return
+	}
+
+	game := m.manager.Game(m.record.Games[len(m.record.Games)-1])
+
+	if game == nil || !game.Finished() {
+		return
+	}
+
+	if err := m.manager.matchGameFinished(game); err != nil {
+		m.manager.Logger().Error("Couldn't update match " + m.Id() + ": " + err.Error())
+		return
+	}
+
+	if record, err := m.manager.storage.Match(m.Id()); err == nil {
+		m.record = record
+	}
+}
+
+//startNextGame sets up the next game of the match and saves the match with
+//it added.
+func (m *Match) startNextGame() error {
+
+	index := len(m.record.Games)
+
+	config := make(GameConfig, len(m.record.Config)+len(matchConfigKeys))
+	for key, val := range m.record.Config {
+		config[key] = val
+	}
+	config[ConfigKeyMatchGame] = strconv.Itoa(index)
+	config[ConfigKeyMatchSeatRotation] = strconv.Itoa(index % m.record.NumPlayers)
+
+	game := m.manager.NewGame()
+
+	if game == nil {
+		return errors.New("The manager couldn't create a new game")
+	}
+
+	game.match = m.Id()
+
+	//The game has to be in the record before it's set up, because it could
+	//finish during SetUp, which would look it up.
+	m.record.Games = append(m.record.Games, game.Id())
+
+	if err := m.manager.storage.SaveMatch(m.record); err != nil {
+		m.record.Games = m.record.Games[:index]
+		return errors.New("Couldn't save match: " + err.Error())
+	}
+
+	if err := game.SetUp(m.record.NumPlayers, config, m.record.Agents); err != nil {
+		m.record.Games = m.record.Games[:index]
+		//The stored record points to a game that was never saved; put it
+		//back the way it was.
+		m.manager.storage.SaveMatch(m.record)
+		return err
+	}
+
+	return nil
+}
+
+//matchGameFinished is called by a game that is part of a match once it has
+//finished and been saved. It adds the game's result to the match and then
+//either finishes the match or starts its next game.
+func (g *GameManager) matchGameFinished(game *Game) error {
+
+	record, err := g.storage.Match(game.MatchId())
+
+	if err != nil {
+		return err
+	}
+
+	if record.Finished || len(record.Games) == 0 || !strings.EqualFold(record.Games[len(record.Games)-1], game.Id()) {
+		//The game isn't the match's current one, so its result has already
+		//been counted.
+		return nil
+	}
+
+	match := &Match{
+		manager: g,
+		record:  record,
+	}
+
+	//If the next game can't be started, the match is put back the way it
+	//was, so that retrying doesn't count this game twice.
+	previous := *record
+	previous.Games = append([]string(nil), record.Games...)
+	previous.Scores = append([]int(nil), record.Scores...)
+	previous.Wins = append([]int(nil), record.Wins...)
+
+	if result := game.Result(); result != nil {
+		for _, player := range result.Players {
+			if int(player.Player) >= len(record.Scores) {
+				continue
+			}
+			record.Scores[player.Player] += player.Score
+		}
+	}
+
+	for _, winner := range game.Winners() {
+		if int(winner) >= len(record.Wins) {
+			continue
+		}
+		record.Wins[winner]++
+	}
+
+	var finished bool
+	var winners []PlayerIndex
+
+	if finisher, ok := g.Delegate().(MatchFinisher); ok {
+		finished, winners = finisher.CheckMatchFinished(match)
+	} else {
+		finished, winners = record.Policy.MatchFinished(match)
+	}
+
+	if finished {
+		record.Finished = true
+		record.Winners = winners
+		return g.storage.SaveMatch(record)
+	}
+
+	if err := match.startNextGame(); err != nil {
+		if saveErr := g.storage.SaveMatch(&previous); saveErr != nil {
+			g.Logger().Error("Couldn't put match " + record.Id + " back after failing to start its next game: " + saveErr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+//Id returns the unique id of the match.
+func (m *Match) Id() string {
+	return m.record.Id
+}
+
+//Manager returns the manager of the match's games.
+func (m *Match) Manager() *GameManager {
+	return m.manager
+}
+
+//NumPlayers returns the number of players in every game of the match.
+func (m *Match) NumPlayers() int {
+	return m.record.NumPlayers
+}
+
+//Agents returns the agents passed to NewMatch.
+func (m *Match) Agents() []string {
+	return m.record.Agents
+}
+
+//Config returns the config passed to NewMatch, which every game is set up
+//with (along with ConfigKeyMatchGame and ConfigKeyMatchSeatRotation).
+func (m *Match) Config() GameConfig {
+	return m.record.Config
+}
+
+//Policy returns the policy passed to NewMatch.
+func (m *Match) Policy() MatchPolicy {
+	return m.record.Policy
+}
+
+//Created returns when the match was created.
+func (m *Match) Created() time.Time {
+	return m.record.Created
+}
+
+//GameIds returns the ids of the match's games, in the order they were
+//played.
+func (m *Match) GameIds() []string {
+	return m.record.Games
+}
+
+//Games returns non-modifiable copies of the match's games, in the order they
+//were played.
+func (m *Match) Games() []*Game {
+	result := make([]*Game, 0, len(m.record.Games))
+	for _, id := range m.record.Games {
+		if game := m.manager.Game(id); game != nil {
+			result = append(result, game)
+		}
+	}
+	return result
+}
+
+//CurrentGame returns a modifiable copy of the game currently being played,
+//or nil if the match is finished.
+func (m *Match) CurrentGame() *Game {
+	if m.Finished() || len(m.record.Games) == 0 {
+		return nil
+	}
+	return m.manager.ModifiableGame(m.record.Games[len(m.record.Games)-1])
+}
+
+//Scores returns each player's cumulative score across the finished games of
+//the match, from each game's GameResult.
+func (m *Match) Scores() []int {
+	return m.record.Scores
+}
+
+//Wins returns how many of the finished games of the match each player has
+//won.
+func (m *Match) Wins() []int {
+	return m.record.Wins
+}
+
+//Finished returns whether the match is over.
+func (m *Match) Finished() bool {
+	return m.record.Finished
+}
+
+//Winners returns the winners of the match, once it's finished.
+func (m *Match) Winners() []PlayerIndex {
+	return m.record.Winners
+}
+
+//StorageRecord returns the record of the match that is saved to storage.
+func (m *Match) StorageRecord() *MatchStorageRecord {
+	return m.record
+}
+
+//Refresh reloads the match from storage, to pick up games that have
+//finished or started since it was fetched. Like GameManager.Match, it
+//updates the match if its current game finished without that happening.
+func (m *Match) Refresh() {
+	record, err := m.manager.storage.Match(m.Id())
+	if err != nil {
+		return
+	}
+	m.record = record
+	m.catchUp()
+}
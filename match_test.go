@@ -0,0 +1,286 @@
+package boardgame
+
+import (
+	"errors"
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+type matchGameDelegate struct {
+	testGameDelegate
+	//failLaterGames makes every game after a match's first one fail to be
+	//set up.
+	failLaterGames bool
+}
+
+func (m *matchGameDelegate) LegalConfig(config GameConfig) error {
+	if index, err := config.Int(ConfigKeyMatchGame); err == nil && index > 0 && m.failLaterGames {
+		return errors.New("Later games are turned off")
+	}
+	return m.testGameDelegate.LegalConfig(config)
+}
+
+func (m *matchGameDelegate) PlayerScore(pState PlayerState) int {
+	return pState.(*testPlayerState).Score
+}
+
+//BeginSetUp has the first player in each game of a match move around the
+//table.
+func (m *matchGameDelegate) BeginSetUp(state MutableState, config GameConfig) error {
+	if err := m.testGameDelegate.BeginSetUp(state, config); err != nil {
+		return err
+	}
+
+	rotation, err := config.Int(ConfigKeyMatchSeatRotation)
+
+	if err != nil {
+		return nil
+	}
+
+	game, _ := concreteStates(state)
+	game.CurrentPlayer = PlayerIndex(rotation)
+
+	return nil
+}
+
+func newTestMatchManager(t *testing.T) *GameManager {
+	manager, err := NewGameManager(&matchGameDelegate{
+		testGameDelegate: testGameDelegate{moveInstaller: func(manager *GameManager) *MoveTypeConfigBundle {
+			return NewMoveTypeConfigBundle().AddMoves(
+				&testMoveConfig,
+			)
+		}},
+	}, newTestGameChest(), newTestStorageManager())
+
+	if err != nil {
+		t.Fatal("Couldn't create manager: " + err.Error())
+	}
+
+	return manager
+}
+
+//winCurrentGame has the current player of the match's current game score
+//enough points to win it.
+func winCurrentGame(match *Match, score int, t *testing.T) PlayerIndex {
+	game := match.CurrentGame()
+
+	assert.For(t).ThatActual(game).IsNotNil()
+
+	player := game.CurrentState().CurrentPlayerIndex()
+
+	move := game.PlayerMoveByName("Test").(*testMove)
+
+	move.AString = "foo"
+	move.ScoreIncrement = score
+	move.TargetPlayerIndex = player
+	move.ABool = true
+
+	assert.For(t).ThatActual(<-game.ProposeMove(move, AdminPlayerIndex)).IsNil()
+	assert.For(t).ThatActual(game.Finished()).IsTrue()
+
+	match.Refresh()
+
+	return player
+}
+
+func TestMatch(t *testing.T) {
+
+	manager := newTestMatchManager(t)
+
+	_, err := manager.NewMatch(0, nil, nil, MatchPolicy{})
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+	_, err = manager.NewMatch(0, GameConfig{ConfigKeyMatchGame: "3"}, nil, MatchPolicy{BestOf: 3})
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+	match, err := manager.NewMatch(0, GameConfig{"color": "red"}, nil, MatchPolicy{BestOf: 3})
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(match.NumPlayers()).Equals(3)
+	assert.For(t).ThatActual(len(match.GameIds())).Equals(1)
+
+	first := match.CurrentGame()
+
+	assert.For(t).ThatActual(first.MatchId()).Equals(match.Id())
+	assert.For(t).ThatActual(first.CurrentState().CurrentPlayerIndex()).Equals(PlayerIndex(0))
+
+	assert.For(t).ThatActual(winCurrentGame(match, 6, t)).Equals(PlayerIndex(0))
+
+	assert.For(t).ThatActual(match.Finished()).IsFalse()
+	assert.For(t).ThatActual(len(match.GameIds())).Equals(2)
+	assert.For(t).ThatActual(match.Wins()).Equals([]int{1, 0, 0})
+	assert.For(t).ThatActual(match.Scores()).Equals([]int{6, 0, 0})
+
+	second := match.CurrentGame()
+
+	assert.For(t).ThatActual(second.Id()).Equals(match.GameIds()[1])
+	assert.For(t).ThatActual(second.MatchId()).Equals(match.Id())
+	assert.For(t).ThatActual(second.CurrentState().CurrentPlayerIndex()).Equals(PlayerIndex(1))
+
+	assert.For(t).ThatActual(winCurrentGame(match, 7, t)).Equals(PlayerIndex(1))
+
+	assert.For(t).ThatActual(match.Wins()).Equals([]int{1, 1, 0})
+	assert.For(t).ThatActual(match.Scores()).Equals([]int{6, 7, 0})
+
+	assert.For(t).ThatActual(match.CurrentGame().CurrentState().CurrentPlayerIndex()).Equals(PlayerIndex(2))
+
+	assert.For(t).ThatActual(winCurrentGame(match, 5, t)).Equals(PlayerIndex(2))
+
+	assert.For(t).ThatActual(match.Finished()).IsTrue()
+	assert.For(t).ThatActual(match.Winners()).Equals([]PlayerIndex{0, 1, 2})
+	assert.For(t).ThatActual(match.CurrentGame()).IsNil()
+	assert.For(t).ThatActual(len(match.Games())).Equals(3)
+
+	refetched := manager.Match(match.Id())
+
+	assert.For(t).ThatActual(refetched).IsNotNil()
+	assert.For(t).ThatActual(refetched.StorageRecord()).Equals(match.StorageRecord())
+
+	assert.For(t).ThatActual(manager.Match("missing")).IsNil()
+
+	_, err = manager.Storage().Match("missing")
+
+	assert.For(t).ThatActual(err).Equals(ErrMatchNotFound)
+
+}
+
+func TestMatchRetriesAdvancement(t *testing.T) {
+
+	manager := newTestMatchManager(t)
+
+	delegate := manager.Delegate().(*matchGameDelegate)
+
+	match, err := manager.NewMatch(0, nil, nil, MatchPolicy{BestOf: 3})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	delegate.failLaterGames = true
+
+	//The move that finishes the game succeeds even though the next game
+	//can't be started.
+	winCurrentGame(match, 6, t)
+
+	assert.For(t).ThatActual(len(match.GameIds())).Equals(1)
+	assert.For(t).ThatActual(match.Wins()).Equals([]int{0, 0, 0})
+	assert.For(t).ThatActual(match.Scores()).Equals([]int{0, 0, 0})
+
+	//Refreshing while it still fails doesn't count the game twice.
+	match.Refresh()
+
+	assert.For(t).ThatActual(len(match.GameIds())).Equals(1)
+	assert.For(t).ThatActual(match.Scores()).Equals([]int{0, 0, 0})
+
+	delegate.failLaterGames = false
+
+	refetched := manager.Match(match.Id())
+
+	assert.For(t).ThatActual(refetched).IsNotNil()
+	assert.For(t).ThatActual(len(refetched.GameIds())).Equals(2)
+	assert.For(t).ThatActual(refetched.Wins()).Equals([]int{1, 0, 0})
+	assert.For(t).ThatActual(refetched.Scores()).Equals([]int{6, 0, 0})
+
+	match.Refresh()
+
+	assert.For(t).ThatActual(match.StorageRecord()).Equals(refetched.StorageRecord())
+
+}
+
+func TestMatchPolicy(t *testing.T) {
+
+	tests := []struct {
+		description      string
+		policy           MatchPolicy
+		games            int
+		scores           []int
+		wins             []int
+		expectedFinished bool
+		expectedWinners  []PlayerIndex
+	}{
+		{
+			"Best of three after one game",
+			MatchPolicy{BestOf: 3},
+			1,
+			[]int{5, 0},
+			[]int{1, 0},
+			false,
+			nil,
+		},
+		{
+			"Best of three after two wins",
+			MatchPolicy{BestOf: 3},
+			2,
+			[]int{5, 9},
+			[]int{2, 0},
+			true,
+			[]PlayerIndex{0},
+		},
+		{
+			"Best of three with draws",
+			MatchPolicy{BestOf: 3},
+			3,
+			[]int{5, 5},
+			[]int{1, 1},
+			true,
+			[]PlayerIndex{0, 1},
+		},
+		{
+			"Fixed games not yet played",
+			MatchPolicy{Games: 4},
+			3,
+			[]int{20, 3},
+			[]int{3, 0},
+			false,
+			nil,
+		},
+		{
+			"Fixed games played",
+			MatchPolicy{Games: 4},
+			4,
+			[]int{20, 23},
+			[]int{3, 1},
+			true,
+			[]PlayerIndex{1},
+		},
+		{
+			"Target score not reached",
+			MatchPolicy{TargetScore: 100},
+			5,
+			[]int{90, 99},
+			[]int{3, 2},
+			false,
+			nil,
+		},
+		{
+			"Target score reached",
+			MatchPolicy{TargetScore: 100},
+			6,
+			[]int{110, 104, 20},
+			[]int{3, 2, 1},
+			true,
+			[]PlayerIndex{0},
+		},
+	}
+
+	for i, test := range tests {
+		match := &Match{
+			record: &MatchStorageRecord{
+				Policy: test.policy,
+				Games:  make([]string, test.games),
+				Scores: test.scores,
+				Wins:   test.wins,
+			},
+		}
+
+		finished, winners := test.policy.MatchFinished(match)
+
+		assert.For(t, i, test.description).ThatActual(finished).Equals(test.expectedFinished)
+		assert.For(t, i, test.description).ThatActual(winners).Equals(test.expectedWinners)
+	}
+
+	assert.For(t).ThatActual(MatchPolicy{BestOf: 3, Games: 2}.Valid()).IsNotNil()
+	assert.For(t).ThatActual(MatchPolicy{Games: -1}.Valid()).IsNotNil()
+	assert.For(t).ThatActual(MatchPolicy{TargetScore: 10}.Valid()).IsNil()
+
+}
@@ -18,6 +18,7 @@ type MoveType struct {
 	legalPhases []int
 	isFixUp     bool
 	validator   *readerValidator
+	constraints map[string]*FieldConstraint
 	manager     *GameManager
 }
 
@@ -97,6 +98,12 @@ func (m *MoveTypeConfig) NewMoveType(manager *GameManager) (*MoveType, error) {
 		return nil, errors.New("Couldn't create validator: " + err.Error())
 	}
 
+	constraints, err := moveFieldConstraints(exampleMove, manager.Chest())
+
+	if err != nil {
+		return nil, errors.New("Couldn't parse field constraints: " + err.Error())
+	}
+
 	return &MoveType{
 		name:        m.Name,
 		helpText:    m.HelpText,
@@ -104,6 +111,7 @@ func (m *MoveTypeConfig) NewMoveType(manager *GameManager) (*MoveType, error) {
 		isFixUp:     m.IsFixUp,
 		legalPhases: m.LegalPhases,
 		validator:   validator,
+		constraints: constraints,
 		manager:     manager,
 	}, nil
 
@@ -0,0 +1,464 @@
+package boardgame
+
+import (
+	"github.com/jkomoros/boardgame/enum"
+	"github.com/jkomoros/boardgame/errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const constraintStructTag = "constraint"
+
+//PlayerFilter is a set of restrictions on which players a PlayerIndex field
+//on a move may refer to. Combine them with |.
+type PlayerFilter int
+
+const (
+	//PlayerFilterPlayer requires the value to be a real player in the game
+	//(not AdminPlayerIndex or ObserverPlayerIndex).
+	PlayerFilterPlayer PlayerFilter = 1 << iota
+	//PlayerFilterSelf requires the value to be equivalent to the proposer.
+	PlayerFilterSelf
+	//PlayerFilterOpponent requires the value to be a real player who isn't
+	//equivalent to the proposer. When the AdminPlayerIndex proposes, any real
+	//player is allowed.
+	PlayerFilterOpponent
+	//PlayerFilterActive requires the value to be a player who has not been
+	//eliminated (see PlayerEliminator).
+	PlayerFilterActive
+)
+
+var playerFilterNames = map[PlayerFilter]string{
+	PlayerFilterPlayer:   "player",
+	PlayerFilterSelf:     "self",
+	PlayerFilterOpponent: "opponent",
+	PlayerFilterActive:   "active",
+}
+
+//Names returns the names of each filter in the set, in a stable order. These
+//are the same names that are used in struct tags.
+func (p PlayerFilter) Names() []string {
+	var result []string
+	for _, filter := range []PlayerFilter{PlayerFilterPlayer, PlayerFilterSelf, PlayerFilterOpponent, PlayerFilterActive} {
+		if p&filter != 0 {
+			result = append(result, playerFilterNames[filter])
+		}
+	}
+	return result
+}
+
+//FieldConstraint is a declarative restriction on the values a field on a
+//move may have. Constraints are checked automatically before a move's Legal
+//method is called, and are exported as part of MoveType.JSONSchema so
+//clients can present only legal choices. The easiest way to declare them is
+//with a `constraint` struct tag on the field, a comma-separated list of:
+//
+//	min=N         The value (or each item, for slices) must be at least N
+//	max=N         The value (or each item, for slices) must be at most N
+//	values=A|B|C  The value must be one of the listed values. For enum fields,
+//	              use the string values of the enum.
+//	slot=Stack    The value must be a valid index into the stack with that
+//	              name on the game state.
+//	player, self, opponent, active
+//	              For PlayerIndex fields, see the PlayerFilter constants.
+//
+//For example, `constraint:"min=0,max=8"` or `constraint:"opponent,active"`.
+//Moves may also implement MoveFieldConstrainer to declare constraints in
+//code.
+type FieldConstraint struct {
+	HasMin bool
+	Min    int
+	HasMax bool
+	Max    int
+	//Values, if not nil, is the set of legal values. For enum fields these
+	//are enum values.
+	Values []int
+	//Slot, if not "", is the name of a stack on the game state that the
+	//value must be a valid index into.
+	Slot    string
+	Players PlayerFilter
+}
+
+//MoveFieldConstrainer may be implemented by moves that want to declare field
+//constraints in code instead of (or in addition to) struct tags. The
+//constraints it returns take precedence over struct tags for the same field.
+//It is called once, on an example move, when the move type is created.
+type MoveFieldConstrainer interface {
+	FieldConstraints() map[string]*FieldConstraint
+}
+
+//parseFieldConstraint parses the value of a constraint struct tag.
+func parseFieldConstraint(tag string, propType PropertyType, enumVal enum.Enum) (*FieldConstraint, error) {
+	result := &FieldConstraint{}
+
+	for _, piece := range strings.Split(tag, ",") {
+		piece = strings.TrimSpace(piece)
+		if piece == "" {
+			continue
+		}
+
+		key := piece
+		value := ""
+
+		if i := strings.Index(piece, "="); i >= 0 {
+			key = strings.TrimSpace(piece[:i])
+			value = strings.TrimSpace(piece[i+1:])
+		}
+
+		switch key {
+		case "min", "max":
+			num, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, errors.New(key + " was not a valid int: " + value)
+			}
+			if key == "min" {
+				result.HasMin = true
+				result.Min = num
+			} else {
+				result.HasMax = true
+				result.Max = num
+			}
+		case "values":
+			for _, item := range strings.Split(value, "|") {
+				item = strings.TrimSpace(item)
+				if enumVal != nil {
+					num := enumVal.ValueFromString(item)
+					if num == enum.IllegalValue {
+						return nil, errors.New(item + " is not a value in enum " + enumVal.Name())
+					}
+					result.Values = append(result.Values, num)
+					continue
+				}
+				num, err := strconv.Atoi(item)
+				if err != nil {
+					return nil, errors.New("values had an item that was not a valid int: " + item)
+				}
+				result.Values = append(result.Values, num)
+			}
+		case "slot":
+			if value == "" {
+				return nil, errors.New("slot requires a stack name")
+			}
+			result.Slot = value
+		case "player":
+			result.Players |= PlayerFilterPlayer
+		case "self":
+			result.Players |= PlayerFilterSelf
+		case "opponent":
+			result.Players |= PlayerFilterOpponent
+		case "active":
+			result.Players |= PlayerFilterActive
+		default:
+			return nil, errors.New("Unknown constraint: " + key)
+		}
+	}
+
+	if err := result.validForType(propType); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+//validForType returns an error if the constraint can't be applied to a
+//field of the given type.
+func (f *FieldConstraint) validForType(propType PropertyType) error {
+	isPlayer := propType == TypePlayerIndex || propType == TypePlayerIndexSlice
+	isInt := propType == TypeInt || propType == TypeIntSlice
+
+	if f.Players != 0 && !isPlayer {
+		return errors.New("player filters may only be used on PlayerIndex fields")
+	}
+
+	if (f.HasMin || f.HasMax || f.Slot != "") && !isInt && !isPlayer {
+		return errors.New("min, max and slot may only be used on int and PlayerIndex fields")
+	}
+
+	if f.Values != nil && !isInt && !isPlayer && propType != TypeEnum {
+		return errors.New("values may only be used on int, PlayerIndex and enum fields")
+	}
+
+	return nil
+}
+
+//check returns an error if val is not allowed by the constraint.
+func (f *FieldConstraint) check(fieldName string, val int, state State, proposer PlayerIndex) error {
+
+	if f.HasMin && val < f.Min {
+		return errors.NewFriendly(fieldName + " must be at least " + strconv.Itoa(f.Min))
+	}
+
+	if f.HasMax && val > f.Max {
+		return errors.NewFriendly(fieldName + " must be at most " + strconv.Itoa(f.Max))
+	}
+
+	if f.Values != nil {
+		legal := false
+		for _, item := range f.Values {
+			if item == val {
+				legal = true
+				break
+			}
+		}
+		if !legal {
+			return errors.NewFriendly(fieldName + " was not one of the allowed values")
+		}
+	}
+
+	if f.Slot != "" {
+		stack, err := state.GameState().Reader().StackProp(f.Slot)
+		if err != nil || stack == nil {
+			return errors.New(fieldName + " must be a slot in " + f.Slot + ", but the game state has no such stack")
+		}
+		if val < 0 || val >= stack.Len() {
+			return errors.NewFriendly(fieldName + " must be a slot in " + f.Slot + " between 0 and " + strconv.Itoa(stack.Len()-1))
+		}
+	}
+
+	if f.Players == 0 {
+		return nil
+	}
+
+	player := PlayerIndex(val)
+
+	isRealPlayer := player >= 0 && int(player) < len(state.PlayerStates())
+
+	if f.Players&(PlayerFilterPlayer|PlayerFilterOpponent|PlayerFilterActive) != 0 && !isRealPlayer {
+		return errors.NewFriendly(fieldName + " must be a player in the game")
+	}
+
+	if f.Players&PlayerFilterSelf != 0 && !player.Equivalent(proposer) {
+		return errors.NewFriendly(fieldName + " must be the player proposing the move")
+	}
+
+	if f.Players&PlayerFilterOpponent != 0 && proposer != AdminPlayerIndex && player.Equivalent(proposer) {
+		return errors.NewFriendly(fieldName + " must be an opponent of the player proposing the move")
+	}
+
+	if f.Players&PlayerFilterActive != 0 && player.Eliminated(state) {
+		return errors.NewFriendly(fieldName + " must be a player who hasn't been eliminated")
+	}
+
+	return nil
+}
+
+//jsonSchema returns the JSON Schema for a field of the given type with this
+//constraint (which may be nil).
+func (f *FieldConstraint) jsonSchema(propType PropertyType, enumVal enum.Enum) map[string]interface{} {
+
+	item := make(map[string]interface{})
+
+	switch propType {
+	case TypeInt, TypeIntSlice, TypePlayerIndex, TypePlayerIndexSlice:
+		item["type"] = "integer"
+	case TypeBool, TypeBoolSlice:
+		item["type"] = "boolean"
+	case TypeString, TypeStringSlice:
+		item["type"] = "string"
+	case TypeEnum:
+		item["type"] = "string"
+		if enumVal != nil {
+			item["x-enum"] = enumVal.Name()
+		}
+	}
+
+	if f != nil {
+		if f.HasMin {
+			item["minimum"] = f.Min
+		}
+		if f.HasMax {
+			item["maximum"] = f.Max
+		}
+		if f.Values != nil {
+			var values []interface{}
+			for _, val := range f.Values {
+				if propType == TypeEnum && enumVal != nil {
+					values = append(values, enumVal.String(val))
+				} else {
+					values = append(values, val)
+				}
+			}
+			item["enum"] = values
+		}
+		if f.Slot != "" {
+			item["minimum"] = 0
+			item["x-slotOf"] = f.Slot
+		}
+		if f.Players != 0 {
+			item["x-playerFilter"] = f.Players.Names()
+		}
+	}
+
+	switch propType {
+	case TypeIntSlice, TypePlayerIndexSlice, TypeBoolSlice, TypeStringSlice:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": item,
+		}
+	}
+
+	return item
+}
+
+//moveFieldConstraints returns the constraints declared on exampleMove via
+//struct tags and MoveFieldConstrainer.
+func moveFieldConstraints(exampleMove Move, chest *ComponentChest) (map[string]*FieldConstraint, error) {
+	result := make(map[string]*FieldConstraint)
+
+	reader := exampleMove.Reader()
+
+	for propName, propType := range reader.Props() {
+		tag := structTagForField(exampleMove, propName, constraintStructTag)
+		if tag == "" {
+			continue
+		}
+		constraint, err := parseFieldConstraint(tag, propType, moveFieldEnum(exampleMove, propName, propType, chest))
+		if err != nil {
+			return nil, errors.New(propName + " had an invalid constraint tag: " + err.Error())
+		}
+		result[propName] = constraint
+	}
+
+	if constrainer, ok := exampleMove.(MoveFieldConstrainer); ok {
+		for propName, constraint := range constrainer.FieldConstraints() {
+			propType, ok := reader.Props()[propName]
+			if !ok {
+				return nil, errors.New("FieldConstraints returned a constraint for " + propName + ", which isn't a field")
+			}
+			if constraint == nil {
+				continue
+			}
+			if err := constraint.validForType(propType); err != nil {
+				return nil, errors.New(propName + " had an invalid constraint: " + err.Error())
+			}
+			result[propName] = constraint
+		}
+	}
+
+	return result, nil
+}
+
+//moveFieldEnum returns the enum for the given field, or nil if it isn't an
+//enum field. Moves fresh from their constructor may not have had their enum
+//fields inflated yet, so it falls back on the field's enum struct tag.
+func moveFieldEnum(move Move, propName string, propType PropertyType, chest *ComponentChest) enum.Enum {
+	if propType != TypeEnum {
+		return nil
+	}
+	val, err := move.Reader().EnumProp(propName)
+	if err == nil && val != nil {
+		return val.Enum()
+	}
+	if enumName := structTagForField(move, propName, enumStructTag); enumName != "" && chest != nil {
+		return chest.Enums().Enum(enumName)
+	}
+	return nil
+}
+
+//FieldConstraints returns the constraints declared on this move type's
+//fields, keyed by field name.
+func (m *MoveType) FieldConstraints() map[string]*FieldConstraint {
+	return m.constraints
+}
+
+//CheckFieldConstraints returns an error if any of move's fields violate the
+//move type's FieldConstraints. It is called automatically before Legal when
+//a move is proposed, and moves.Base's Legal calls it too, so that fix up
+//moves and legal move listings never offer a move that violates them. Its
+//errors have the code CodeInvalidMoveField.
+func (m *MoveType) CheckFieldConstraints(move Move, state State, proposer PlayerIndex) error {
+
+	if len(m.constraints) == 0 {
+		return nil
+	}
+
+	reader := move.Reader()
+
+	//Check in a stable order so errors are deterministic.
+	names := make([]string, 0, len(m.constraints))
+
+	for name := range m.constraints {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		constraint := m.constraints[name]
+
+		var vals []int
+
+		switch reader.Props()[name] {
+		case TypeInt:
+			val, err := reader.IntProp(name)
+			if err != nil {
+				return err
+			}
+			vals = []int{val}
+		case TypeIntSlice:
+			val, err := reader.IntSliceProp(name)
+			if err != nil {
+				return err
+			}
+			vals = val
+		case TypePlayerIndex:
+			val, err := reader.PlayerIndexProp(name)
+			if err != nil {
+				return err
+			}
+			vals = []int{int(val)}
+		case TypePlayerIndexSlice:
+			val, err := reader.PlayerIndexSliceProp(name)
+			if err != nil {
+				return err
+			}
+			for _, item := range val {
+				vals = append(vals, int(item))
+			}
+		case TypeEnum:
+			val, err := reader.EnumProp(name)
+			if err != nil {
+				return err
+			}
+			if val != nil {
+				vals = []int{val.Value()}
+			}
+		}
+
+		for _, val := range vals {
+			if err := constraint.check(name, val, state, proposer); err != nil {
+				return illegalMoveError(err, CodeInvalidMoveField)
+			}
+		}
+	}
+
+	return nil
+}
+
+//JSONSchema returns a JSON Schema (draft 7) describing the fields of moves of
+//this type, including their FieldConstraints. Constraints that depend on the
+//state of a specific game are described with extension keywords: x-slotOf
+//names the game stack a slot must be in, x-playerFilter lists the
+//PlayerFilter names, and x-enum names the enum for enum fields.
+func (m *MoveType) JSONSchema() map[string]interface{} {
+
+	exampleMove := m.constructor()
+
+	properties := make(map[string]interface{})
+
+	if exampleMove != nil && exampleMove.Reader() != nil {
+		reader := exampleMove.Reader()
+		for propName, propType := range reader.Props() {
+			properties[propName] = m.constraints[propName].jsonSchema(propType, moveFieldEnum(exampleMove, propName, propType, m.manager.Chest()))
+		}
+	}
+
+	return map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       m.Name(),
+		"description": m.HelpText(),
+		"type":        "object",
+		"properties":  properties,
+	}
+}
@@ -0,0 +1,188 @@
+package boardgame
+
+import (
+	"github.com/jkomoros/boardgame/enum"
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+type testMoveConstrained struct {
+	baseMove
+	Slot   int             `constraint:"slot=DrawDeck"`
+	Amount int             `constraint:"min=1,max=3"`
+	Target PlayerIndex     `constraint:"opponent"`
+	Color  enum.MutableVal `enum:"color" constraint:"values=Red|Blue"`
+}
+
+func (t *testMoveConstrained) Apply(state MutableState) error {
+	return nil
+}
+
+func (t *testMoveConstrained) Legal(state State, proposer PlayerIndex) error {
+	return nil
+}
+
+func (t *testMoveConstrained) Reader() PropertyReader {
+	return getDefaultReader(t)
+}
+
+func (t *testMoveConstrained) ReadSetter() PropertyReadSetter {
+	return getDefaultReadSetter(t)
+}
+
+func (t *testMoveConstrained) ReadSetConfigurer() PropertyReadSetConfigurer {
+	return getDefaultReadSetConfigurer(t)
+}
+
+var testMoveConstrainedConfig = MoveTypeConfig{
+	Name:     "Constrained",
+	HelpText: "A move whose fields are constrained.",
+	MoveConstructor: func() Move {
+		return new(testMoveConstrained)
+	},
+}
+
+type testMoveBadConstraint struct {
+	testMoveConstrained
+	Flag bool `constraint:"min=1"`
+}
+
+func (t *testMoveBadConstraint) Reader() PropertyReader {
+	return getDefaultReader(t)
+}
+
+func (t *testMoveBadConstraint) ReadSetter() PropertyReadSetter {
+	return getDefaultReadSetter(t)
+}
+
+func (t *testMoveBadConstraint) ReadSetConfigurer() PropertyReadSetConfigurer {
+	return getDefaultReadSetConfigurer(t)
+}
+
+func TestMoveFieldConstraints(t *testing.T) {
+
+	manager, err := NewGameManager(&testGameDelegate{moveInstaller: func(manager *GameManager) *MoveTypeConfigBundle {
+		return NewMoveTypeConfigBundle().AddMove(&testMoveConstrainedConfig)
+	}}, newTestGameChest(), newTestStorageManager())
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(2, nil, nil)).IsNil()
+
+	tests := []struct {
+		description string
+		slot        int
+		amount      int
+		target      PlayerIndex
+		color       int
+		legal       bool
+	}{
+		{
+			"Legal",
+			3,
+			1,
+			1,
+			colorBlue,
+			true,
+		},
+		{
+			"Slot out of bounds",
+			4,
+			1,
+			1,
+			colorBlue,
+			false,
+		},
+		{
+			"Amount too high",
+			0,
+			4,
+			1,
+			colorBlue,
+			false,
+		},
+		{
+			"Target is self",
+			0,
+			2,
+			0,
+			colorRed,
+			false,
+		},
+		{
+			"Target not a player",
+			0,
+			2,
+			ObserverPlayerIndex,
+			colorRed,
+			false,
+		},
+		{
+			"Color not allowed",
+			0,
+			2,
+			1,
+			colorGreen,
+			false,
+		},
+	}
+
+	for i, test := range tests {
+		move := game.PlayerMoveByName("Constrained").(*testMoveConstrained)
+		move.Slot = test.slot
+		move.Amount = test.amount
+		move.Target = test.target
+		move.Color.SetValue(test.color)
+
+		err := <-game.ProposeMove(move, 0)
+
+		if test.legal {
+			assert.For(t, i, test.description).ThatActual(err).IsNil()
+		} else {
+			assert.For(t, i, test.description).ThatActual(err).IsNotNil()
+		}
+	}
+
+	schema := manager.PlayerMoveTypeByName("Constrained").JSONSchema()
+
+	assert.For(t).ThatActual(schema["title"]).Equals("Constrained")
+
+	properties := schema["properties"].(map[string]interface{})
+
+	assert.For(t).ThatActual(properties["Amount"]).Equals(map[string]interface{}{
+		"type":    "integer",
+		"minimum": 1,
+		"maximum": 3,
+	})
+
+	assert.For(t).ThatActual(properties["Slot"]).Equals(map[string]interface{}{
+		"type":     "integer",
+		"minimum":  0,
+		"x-slotOf": "DrawDeck",
+	})
+
+	assert.For(t).ThatActual(properties["Target"]).Equals(map[string]interface{}{
+		"type":           "integer",
+		"x-playerFilter": []string{"opponent"},
+	})
+
+	assert.For(t).ThatActual(properties["Color"]).Equals(map[string]interface{}{
+		"type":   "string",
+		"x-enum": "color",
+		"enum":   []interface{}{"Red", "Blue"},
+	})
+
+	_, err = NewGameManager(&testGameDelegate{moveInstaller: func(manager *GameManager) *MoveTypeConfigBundle {
+		return NewMoveTypeConfigBundle().AddMove(&MoveTypeConfig{
+			Name: "Bad Constraint",
+			MoveConstructor: func() Move {
+				return new(testMoveBadConstraint)
+			},
+		})
+	}}, newTestGameChest(), newTestStorageManager())
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+}
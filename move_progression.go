@@ -0,0 +1,393 @@
+package boardgame
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//ProgressionUnbounded may be passed as max to ProgressionRepeat to allow any
+//number of repetitions.
+const ProgressionUnbounded = -1
+
+//MoveProgression is a structured description of the order that moves must be
+//made in within a phase, installed with AddOrderedMovesForPhase and returned
+//from your delegate's PhaseMoveProgression. A *MoveTypeConfig is itself the
+//simplest MoveProgression: that move, once. Larger progressions are built
+//with ProgressionSerial, ProgressionAlternate, ProgressionOptional,
+//ProgressionZeroOrMore, ProgressionOneOrMore and ProgressionRepeat, which may
+//be nested arbitrarily. For example, "deal, then draw or discard 1 to 3
+//times, then optionally bonus, then end" is:
+//
+//	ProgressionSerial(
+//		dealConfig,
+//		ProgressionRepeat(ProgressionAlternate(drawConfig, discardConfig), 1, 3),
+//		ProgressionOptional(bonusConfig),
+//		endConfig,
+//	)
+//
+//A move that implements moveinterfaces.AllowMultipleInProgression and
+//returns true may be applied multiple times in a row wherever it appears, and
+//each run counts as a single step in the progression. Use a
+//ProgressionMatcher to evaluate a history of moves against a progression.
+type MoveProgression interface {
+	//MoveConfigs returns every MoveTypeConfig in the progression, in the order
+	//they first appear, with no duplicates.
+	MoveConfigs() []*MoveTypeConfig
+	//MoveNames returns the names of every move in the progression, in the
+	//order they first appear, with no duplicates.
+	MoveNames() []string
+	//String returns a description of the progression, like "Deal (Draw |
+	//Discard){1,3} Bonus? End".
+	String() string
+
+	//compile adds the states and edges for this progression to nfa, starting
+	//from start, and returns the state the progression ends at.
+	compile(nfa *progressionNFA, start int) int
+}
+
+//MoveConfigs returns a slice containing only this config, so a
+//MoveTypeConfig can be used as a MoveProgression.
+func (m *MoveTypeConfig) MoveConfigs() []*MoveTypeConfig {
+	return []*MoveTypeConfig{m}
+}
+
+//MoveNames returns a slice containing only this config's name, so a
+//MoveTypeConfig can be used as a MoveProgression.
+func (m *MoveTypeConfig) MoveNames() []string {
+	return []string{m.Name}
+}
+
+//String returns the name of the config.
+func (m *MoveTypeConfig) String() string {
+	return m.Name
+}
+
+func (m *MoveTypeConfig) compile(nfa *progressionNFA, start int) int {
+	end := nfa.newState()
+	nfa.addEdge(start, end, m.Name, false)
+	//Moves that allow multiple in progression may keep being applied.
+	nfa.addEdge(end, end, m.Name, true)
+	return end
+}
+
+type progressionSerial struct {
+	items []MoveProgression
+}
+
+type progressionAlternate struct {
+	items []MoveProgression
+}
+
+type progressionRepeat struct {
+	item MoveProgression
+	min  int
+	max  int
+}
+
+//ProgressionSerial returns a progression where each of items must happen in
+//order.
+func ProgressionSerial(items ...MoveProgression) MoveProgression {
+	return &progressionSerial{items}
+}
+
+//ProgressionAlternate returns a progression where exactly one of items must
+//happen.
+func ProgressionAlternate(items ...MoveProgression) MoveProgression {
+	return &progressionAlternate{items}
+}
+
+//ProgressionRepeat returns a progression where item must happen between min
+//and max times, inclusive. Pass ProgressionUnbounded for max to allow any
+//number of repetitions above min.
+func ProgressionRepeat(item MoveProgression, min, max int) MoveProgression {
+	if min < 0 {
+		min = 0
+	}
+	if max != ProgressionUnbounded && max < min {
+		max = min
+	}
+	return &progressionRepeat{item, min, max}
+}
+
+//ProgressionOptional returns a progression where item may happen once or
+//not at all.
+func ProgressionOptional(item MoveProgression) MoveProgression {
+	return ProgressionRepeat(item, 0, 1)
+}
+
+//ProgressionZeroOrMore returns a progression where item may happen any
+//number of times, including none.
+func ProgressionZeroOrMore(item MoveProgression) MoveProgression {
+	return ProgressionRepeat(item, 0, ProgressionUnbounded)
+}
+
+//ProgressionOneOrMore returns a progression where item must happen at least
+//once.
+func ProgressionOneOrMore(item MoveProgression) MoveProgression {
+	return ProgressionRepeat(item, 1, ProgressionUnbounded)
+}
+
+//mergeMoveConfigs returns the configs of all of the items, without
+//duplicates.
+func mergeMoveConfigs(items []MoveProgression) []*MoveTypeConfig {
+	var result []*MoveTypeConfig
+	seen := make(map[*MoveTypeConfig]bool)
+	for _, item := range items {
+		for _, config := range item.MoveConfigs() {
+			if seen[config] {
+				continue
+			}
+			seen[config] = true
+			result = append(result, config)
+		}
+	}
+	return result
+}
+
+//mergeMoveNames returns the move names of all of the items, without
+//duplicates.
+func mergeMoveNames(items []MoveProgression) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, item := range items {
+		for _, name := range item.MoveNames() {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func (p *progressionSerial) MoveConfigs() []*MoveTypeConfig {
+	return mergeMoveConfigs(p.items)
+}
+
+func (p *progressionSerial) MoveNames() []string {
+	return mergeMoveNames(p.items)
+}
+
+func (p *progressionSerial) String() string {
+	parts := make([]string, len(p.items))
+	for i, item := range p.items {
+		parts[i] = item.String()
+		if _, ok := item.(*progressionSerial); ok {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func (p *progressionSerial) compile(nfa *progressionNFA, start int) int {
+	for _, item := range p.items {
+		start = item.compile(nfa, start)
+	}
+	return start
+}
+
+func (p *progressionAlternate) MoveConfigs() []*MoveTypeConfig {
+	return mergeMoveConfigs(p.items)
+}
+
+func (p *progressionAlternate) MoveNames() []string {
+	return mergeMoveNames(p.items)
+}
+
+func (p *progressionAlternate) String() string {
+	parts := make([]string, len(p.items))
+	for i, item := range p.items {
+		parts[i] = item.String()
+	}
+	return "(" + strings.Join(parts, " | ") + ")"
+}
+
+func (p *progressionAlternate) compile(nfa *progressionNFA, start int) int {
+	end := nfa.newState()
+	for _, item := range p.items {
+		itemStart := nfa.newState()
+		nfa.addEdge(start, itemStart, "", false)
+		nfa.addEdge(item.compile(nfa, itemStart), end, "", false)
+	}
+	return end
+}
+
+func (p *progressionRepeat) MoveConfigs() []*MoveTypeConfig {
+	return p.item.MoveConfigs()
+}
+
+func (p *progressionRepeat) MoveNames() []string {
+	return p.item.MoveNames()
+}
+
+func (p *progressionRepeat) String() string {
+	item := p.item.String()
+	if _, ok := p.item.(*progressionSerial); ok {
+		item = "(" + item + ")"
+	}
+
+	switch {
+	case p.min == 0 && p.max == 1:
+		return item + "?"
+	case p.min == 0 && p.max == ProgressionUnbounded:
+		return item + "*"
+	case p.min == 1 && p.max == ProgressionUnbounded:
+		return item + "+"
+	case p.max == ProgressionUnbounded:
+		return item + "{" + strconv.Itoa(p.min) + ",}"
+	case p.min == p.max:
+		return item + "{" + strconv.Itoa(p.min) + "}"
+	}
+	return item + "{" + strconv.Itoa(p.min) + "," + strconv.Itoa(p.max) + "}"
+}
+
+func (p *progressionRepeat) compile(nfa *progressionNFA, start int) int {
+	for i := 0; i < p.min; i++ {
+		start = p.item.compile(nfa, start)
+	}
+
+	if p.max == ProgressionUnbounded {
+		loopStart := nfa.newState()
+		nfa.addEdge(start, loopStart, "", false)
+		nfa.addEdge(p.item.compile(nfa, loopStart), start, "", false)
+		return start
+	}
+
+	end := nfa.newState()
+	nfa.addEdge(start, end, "", false)
+
+	for i := p.min; i < p.max; i++ {
+		start = p.item.compile(nfa, start)
+		nfa.addEdge(start, end, "", false)
+	}
+
+	return end
+}
+
+type progressionEdge struct {
+	//name is the move this edge consumes, or "" for an edge that consumes
+	//nothing.
+	name string
+	to   int
+	//repeat edges are only followed if the move allows multiple in
+	//progression.
+	repeat bool
+}
+
+//progressionNFA is a non-deterministic finite automaton compiled from a
+//MoveProgression.
+type progressionNFA struct {
+	edges  [][]progressionEdge
+	accept int
+}
+
+func (n *progressionNFA) newState() int {
+	n.edges = append(n.edges, nil)
+	return len(n.edges) - 1
+}
+
+func (n *progressionNFA) addEdge(from, to int, name string, repeat bool) {
+	n.edges[from] = append(n.edges[from], progressionEdge{name, to, repeat})
+}
+
+//closure adds every state reachable from states without consuming a move.
+func (n *progressionNFA) closure(states map[int]bool) map[int]bool {
+	queue := make([]int, 0, len(states))
+	for state := range states {
+		queue = append(queue, state)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, edge := range n.edges[state] {
+			if edge.name != "" || states[edge.to] {
+				continue
+			}
+			states[edge.to] = true
+			queue = append(queue, edge.to)
+		}
+	}
+	return states
+}
+
+//ProgressionMatcher evaluates a sequence of moves against a MoveProgression
+//one move at a time. Get one from NewProgressionMatcher.
+type ProgressionMatcher struct {
+	nfa         *progressionNFA
+	states      map[int]bool
+	allowRepeat func(name string) bool
+}
+
+//NewProgressionMatcher returns a ProgressionMatcher for progression that
+//hasn't had any moves applied yet. allowRepeat is consulted to see if a move
+//may be applied multiple times in a row as a single step; if it is nil, no
+//moves may be.
+func NewProgressionMatcher(progression MoveProgression, allowRepeat func(name string) bool) *ProgressionMatcher {
+	nfa := &progressionNFA{}
+	start := nfa.newState()
+	nfa.accept = progression.compile(nfa, start)
+
+	if allowRepeat == nil {
+		allowRepeat = func(name string) bool {
+			return false
+		}
+	}
+
+	return &ProgressionMatcher{
+		nfa:         nfa,
+		states:      nfa.closure(map[int]bool{start: true}),
+		allowRepeat: allowRepeat,
+	}
+}
+
+//Advance records that the move with the given name was applied. It returns
+//false (and leaves the matcher unchanged) if that move wasn't one of the
+//moves in Next.
+func (p *ProgressionMatcher) Advance(name string) bool {
+	next := make(map[int]bool)
+	for state := range p.states {
+		for _, edge := range p.nfa.edges[state] {
+			if edge.name != name {
+				continue
+			}
+			if edge.repeat && !p.allowRepeat(name) {
+				continue
+			}
+			next[edge.to] = true
+		}
+	}
+	if len(next) == 0 {
+		return false
+	}
+	p.states = p.nfa.closure(next)
+	return true
+}
+
+//Next returns the names of the moves that may legally be applied next,
+//sorted alphabetically.
+func (p *ProgressionMatcher) Next() []string {
+	seen := make(map[string]bool)
+	var result []string
+	for state := range p.states {
+		for _, edge := range p.nfa.edges[state] {
+			if edge.name == "" || seen[edge.name] {
+				continue
+			}
+			if edge.repeat && !p.allowRepeat(edge.name) {
+				continue
+			}
+			seen[edge.name] = true
+			result = append(result, edge.name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+//Complete returns true if the moves applied so far are a complete match for
+//the progression.
+func (p *ProgressionMatcher) Complete() bool {
+	return p.states[p.nfa.accept]
+}
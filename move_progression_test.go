@@ -0,0 +1,328 @@
+package boardgame
+
+import (
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+func TestMoveProgressionMatcher(t *testing.T) {
+
+	deal := &MoveTypeConfig{Name: "Deal"}
+	draw := &MoveTypeConfig{Name: "Draw"}
+	discard := &MoveTypeConfig{Name: "Discard"}
+	bonus := &MoveTypeConfig{Name: "Bonus"}
+	end := &MoveTypeConfig{Name: "End"}
+
+	progression := ProgressionSerial(
+		deal,
+		ProgressionRepeat(ProgressionAlternate(draw, discard), 1, 3),
+		ProgressionOptional(bonus),
+		end,
+	)
+
+	assert.For(t).ThatActual(progression.String()).Equals("Deal (Draw | Discard){1,3} Bonus? End")
+	assert.For(t).ThatActual(progression.MoveNames()).Equals([]string{"Deal", "Draw", "Discard", "Bonus", "End"})
+	assert.For(t).ThatActual(len(progression.MoveConfigs())).Equals(5)
+
+	tests := []struct {
+		description  string
+		history      []string
+		allowRepeat  bool
+		valid        bool
+		expectedNext []string
+		complete     bool
+	}{
+		{
+			"Empty",
+			nil,
+			false,
+			true,
+			[]string{"Deal"},
+			false,
+		},
+		{
+			"Wrong first move",
+			[]string{"Draw"},
+			false,
+			false,
+			nil,
+			false,
+		},
+		{
+			"After deal",
+			[]string{"Deal"},
+			false,
+			true,
+			[]string{"Discard", "Draw"},
+			false,
+		},
+		{
+			"Repeated deal not allowed",
+			[]string{"Deal", "Deal"},
+			false,
+			false,
+			nil,
+			false,
+		},
+		{
+			"Repeated deal allowed",
+			[]string{"Deal", "Deal", "Deal"},
+			true,
+			true,
+			[]string{"Deal", "Discard", "Draw"},
+			false,
+		},
+		{
+			"One draw",
+			[]string{"Deal", "Draw"},
+			false,
+			true,
+			[]string{"Bonus", "Discard", "Draw", "End"},
+			false,
+		},
+		{
+			"Three draws",
+			[]string{"Deal", "Draw", "Discard", "Draw"},
+			false,
+			true,
+			[]string{"Bonus", "End"},
+			false,
+		},
+		{
+			"Four draws",
+			[]string{"Deal", "Draw", "Discard", "Draw", "Discard"},
+			false,
+			false,
+			nil,
+			false,
+		},
+		{
+			"Skip bonus",
+			[]string{"Deal", "Discard", "End"},
+			false,
+			true,
+			nil,
+			true,
+		},
+		{
+			"Bonus",
+			[]string{"Deal", "Discard", "Bonus", "End"},
+			false,
+			true,
+			nil,
+			true,
+		},
+	}
+
+	for i, test := range tests {
+		matcher := NewProgressionMatcher(progression, func(name string) bool {
+			return test.allowRepeat
+		})
+
+		valid := true
+
+		for _, name := range test.history {
+			if !matcher.Advance(name) {
+				valid = false
+				break
+			}
+		}
+
+		assert.For(t, i, test.description).ThatActual(valid).Equals(test.valid)
+
+		if !test.valid {
+			continue
+		}
+
+		assert.For(t, i, test.description).ThatActual(matcher.Next()).Equals(test.expectedNext)
+		assert.For(t, i, test.description).ThatActual(matcher.Complete()).Equals(test.complete)
+	}
+
+}
+
+func TestMoveProgressionString(t *testing.T) {
+
+	a := &MoveTypeConfig{Name: "A"}
+	b := &MoveTypeConfig{Name: "B"}
+
+	tests := []struct {
+		progression MoveProgression
+		expected    string
+	}{
+		{ProgressionZeroOrMore(a), "A*"},
+		{ProgressionOneOrMore(ProgressionSerial(a, b)), "(A B)+"},
+		{ProgressionRepeat(a, 2, 2), "A{2}"},
+		{ProgressionRepeat(a, 2, ProgressionUnbounded), "A{2,}"},
+		{ProgressionSerial(a, ProgressionSerial(b, a)), "A (B A)"},
+		{ProgressionAlternate(a, ProgressionOptional(b)), "(A | B?)"},
+	}
+
+	for i, test := range tests {
+		assert.For(t, i).ThatActual(test.progression.String()).Equals(test.expected)
+	}
+
+	matcher := NewProgressionMatcher(ProgressionOneOrMore(ProgressionSerial(a, b)), nil)
+
+	for i, name := range []string{"A", "B", "A", "B"} {
+		assert.For(t, i).ThatActual(matcher.Advance(name)).IsTrue()
+	}
+
+	assert.For(t).ThatActual(matcher.Complete()).IsTrue()
+	assert.For(t).ThatActual(matcher.Next()).Equals([]string{"A"})
+
+}
+
+//TestMoveProgressionSerial checks flat progressions where every move may be
+//repeated any number of times in a row.
+func TestMoveProgressionSerial(t *testing.T) {
+
+	tests := []struct {
+		progression    []string
+		pattern        []string
+		expectedResult bool
+	}{
+		{
+			[]string{
+				"A",
+			},
+			[]string{
+				"A",
+				"B",
+				"C",
+			},
+			true,
+		},
+		{
+			[]string{
+				"B",
+			},
+			[]string{
+				"A",
+				"B",
+				"C",
+			},
+			false,
+		},
+		{
+			[]string{
+				"A",
+				"A",
+				"A",
+			},
+			[]string{
+				"A",
+				"B",
+				"C",
+			},
+			true,
+		},
+		{
+			[]string{
+				"A",
+				"A",
+				"B",
+			},
+			[]string{
+				"A",
+				"B",
+				"C",
+			},
+			true,
+		},
+		{
+			[]string{
+				"A",
+				"A",
+				"C",
+			},
+			[]string{
+				"A",
+				"B",
+				"C",
+			},
+			false,
+		},
+		{
+			[]string{
+				"A",
+				"A",
+				"B",
+				"A",
+			},
+			[]string{
+				"A",
+				"B",
+				"A",
+				"C",
+			},
+			true,
+		},
+		{
+			[]string{
+				"A",
+				"A",
+				"B",
+				"B",
+			},
+			[]string{
+				"A",
+				"B",
+				"A",
+				"C",
+			},
+			true,
+		},
+		{
+			[]string{
+				"A",
+				"A",
+				"B",
+				"C",
+			},
+			[]string{
+				"A",
+				"B",
+				"A",
+				"C",
+			},
+			false,
+		},
+		{
+			[]string{
+				"Multi Word Move",
+				"B",
+			},
+			[]string{
+				"Multi Word Move",
+				"B",
+				"Multi Word Move",
+			},
+			true,
+		},
+	}
+
+	for i, test := range tests {
+
+		items := make([]MoveProgression, len(test.pattern))
+
+		for j, name := range test.pattern {
+			items[j] = &MoveTypeConfig{Name: name}
+		}
+
+		matcher := NewProgressionMatcher(ProgressionSerial(items...), func(name string) bool {
+			return true
+		})
+
+		result := true
+
+		for _, name := range test.progression {
+			if !matcher.Advance(name) {
+				result = false
+				break
+			}
+		}
+
+		assert.For(t, i).ThatActual(result).Equals(test.expectedResult)
+	}
+
+}
@@ -0,0 +1,753 @@
+package moves
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/moves/moveinterfaces"
+	"strconv"
+)
+
+//The styles of auction that StartAuction can configure, returned from
+//AuctionStyle.
+const (
+	//AuctionEnglish is an open auction where players take turns raising the
+	//high bid or dropping out, until only the high bidder remains.
+	AuctionEnglish = iota
+	//AuctionSealed is an auction where every player secretly bids once, in
+	//any order, and the highest bid wins.
+	AuctionSealed
+	//AuctionDutch is an auction where the price starts high and drops each
+	//time every player passes. The first player to bid takes the lot at the
+	//current price.
+	AuctionDutch
+	//AuctionOnceAround is an open auction where each player, in turn, gets
+	//exactly one chance to outbid the current high bid or pass.
+	AuctionOnceAround
+)
+
+//We can keep these private because embedders already will have the interface
+//satisfied so don't need to be confused by them.
+type auctionConfiger interface {
+	AuctionStyle() int
+	AuctionLot(state boardgame.State) int
+	AuctionStarterPlayer(state boardgame.State) boardgame.PlayerIndex
+	AuctionStartingPrice(state boardgame.State) int
+	AuctionReserve(state boardgame.State) int
+	AuctionIncrement(state boardgame.State) int
+}
+
+type auctionTieBreaker interface {
+	AuctionTieBreak(state boardgame.State, tied []boardgame.PlayerIndex) boardgame.PlayerIndex
+}
+
+/*
+
+StartAuction is a FixUp move that begins a new auction. Your gameState must
+implement moveinterfaces.AuctionProperties and your playerStates must
+implement moveinterfaces.AuctionPlayerProperties; generally you simply embed
+moveinterfaces.AuctionBaseGameState and moveinterfaces.AuctionBasePlayerState.
+
+The style of auction and its parameters are configured by overriding methods
+on your embedding move: AuctionStyle, AuctionLot, AuctionStarterPlayer,
+AuctionStartingPrice, AuctionReserve, and AuctionIncrement. The values are
+recorded on the gameState when the auction starts so that AuctionBid,
+AuctionPass, and ResolveAuction know how to behave.
+
+StartAuction is legal whenever no auction is active, so you'll almost always
+want to override Legal (calling StartAuction.Legal first) to add your own
+condition, for example that there are still lots left to auction, or use it
+in an ordered phase.
+
+*/
+type StartAuction struct {
+	Base
+}
+
+func (s *StartAuction) ValidConfiguration(exampleState boardgame.MutableState) error {
+	return validAuctionConfiguration(exampleState)
+}
+
+//Legal returns an error if an auction is already active.
+func (s *StartAuction) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := s.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	game, ok := state.GameState().(moveinterfaces.AuctionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement AuctionProperties")
+	}
+
+	if game.AuctionActive() {
+		return errors.New("There is already an auction underway")
+	}
+
+	return nil
+
+}
+
+//Apply records the auction's configuration on the gameState and resets each
+//player's bidding state.
+func (s *StartAuction) Apply(state boardgame.MutableState) error {
+
+	configer, ok := s.TopLevelStruct().(auctionConfiger)
+
+	if !ok {
+		return errors.New("Unexpectedly the top level struct didn't have auction configuration methods")
+	}
+
+	game, ok := state.MutableGameState().(moveinterfaces.AuctionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement AuctionProperties")
+	}
+
+	if err := resetAuctionPlayers(state); err != nil {
+		return err
+	}
+
+	style := configer.AuctionStyle()
+
+	starter := configer.AuctionStarterPlayer(state)
+
+	if starter < 0 || !starter.Valid(state) {
+		starter = 0
+	}
+
+	if starter.Eliminated(state) {
+		starter = starter.NextActive(state)
+	}
+
+	game.SetAuctionActive(true)
+	game.SetAuctionStyle(style)
+	game.SetAuctionLot(configer.AuctionLot(state))
+	game.SetAuctionReserve(configer.AuctionReserve(state))
+	game.SetAuctionIncrement(configer.AuctionIncrement(state))
+	game.SetAuctionStarterPlayer(starter)
+	game.SetAuctionCurrentBidder(starter)
+	game.SetAuctionHighBidder(boardgame.ObserverPlayerIndex)
+
+	if style == AuctionDutch {
+		game.SetAuctionPrice(configer.AuctionStartingPrice(state))
+	} else {
+		game.SetAuctionPrice(0)
+	}
+
+	return nil
+}
+
+//AuctionStyle is the style of auction to run. Defaults to AuctionEnglish.
+func (s *StartAuction) AuctionStyle() int {
+	return AuctionEnglish
+}
+
+//AuctionLot is a game-specific identifier for what's being auctioned, for
+//example the index of a card in a stack. Defaults to 0.
+func (s *StartAuction) AuctionLot(state boardgame.State) int {
+	return 0
+}
+
+//AuctionStarterPlayer is the player who bids first in auctions with turns.
+//Defaults to the CurrentPlayerIndex. If that player has been eliminated, the
+//next active player starts instead.
+func (s *StartAuction) AuctionStarterPlayer(state boardgame.State) boardgame.PlayerIndex {
+	return state.CurrentPlayerIndex()
+}
+
+//AuctionStartingPrice is the price a Dutch auction starts at. Ignored for
+//other styles. Defaults to AuctionReserve, so you'll want to override it if
+//you use Dutch auctions.
+func (s *StartAuction) AuctionStartingPrice(state boardgame.State) int {
+	configer, ok := s.TopLevelStruct().(auctionConfiger)
+	if !ok {
+		return 1
+	}
+	return configer.AuctionReserve(state)
+}
+
+//AuctionReserve is the minimum opening bid, or the lowest price a Dutch
+//auction will drop to before the lot goes unsold. Defaults to 1.
+func (s *StartAuction) AuctionReserve(state boardgame.State) int {
+	return 1
+}
+
+//AuctionIncrement is how much each bid in an open auction must raise the
+//high bid by, and how much the price of a Dutch auction drops each time
+//everyone passes. Defaults to 1.
+func (s *StartAuction) AuctionIncrement(state boardgame.State) int {
+	return 1
+}
+
+func (s *StartAuction) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Start Auction"
+}
+
+func (s *StartAuction) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Starts a new auction."
+}
+
+func (s *StartAuction) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return true
+}
+
+/*
+
+AuctionBid is the move a player makes to bid in the current auction. What is
+legal depends on the style of the auction. In English and once-around
+auctions it must be the player's turn to bid and the bid must beat the high
+bid by at least the increment (or meet the reserve if there is no bid yet). In
+sealed auctions any player who hasn't bid may bid at least the reserve. In
+Dutch auctions the bid must be exactly the current price.
+
+If the playerState implements moveinterfaces.AuctionBudgeter, bids larger
+than the player's budget are not legal.
+
+Apply records the Amount on the bidder's playerState, which hides it from
+other players until the auction is resolved. The Amount itself is not
+serialized with the move record (which every player can see), so sealed bids
+do not leak via the move history either.
+
+*/
+type AuctionBid struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+	Amount            int `json:"-"`
+}
+
+func (a *AuctionBid) ValidConfiguration(exampleState boardgame.MutableState) error {
+	return validAuctionConfiguration(exampleState)
+}
+
+//Legal checks that the auction is underway, that TargetPlayerIndex may bid
+//right now, and that Amount is a legal bid for the auction's style.
+func (a *AuctionBid) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := a.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	game, player, err := auctionBidder(state, a.TargetPlayerIndex, proposer)
+
+	if err != nil {
+		return err
+	}
+
+	switch game.AuctionStyle() {
+	case AuctionEnglish, AuctionOnceAround:
+		if a.TargetPlayerIndex != game.AuctionCurrentBidder() {
+			return errors.New("It's not your turn to bid")
+		}
+		if player.AuctionHasBid() && game.AuctionStyle() == AuctionOnceAround {
+			return errors.New("You may only bid once in this auction")
+		}
+	case AuctionSealed:
+		if player.AuctionHasBid() {
+			return errors.New("You have already submitted your bid")
+		}
+	case AuctionDutch:
+		if a.Amount != game.AuctionPrice() {
+			return errors.New("In a Dutch auction you may only bid the current price of " + strconv.Itoa(game.AuctionPrice()))
+		}
+	}
+
+	minimum := minimumAuctionBid(game)
+
+	if a.Amount < minimum {
+		return errors.New("The bid must be at least " + strconv.Itoa(minimum))
+	}
+
+	if budgeter, ok := player.(moveinterfaces.AuctionBudgeter); ok {
+		if a.Amount > budgeter.AuctionBudget() {
+			return errors.New("You can't bid more than " + strconv.Itoa(budgeter.AuctionBudget()))
+		}
+	}
+
+	return nil
+
+}
+
+//Apply records the bid on the player. In open auctions it also becomes the
+//high bid and the turn advances to the next bidder.
+func (a *AuctionBid) Apply(state boardgame.MutableState) error {
+
+	game, ok := state.MutableGameState().(moveinterfaces.AuctionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement AuctionProperties")
+	}
+
+	player, ok := state.MutablePlayerStates()[a.TargetPlayerIndex].(moveinterfaces.AuctionPlayerProperties)
+
+	if !ok {
+		return errors.New("PlayerState does not implement AuctionPlayerProperties")
+	}
+
+	player.SetAuctionBid(a.Amount)
+	player.SetAuctionHasBid(true)
+
+	switch game.AuctionStyle() {
+	case AuctionEnglish, AuctionOnceAround:
+		game.SetAuctionPrice(a.Amount)
+		game.SetAuctionHighBidder(a.TargetPlayerIndex)
+		advanceAuctionBidder(state, game)
+	case AuctionDutch:
+		game.SetAuctionHighBidder(a.TargetPlayerIndex)
+	}
+
+	return nil
+}
+
+//DefaultsForState sets the TargetPlayerIndex to the current bidder (see
+//defaultAuctionBidder) and the Amount to the minimum legal bid.
+func (a *AuctionBid) DefaultsForState(state boardgame.State) {
+	game, ok := state.GameState().(moveinterfaces.AuctionProperties)
+	if !ok {
+		return
+	}
+	a.TargetPlayerIndex = defaultAuctionBidder(game)
+	a.Amount = minimumAuctionBid(game)
+}
+
+func (a *AuctionBid) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Auction Bid"
+}
+
+func (a *AuctionBid) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Bids on the lot currently being auctioned."
+}
+
+func (a *AuctionBid) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+/*
+
+AuctionPass is the move a player makes to drop out of the current auction. In
+English and once-around auctions it must be the player's turn, and the turn
+advances to the next player who hasn't dropped out. In sealed auctions it
+signals that the player won't bid at all. In Dutch auctions it signals the
+player won't take the lot at the current price; once every player has passed
+the price drops by the increment and everyone may bid again.
+
+*/
+type AuctionPass struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+}
+
+func (a *AuctionPass) ValidConfiguration(exampleState boardgame.MutableState) error {
+	return validAuctionConfiguration(exampleState)
+}
+
+//Legal checks that the auction is underway and that TargetPlayerIndex may
+//pass right now.
+func (a *AuctionPass) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := a.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	game, player, err := auctionBidder(state, a.TargetPlayerIndex, proposer)
+
+	if err != nil {
+		return err
+	}
+
+	switch game.AuctionStyle() {
+	case AuctionEnglish, AuctionOnceAround:
+		if a.TargetPlayerIndex != game.AuctionCurrentBidder() {
+			return errors.New("It's not your turn to bid")
+		}
+		if player.AuctionHasBid() && game.AuctionStyle() == AuctionOnceAround {
+			return errors.New("You have already bid in this auction")
+		}
+	case AuctionSealed:
+		if player.AuctionHasBid() {
+			return errors.New("You have already submitted your bid")
+		}
+	}
+
+	return nil
+
+}
+
+//Apply marks the player as passed and advances the auction.
+func (a *AuctionPass) Apply(state boardgame.MutableState) error {
+
+	game, ok := state.MutableGameState().(moveinterfaces.AuctionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement AuctionProperties")
+	}
+
+	player, ok := state.MutablePlayerStates()[a.TargetPlayerIndex].(moveinterfaces.AuctionPlayerProperties)
+
+	if !ok {
+		return errors.New("PlayerState does not implement AuctionPlayerProperties")
+	}
+
+	player.SetAuctionPassed(true)
+
+	switch game.AuctionStyle() {
+	case AuctionEnglish, AuctionOnceAround:
+		advanceAuctionBidder(state, game)
+	case AuctionDutch:
+		for i, p := range state.PlayerStates() {
+			if boardgame.PlayerIndex(i).Eliminated(state) {
+				continue
+			}
+			if !p.(moveinterfaces.AuctionPlayerProperties).AuctionPassed() {
+				return nil
+			}
+		}
+		//Everyone passed at this price, so drop it.
+		game.SetAuctionPrice(game.AuctionPrice() - game.AuctionIncrement())
+		for _, p := range state.MutablePlayerStates() {
+			p.(moveinterfaces.AuctionPlayerProperties).SetAuctionPassed(false)
+		}
+	}
+
+	return nil
+}
+
+//DefaultsForState sets the TargetPlayerIndex to the current bidder (see
+//defaultAuctionBidder).
+func (a *AuctionPass) DefaultsForState(state boardgame.State) {
+	game, ok := state.GameState().(moveinterfaces.AuctionProperties)
+	if !ok {
+		return
+	}
+	a.TargetPlayerIndex = defaultAuctionBidder(game)
+}
+
+func (a *AuctionPass) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Auction Pass"
+}
+
+func (a *AuctionPass) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Passes in the current auction."
+}
+
+func (a *AuctionPass) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+/*
+
+ResolveAuction is a FixUp move that becomes legal once the current auction is
+over. It figures out the winner and the price, marks the auction as no longer
+active, and then calls AuctionResolved on the embedding move, which must
+implement moveinterfaces.AuctionResolver. After it applies, the gameState's
+AuctionHighBidder and AuctionPrice record the outcome.
+
+In sealed auctions, ties are broken by AuctionTieBreak, which you may
+override. By default the tied player who comes first in turn order starting
+from AuctionStarterPlayer wins.
+
+*/
+type ResolveAuction struct {
+	Base
+}
+
+func (r *ResolveAuction) ValidConfiguration(exampleState boardgame.MutableState) error {
+
+	if err := validAuctionConfiguration(exampleState); err != nil {
+		return err
+	}
+
+	if _, ok := r.TopLevelStruct().(moveinterfaces.AuctionResolver); !ok {
+		return errors.New("Embedding move doesn't implement AuctionResolver")
+	}
+
+	return nil
+}
+
+//Legal returns nil if an auction is active and it is over.
+func (r *ResolveAuction) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := r.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	game, ok := state.GameState().(moveinterfaces.AuctionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement AuctionProperties")
+	}
+
+	if !game.AuctionActive() {
+		return errors.New("There is no auction underway")
+	}
+
+	if !auctionOver(state, game) {
+		return errors.New("The auction is not over yet")
+	}
+
+	return nil
+
+}
+
+//Apply determines the winner and price, ends the auction, and calls
+//AuctionResolved.
+func (r *ResolveAuction) Apply(state boardgame.MutableState) error {
+
+	resolver, ok := r.TopLevelStruct().(moveinterfaces.AuctionResolver)
+
+	if !ok {
+		return errors.New("Embedding move doesn't implement AuctionResolver")
+	}
+
+	game, ok := state.MutableGameState().(moveinterfaces.AuctionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement AuctionProperties")
+	}
+
+	winner := game.AuctionHighBidder()
+	price := game.AuctionPrice()
+
+	if game.AuctionStyle() == AuctionSealed {
+		winner, price = r.sealedWinner(state, game)
+	}
+
+	if winner < 0 {
+		winner = boardgame.ObserverPlayerIndex
+		price = 0
+	}
+
+	game.SetAuctionActive(false)
+	game.SetAuctionHighBidder(winner)
+	game.SetAuctionPrice(price)
+
+	if err := resetAuctionPlayers(state); err != nil {
+		return err
+	}
+
+	return resolver.AuctionResolved(state, winner, price)
+}
+
+func (r *ResolveAuction) sealedWinner(state boardgame.State, game moveinterfaces.AuctionProperties) (boardgame.PlayerIndex, int) {
+
+	price := 0
+	var tied []boardgame.PlayerIndex
+
+	//Walk in turn order from the starter so tied is in turn order too.
+	index := game.AuctionStarterPlayer()
+	for i := 0; i < len(state.PlayerStates()); i++ {
+		player := state.PlayerStates()[index].(moveinterfaces.AuctionPlayerProperties)
+		if player.AuctionHasBid() {
+			if player.AuctionBid() > price {
+				price = player.AuctionBid()
+				tied = nil
+			}
+			if player.AuctionBid() == price {
+				tied = append(tied, index)
+			}
+		}
+		index = index.Next(state)
+	}
+
+	if len(tied) == 0 {
+		return boardgame.ObserverPlayerIndex, 0
+	}
+
+	if len(tied) == 1 {
+		return tied[0], price
+	}
+
+	tieBreaker, ok := r.TopLevelStruct().(auctionTieBreaker)
+
+	if !ok {
+		return tied[0], price
+	}
+
+	return tieBreaker.AuctionTieBreak(state, tied), price
+}
+
+//AuctionTieBreak picks the winner of a sealed auction from the players tied
+//for the high bid, which are provided in turn order starting from
+//AuctionStarterPlayer. The default returns the first one.
+func (r *ResolveAuction) AuctionTieBreak(state boardgame.State, tied []boardgame.PlayerIndex) boardgame.PlayerIndex {
+	return tied[0]
+}
+
+func (r *ResolveAuction) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Resolve Auction"
+}
+
+func (r *ResolveAuction) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Awards the lot to the winner of the auction."
+}
+
+func (r *ResolveAuction) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return true
+}
+
+func validAuctionConfiguration(exampleState boardgame.MutableState) error {
+	if _, ok := exampleState.GameState().(moveinterfaces.AuctionProperties); !ok {
+		return errors.New("GameState does not implement AuctionProperties")
+	}
+
+	if _, ok := exampleState.PlayerStates()[0].(moveinterfaces.AuctionPlayerProperties); !ok {
+		return errors.New("PlayerState does not implement AuctionPlayerProperties")
+	}
+
+	return nil
+}
+
+//auctionBidder does the checks shared by AuctionBid and AuctionPass.
+func auctionBidder(state boardgame.State, target boardgame.PlayerIndex, proposer boardgame.PlayerIndex) (moveinterfaces.AuctionProperties, moveinterfaces.AuctionPlayerProperties, error) {
+
+	game, ok := state.GameState().(moveinterfaces.AuctionProperties)
+
+	if !ok {
+		return nil, nil, errors.New("GameState does not implement AuctionProperties")
+	}
+
+	if !game.AuctionActive() {
+		return nil, nil, errors.New("There is no auction underway")
+	}
+
+	if auctionOver(state, game) {
+		return nil, nil, errors.New("The auction is over")
+	}
+
+	if !target.Valid(state) || target < 0 {
+		return nil, nil, errors.New("The specified target player is not valid")
+	}
+
+	if !target.Equivalent(proposer) {
+		return nil, nil, errors.New("You can't bid on behalf of another player")
+	}
+
+	if target.Eliminated(state) {
+		return nil, nil, errors.New("Eliminated players can't take part in auctions")
+	}
+
+	player, ok := state.PlayerStates()[target].(moveinterfaces.AuctionPlayerProperties)
+
+	if !ok {
+		return nil, nil, errors.New("PlayerState does not implement AuctionPlayerProperties")
+	}
+
+	if player.AuctionPassed() {
+		return nil, nil, errors.New("You have already passed")
+	}
+
+	return game, player, nil
+}
+
+//minimumAuctionBid returns the smallest amount that could be bid right now.
+func minimumAuctionBid(game moveinterfaces.AuctionProperties) int {
+	switch game.AuctionStyle() {
+	case AuctionEnglish, AuctionOnceAround:
+		if game.AuctionHighBidder() >= 0 {
+			return game.AuctionPrice() + game.AuctionIncrement()
+		}
+	case AuctionDutch:
+		return game.AuctionPrice()
+	}
+	return game.AuctionReserve()
+}
+
+//defaultAuctionBidder returns the player whose turn it is to bid, or
+//ObserverPlayerIndex in sealed and Dutch auctions, where every player may bid
+//at any time so there is no sensible default.
+func defaultAuctionBidder(game moveinterfaces.AuctionProperties) boardgame.PlayerIndex {
+	switch game.AuctionStyle() {
+	case AuctionEnglish, AuctionOnceAround:
+		return game.AuctionCurrentBidder()
+	}
+	return boardgame.ObserverPlayerIndex
+}
+
+//auctionPlayerDone returns true if the player should be skipped when
+//advancing the current bidder.
+func auctionPlayerDone(style int, player moveinterfaces.AuctionPlayerProperties) bool {
+	if player.AuctionPassed() {
+		return true
+	}
+	return style == AuctionOnceAround && player.AuctionHasBid()
+}
+
+//advanceAuctionBidder moves AuctionCurrentBidder forward to the next active
+//player who is still in the auction, picking them the same way a RoundRobin
+//in RoundRobinOrderForward would. If there is no such player it stays where
+//it is.
+func advanceAuctionBidder(state boardgame.State, game moveinterfaces.AuctionProperties) {
+
+	order := playerOrder(state, game.AuctionCurrentBidder(), false)
+
+	//The current bidder is at position 0.
+	player, _, found := nextPlayerInOrder(state, 0, func(round int) []boardgame.PlayerIndex {
+		return order
+	}, func(playerState boardgame.PlayerState) bool {
+		return auctionPlayerDone(game.AuctionStyle(), playerState.(moveinterfaces.AuctionPlayerProperties))
+	})
+
+	if found {
+		game.SetAuctionCurrentBidder(player)
+	}
+}
+
+//auctionOver returns true if no more bids or passes are possible in the
+//active auction.
+func auctionOver(state boardgame.State, game moveinterfaces.AuctionProperties) bool {
+
+	style := game.AuctionStyle()
+
+	if style == AuctionDutch {
+		return game.AuctionHighBidder() >= 0 || game.AuctionPrice() < game.AuctionReserve()
+	}
+
+	remaining := 0
+	highBidderRemaining := false
+
+	for i, p := range state.PlayerStates() {
+		if boardgame.PlayerIndex(i).Eliminated(state) {
+			continue
+		}
+		player := p.(moveinterfaces.AuctionPlayerProperties)
+		if style == AuctionSealed {
+			if !player.AuctionPassed() && !player.AuctionHasBid() {
+				return false
+			}
+			continue
+		}
+		if auctionPlayerDone(style, player) {
+			continue
+		}
+		remaining++
+		if boardgame.PlayerIndex(i) == game.AuctionHighBidder() {
+			highBidderRemaining = true
+		}
+	}
+
+	if style == AuctionSealed {
+		return true
+	}
+
+	if remaining == 0 {
+		return true
+	}
+
+	//In an English auction, once everyone but the high bidder has dropped
+	//out there's no one left to outbid them.
+	return style == AuctionEnglish && remaining == 1 && highBidderRemaining
+
+}
+
+func resetAuctionPlayers(state boardgame.MutableState) error {
+	for _, p := range state.MutablePlayerStates() {
+		player, ok := p.(moveinterfaces.AuctionPlayerProperties)
+		if !ok {
+			return errors.New("PlayerState does not implement AuctionPlayerProperties")
+		}
+		player.SetAuctionBid(0)
+		player.SetAuctionHasBid(false)
+		player.SetAuctionPassed(false)
+	}
+	return nil
+}
@@ -0,0 +1,356 @@
+package moves
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/workfit/tester/assert"
+	"strings"
+	"testing"
+)
+
+func (p *playerState) AuctionBudget() int {
+	return 10
+}
+
+//+autoreader
+type moveStartAuction struct {
+	StartAuction
+	style int
+}
+
+func (m *moveStartAuction) AuctionStyle() int {
+	return m.style
+}
+
+func (m *moveStartAuction) AuctionStartingPrice(state boardgame.State) int {
+	return 3
+}
+
+func (m *moveStartAuction) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+	if err := m.StartAuction.Legal(state, proposer); err != nil {
+		return err
+	}
+	//Only run one auction per game in the test.
+	if state.Version() > 0 {
+		return errors.New("The auction already happened")
+	}
+	return nil
+}
+
+//+autoreader
+type moveAuctionBid struct {
+	AuctionBid
+}
+
+//+autoreader
+type moveAuctionPass struct {
+	AuctionPass
+}
+
+//+autoreader
+type moveResolveAuction struct {
+	ResolveAuction
+}
+
+func (m *moveResolveAuction) AuctionResolved(state boardgame.MutableState, winner boardgame.PlayerIndex, price int) error {
+	game, players := concreteStates(state)
+	game.Counter = price
+	if winner >= 0 {
+		players[winner].Counter = price
+	}
+	return nil
+}
+
+func auctionMoveInstaller(style int) func(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle {
+	return func(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle {
+		startAuctionConfig := MustDefaultConfig(manager, new(moveStartAuction))
+		startAuctionConfig.MoveConstructor = func() boardgame.Move {
+			return &moveStartAuction{style: style}
+		}
+		return boardgame.NewMoveTypeConfigBundle().AddMoves(
+			startAuctionConfig,
+			MustDefaultConfig(manager, new(moveAuctionBid)),
+			MustDefaultConfig(manager, new(moveAuctionPass)),
+			MustDefaultConfig(manager, new(moveResolveAuction)),
+		)
+	}
+}
+
+type auctionAction struct {
+	player boardgame.PlayerIndex
+	bid    int
+	pass   bool
+	legal  bool
+}
+
+func TestAuction(t *testing.T) {
+
+	tests := []struct {
+		description    string
+		style          int
+		actions        []auctionAction
+		expectedWinner boardgame.PlayerIndex
+		expectedPrice  int
+	}{
+		{
+			"English",
+			AuctionEnglish,
+			[]auctionAction{
+				{1, 2, false, false},
+				{0, 2, false, true},
+				{1, 2, false, false},
+				{1, 3, false, true},
+				{2, 11, false, false},
+				{2, 0, true, true},
+				{3, 5, false, true},
+				{0, 0, true, true},
+				{1, 0, true, true},
+			},
+			3,
+			5,
+		},
+		{
+			"Sealed",
+			AuctionSealed,
+			[]auctionAction{
+				{2, 4, false, true},
+				{2, 5, false, false},
+				{0, 4, false, true},
+				{1, 0, true, true},
+				{3, 2, false, true},
+			},
+			0,
+			4,
+		},
+		{
+			"Dutch",
+			AuctionDutch,
+			[]auctionAction{
+				{1, 2, false, false},
+				{0, 0, true, true},
+				{1, 0, true, true},
+				{2, 0, true, true},
+				{3, 0, true, true},
+				{1, 3, false, false},
+				{1, 2, false, true},
+			},
+			1,
+			2,
+		},
+		{
+			"Once Around",
+			AuctionOnceAround,
+			[]auctionAction{
+				{0, 2, false, true},
+				{1, 0, true, true},
+				{2, 6, false, true},
+				{0, 7, false, false},
+				{3, 4, false, false},
+				{3, 0, true, true},
+			},
+			2,
+			6,
+		},
+		{
+			"Dutch unsold",
+			AuctionDutch,
+			[]auctionAction{
+				{0, 0, true, true},
+				{1, 0, true, true},
+				{2, 0, true, true},
+				{3, 0, true, true},
+				{0, 0, true, true},
+				{1, 0, true, true},
+				{2, 0, true, true},
+				{3, 0, true, true},
+				{0, 0, true, true},
+				{1, 0, true, true},
+				{2, 0, true, true},
+				{3, 0, true, true},
+				{0, 1, false, false},
+			},
+			boardgame.ObserverPlayerIndex,
+			0,
+		},
+	}
+
+	for i, test := range tests {
+		manager, err := newGameManager(auctionMoveInstaller(test.style))
+
+		assert.For(t, i, test.description).ThatActual(err).IsNil()
+
+		game := manager.NewGame()
+
+		err = game.SetUp(0, nil, nil)
+
+		assert.For(t, i, test.description).ThatActual(err).IsNil()
+
+		gameState, _ := concreteStates(game.CurrentState())
+
+		assert.For(t, i, test.description).ThatActual(gameState.AUActive).IsTrue()
+		assert.For(t, i, test.description).ThatActual(gameState.AUStyle).Equals(test.style)
+
+		for j, action := range test.actions {
+			var move boardgame.Move
+			if action.pass {
+				pass := game.PlayerMoveByName("Auction Pass").(*moveAuctionPass)
+				pass.TargetPlayerIndex = action.player
+				move = pass
+			} else {
+				bid := game.PlayerMoveByName("Auction Bid").(*moveAuctionBid)
+				bid.TargetPlayerIndex = action.player
+				bid.Amount = action.bid
+				move = bid
+			}
+			err := <-game.ProposeMove(move, action.player)
+			if action.legal {
+				assert.For(t, i, test.description, j).ThatActual(err).IsNil()
+			} else {
+				assert.For(t, i, test.description, j).ThatActual(err).IsNotNil()
+			}
+		}
+
+		gameState, players := concreteStates(game.CurrentState())
+
+		assert.For(t, i, test.description).ThatActual(gameState.AUActive).IsFalse()
+		assert.For(t, i, test.description).ThatActual(gameState.AUHighBidder).Equals(test.expectedWinner)
+		assert.For(t, i, test.description).ThatActual(gameState.AUPrice).Equals(test.expectedPrice)
+
+		if test.expectedWinner >= 0 {
+			assert.For(t, i, test.description).ThatActual(players[test.expectedWinner].Counter).Equals(test.expectedPrice)
+		}
+	}
+
+}
+
+func TestAuctionSealedBidsHidden(t *testing.T) {
+	manager, err := newGameManager(auctionMoveInstaller(AuctionSealed))
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	bid := game.PlayerMoveByName("Auction Bid").(*moveAuctionBid)
+	bid.TargetPlayerIndex = 2
+	bid.Amount = 7
+
+	assert.For(t).ThatActual(<-game.ProposeMove(bid, 2)).IsNil()
+
+	_, players := concreteStates(game.CurrentState().SanitizedForPlayer(0))
+
+	assert.For(t).ThatActual(players[2].AUHasBid).IsTrue()
+	assert.For(t).ThatActual(players[2].AUBid).Equals(0)
+
+	_, players = concreteStates(game.CurrentState().SanitizedForPlayer(2))
+
+	assert.For(t).ThatActual(players[2].AUBid).Equals(7)
+
+	//The move history is visible to every player, so the bid can't be in it.
+	record, err := manager.Storage().Move(game.Id(), game.Version())
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(record.Name).Equals("Auction Bid")
+	assert.For(t).ThatActual(strings.Contains(string(record.Blob), "Amount")).IsFalse()
+}
+
+func TestAuctionEliminatedPlayers(t *testing.T) {
+
+	tests := []struct {
+		description    string
+		style          int
+		actions        []auctionAction
+		expectedWinner boardgame.PlayerIndex
+		expectedPrice  int
+	}{
+		{
+			"English",
+			AuctionEnglish,
+			[]auctionAction{
+				{0, 2, false, false},
+				{1, 2, false, true},
+				{2, 3, false, false},
+				{3, 3, false, true},
+				{1, 0, true, true},
+			},
+			3,
+			3,
+		},
+		{
+			"Sealed",
+			AuctionSealed,
+			[]auctionAction{
+				{0, 9, false, false},
+				{1, 4, false, true},
+				{3, 5, false, true},
+			},
+			3,
+			5,
+		},
+		{
+			"Dutch",
+			AuctionDutch,
+			[]auctionAction{
+				{1, 0, true, true},
+				{3, 0, true, true},
+				{1, 2, false, true},
+			},
+			1,
+			2,
+		},
+	}
+
+	for i, test := range tests {
+		manager, err := newGameManagerWithDelegate(&gameDelegate{
+			moveInstaller: auctionMoveInstaller(test.style),
+			eliminated:    []boardgame.PlayerIndex{0, 2},
+		})
+
+		assert.For(t, i, test.description).ThatActual(err).IsNil()
+
+		game := manager.NewGame()
+
+		assert.For(t, i, test.description).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+		gameState, _ := concreteStates(game.CurrentState())
+
+		//Player 0 would normally start, but has been eliminated.
+		assert.For(t, i, test.description).ThatActual(gameState.AUStarterPlayer).Equals(boardgame.PlayerIndex(1))
+
+		bid := game.PlayerMoveByName("Auction Bid").(*moveAuctionBid)
+
+		if test.style == AuctionEnglish {
+			assert.For(t, i, test.description).ThatActual(bid.TargetPlayerIndex).Equals(boardgame.PlayerIndex(1))
+		} else {
+			assert.For(t, i, test.description).ThatActual(bid.TargetPlayerIndex).Equals(boardgame.ObserverPlayerIndex)
+		}
+
+		for j, action := range test.actions {
+			var move boardgame.Move
+			if action.pass {
+				pass := game.PlayerMoveByName("Auction Pass").(*moveAuctionPass)
+				pass.TargetPlayerIndex = action.player
+				move = pass
+			} else {
+				bid := game.PlayerMoveByName("Auction Bid").(*moveAuctionBid)
+				bid.TargetPlayerIndex = action.player
+				bid.Amount = action.bid
+				move = bid
+			}
+			err := <-game.ProposeMove(move, action.player)
+			if action.legal {
+				assert.For(t, i, test.description, j).ThatActual(err).IsNil()
+			} else {
+				assert.For(t, i, test.description, j).ThatActual(err).IsNotNil()
+			}
+		}
+
+		gameState, _ = concreteStates(game.CurrentState())
+
+		assert.For(t, i, test.description).ThatActual(gameState.AUActive).IsFalse()
+		assert.For(t, i, test.description).ThatActual(gameState.AUHighBidder).Equals(test.expectedWinner)
+		assert.For(t, i, test.description).ThatActual(gameState.AUPrice).Equals(test.expectedPrice)
+	}
+
+}
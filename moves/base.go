@@ -1,15 +1,44 @@
 package moves
 
 import (
-	"errors"
 	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/errors"
+	"github.com/jkomoros/boardgame/i18n"
 	"github.com/jkomoros/boardgame/moves/moveinterfaces"
 	"strconv"
+	"strings"
 	"sync"
 )
 
 //go:generate autoreader
 
+//The message ids of the errors returned by Base's Legal, for use in a game's
+//i18n.Catalog.
+const (
+	MessageIllegalPhase        = "moves.illegal_phase"
+	MessageProgressionMismatch = "moves.progression_mismatch"
+	MessageNoMoreMoves         = "moves.no_more_moves"
+	MessageExpectedMove        = "moves.expected_move"
+	MessageExpectedMoves       = "moves.expected_moves"
+	MessageEarlierMoveLegal    = "moves.earlier_move_legal"
+	MessageNotYourTurn         = "moves.not_your_turn"
+	MessageInvalidTarget       = "moves.invalid_target_player"
+	MessagePlayerEliminated    = "moves.player_eliminated"
+	MessageReactionPending     = "moves.reaction_pending"
+)
+
+//The codes of the errors returned by the Legal methods of moves in this
+//package. All of them have the IllegalMove category except CodeNotYourTurn,
+//which has the NotYourTurn category.
+const (
+	CodeIllegalPhase     errors.Code = "illegal_phase"
+	CodeOutOfOrder       errors.Code = "out_of_order"
+	CodeNotYourTurn      errors.Code = "not_your_turn"
+	CodeInvalidTarget    errors.Code = "invalid_target_player"
+	CodePlayerEliminated errors.Code = "player_eliminated"
+	CodeReactionPending  errors.Code = "reaction_pending"
+)
+
 //game.Name() to set of move types that are always legal
 var alwaysLegalMoveTypesByGame map[string]map[string]bool
 var alwaysLegalMoveTypesMutex sync.RWMutex
@@ -105,19 +134,37 @@ func (b *Base) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
 //is one of the LegalPhases for this moveType. A zero-length LegalPhases is
 //interpreted as the move being legal in all phases. The string for the
 //current phase will be based on the enum value of the PhaseEnum named by
-//delegate.PhaseEnumName(), if it exists. Next, it checks to see if the give
+//delegate.PhaseEnumName(), if it exists. Then it checks the move's fields
+//against the move type's FieldConstraints. Next, it checks to see if the give
 //move is at a legal point in the move progression for this phase, if it
-//exists. Each move in the move progression must show up 1 or more times. The
-//method checks to see if we were to make this move, would the moves since the
-//last phase change match the pattern? If your move can be made legally
-//multiple times in a row in a given move progression, implement
-//moveinterfaces.AllowMultipleInProgression() and return true.
+//exists. The method checks to see if we were to make this move, would the
+//moves since the last phase change still be on track to match the
+//progression? If not, the error names the moves that are expected next. See
+//boardgame.MoveProgression for how to describe progressions with optional,
+//repeated and alternative steps. If your move can be made legally multiple
+//times in a row wherever it appears in a progression, implement
+//moveinterfaces.AllowMultipleInProgression() and return true; a different
+//move may then only follow it once it is no longer legal. Finally, while a
+//reaction window is open (see OpenReactionWindow) only the reaction moves
+//are legal, and they are exempt from the phase's move progression.
 func (d *Base) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
 
 	if err := d.legalInPhase(state); err != nil {
 		return err
 	}
 
+	if err := d.Info().Type().CheckFieldConstraints(d.TopLevelStruct(), state, proposer); err != nil {
+		return err
+	}
+
+	if err := legalInReactionWindow(state, d.TopLevelStruct()); err != nil {
+		return err
+	}
+
+	if _, ok := d.TopLevelStruct().(reactionWindowMove); ok {
+		return nil
+	}
+
 	return d.legalMoveInProgression(state, proposer)
 
 }
@@ -146,7 +193,7 @@ func (d *Base) legalInPhase(state boardgame.State) error {
 		phaseName = phaseEnum.String(currentPhase)
 	}
 
-	return errors.New("Move is not legal in phase " + phaseName)
+	return i18n.NewError(MessageIllegalPhase, "Move is not legal in phase {phase}", i18n.Params{"phase": phaseName}).WithCode(CodeIllegalPhase, errors.IllegalMove)
 }
 
 func (d *Base) historicalMovesSincePhaseTransition(game *boardgame.Game, upToVersion int, targetPhase int) []*boardgame.MoveStorageRecord {
@@ -177,6 +224,14 @@ func (d *Base) historicalMovesSincePhaseTransition(game *boardgame.Game, upToVer
 			}
 		}
 
+		//Reaction moves interrupt the progression rather than being part of
+		//it, so skip them too.
+		for _, playerMove := range game.Manager().PlayerMoveTypes() {
+			if _, ok := playerMove.NewMove(game.CurrentState()).(reactionWindowMove); ok {
+				alwaysLegalMoveTypes[playerMove.Name()] = true
+			}
+		}
+
 		alwaysLegalMoveTypesMutex.Lock()
 		alwaysLegalMoveTypesByGame[game.Name()] = alwaysLegalMoveTypes
 		alwaysLegalMoveTypesMutex.Unlock()
@@ -225,15 +280,23 @@ func (d *Base) legalMoveInProgression(state boardgame.State, proposer boardgame.
 
 	historicalMoves := d.historicalMovesSincePhaseTransition(state.Game(), state.Version(), currentPhase)
 
-	progression := make([]string, len(historicalMoves))
+	allowRepeat := func(name string) bool {
+		return allowMultipleInProgression(state, name)
+	}
+
+	matcher := boardgame.NewProgressionMatcher(pattern, allowRepeat)
 
-	for i, move := range historicalMoves {
-		progression[i] = move.Name
+	for _, move := range historicalMoves {
+		if !matcher.Advance(move.Name) {
+			return i18n.NewError(MessageProgressionMismatch, "The moves so far in this phase don't match the phase's move progression.", nil).WithCode(CodeOutOfOrder, errors.IllegalMove)
+		}
 	}
 
+	name := d.Info().Type().Name()
+
 	//If we were to add our target move to the historical progression, would it match the pattern?
-	if !progressionMatches(append(progression, d.Info().Type().Name()), pattern) {
-		return errors.New("This move is not legal at this point in the current phase.")
+	if !matcher.Advance(name) {
+		return outOfOrderError(matcher.Next())
 	}
 
 	//Are we a new type of move in the progression? if so, is the move before
@@ -246,28 +309,20 @@ func (d *Base) legalMoveInProgression(state boardgame.State, proposer boardgame.
 
 	lastMoveRecord := historicalMoves[len(historicalMoves)-1]
 
-	if lastMoveRecord.Name == d.Info().Type().Name() {
-
-		//We're applying multiple in a row. Is that legal?
-
-		//We can't check ourselves because we're embedded in the real move type.
-		testMove := d.TopLevelStruct()
-
-		allowMultiple, ok := testMove.(moveinterfaces.AllowMultipleInProgression)
-
-		if !ok || !allowMultiple.AllowMultipleInProgression() {
-			return errors.New("This move was just applied and is not configured to allow multiple in a row in this phase.")
-		}
-
+	if lastMoveRecord.Name == name {
+		//We're applying multiple in a row, which the progression allows.
 		return nil
 	}
 
-	lastMoveType := state.Game().Manager().FixUpMoveTypeByName(lastMoveRecord.Name)
-
-	if lastMoveType == nil {
-		lastMoveType = state.Game().Manager().PlayerMoveTypeByName(lastMoveRecord.Name)
+	//Only moves that may be applied multiple times in a row need to be
+	//finished before moving on; for any other move the progression itself
+	//determines whether it's done.
+	if !allowRepeat(lastMoveRecord.Name) {
+		return nil
 	}
 
+	lastMoveType := moveTypeByName(state, lastMoveRecord.Name)
+
 	if lastMoveType == nil {
 		return errors.New("Unexpected error: couldn't find a historical move type")
 	}
@@ -276,53 +331,47 @@ func (d *Base) legalMoveInProgression(state boardgame.State, proposer boardgame.
 	lastMove := lastMoveType.NewMove(state)
 
 	if lastMove.Legal(state, proposer) == nil {
-		return errors.New("A move that needs to happen earlier in the phase is still legal to apply.")
+		return i18n.NewError(MessageEarlierMoveLegal, "A move that needs to happen earlier in the phase is still legal to apply.", nil).WithCode(CodeOutOfOrder, errors.IllegalMove)
 	}
 
 	return nil
 
 }
 
-//progressionMatches returns true if the given history matches the pattern.
-func progressionMatches(input []string, pattern []string) bool {
-
-	inputPosition := 0
-	patternPosition := 0
-
-	for inputPosition < len(input) {
+//moveTypeByName returns the fix up or player move type with the given name,
+//or nil if there isn't one.
+func moveTypeByName(state boardgame.State, name string) *boardgame.MoveType {
+	moveType := state.Game().Manager().FixUpMoveTypeByName(name)
 
-		inputItem := input[inputPosition]
-		patternItem := pattern[patternPosition]
-
-		if inputItem != patternItem {
-			//Perhaps we just passed to the next part of the pattern?
-
-			//that's not legal at the very front of input
-			if inputPosition == 0 {
-				return false
-			}
+	if moveType == nil {
+		moveType = state.Game().Manager().PlayerMoveTypeByName(name)
+	}
 
-			patternPosition++
+	return moveType
+}
 
-			if patternPosition >= len(pattern) {
-				//No more pattern, I guess we didn't match.
-				return false
-			}
+//allowMultipleInProgression returns true if the move type with the given
+//name implements moveinterfaces.AllowMultipleInProgression and returns true.
+func allowMultipleInProgression(state boardgame.State, name string) bool {
+	moveType := moveTypeByName(state, name)
 
-			patternItem = pattern[patternPosition]
+	if moveType == nil {
+		return false
+	}
 
-			if inputItem != patternItem {
-				//Nope, we didn't match the next part of the pattern, we just don't match
-				return false
-			}
+	allowMultiple, ok := moveType.NewMove(state).(moveinterfaces.AllowMultipleInProgression)
 
-		}
-
-		inputPosition++
+	return ok && allowMultiple.AllowMultipleInProgression()
+}
 
+//outOfOrderError returns the error for a move that isn't legal at this point
+//in a progression, naming the moves that may come next.
+func outOfOrderError(next []string) error {
+	if len(next) == 0 {
+		return i18n.NewError(MessageNoMoreMoves, "This move is not legal at this point in the current phase. No more moves are expected in this phase.", nil).WithCode(CodeOutOfOrder, errors.IllegalMove)
 	}
-
-	//If we got to the end of the input without invalidating then it passes.
-	return true
-
+	if len(next) == 1 {
+		return i18n.NewError(MessageExpectedMove, "This move is not legal at this point in the current phase. Expected {move}.", i18n.Params{"move": next[0]}).WithCode(CodeOutOfOrder, errors.IllegalMove)
+	}
+	return i18n.NewError(MessageExpectedMoves, "This move is not legal at this point in the current phase. Expected one of {moves}.", i18n.Params{"moves": strings.Join(next, ", ")}).WithCode(CodeOutOfOrder, errors.IllegalMove)
 }
@@ -1,8 +1,9 @@
 package moves
 
 import (
-	"errors"
 	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/errors"
+	"github.com/jkomoros/boardgame/i18n"
 )
 
 /*
@@ -29,7 +30,8 @@ type CurrentPlayer struct {
 
 //Legal will return an error if the TargetPlayerIndex is not the
 //CurrentPlayerIndex, if the TargetPlayerIndex is not equivalent to the
-//proposer, or if the TargetPlayerIndex is not one of the players.
+//proposer, if the TargetPlayerIndex is not one of the players, or if that
+//player has been eliminated.
 func (c *CurrentPlayer) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
 
 	if err := c.Base.Legal(state, proposer); err != nil {
@@ -39,19 +41,23 @@ func (c *CurrentPlayer) Legal(state boardgame.State, proposer boardgame.PlayerIn
 	currentPlayer := state.CurrentPlayerIndex()
 
 	if !c.TargetPlayerIndex.Valid(state) {
-		return errors.New("The specified target player is not valid")
+		return i18n.NewError(MessageInvalidTarget, "The specified target player is not valid", nil).WithCode(CodeInvalidTarget, errors.IllegalMove)
 	}
 
 	if c.TargetPlayerIndex < 0 {
-		return errors.New("The specified target player is not valid")
+		return i18n.NewError(MessageInvalidTarget, "The specified target player is not valid", nil).WithCode(CodeInvalidTarget, errors.IllegalMove)
+	}
+
+	if c.TargetPlayerIndex.Eliminated(state) {
+		return i18n.NewError(MessagePlayerEliminated, "The specified target player has been eliminated", nil).WithCode(CodePlayerEliminated, errors.IllegalMove)
 	}
 
 	if !c.TargetPlayerIndex.Equivalent(currentPlayer) {
-		return errors.New("It's not your turn!")
+		return i18n.NewError(MessageNotYourTurn, "It's not your turn!", nil).WithCode(CodeNotYourTurn, errors.NotYourTurn)
 	}
 
 	if !c.TargetPlayerIndex.Equivalent(proposer) {
-		return errors.New("It's not your turn!")
+		return i18n.NewError(MessageNotYourTurn, "It's not your turn!", nil).WithCode(CodeNotYourTurn, errors.NotYourTurn)
 	}
 
 	return nil
@@ -107,14 +107,17 @@ These moves are what the MoveComponents moves are based off of and are
 designed to be subclassed. They apply the move in question until some
 condition is reached.
 
-RoundRobin and RoundRobinNumRounds
+RoundRobin, RoundRobinNumRounds, and RoundRobinPassOut
 
 Round Robin moves are like ApplyUntilCount and friends, except they go around
 and operate on each player in succession. RoundRobinNumRounds goes around each
 player until NumRounds() cycles have completed. The base RoundRobin goes
-around until the PlayerCondition has been met for each player. These are the
-most complicated moves in the set; if you subclass one directly you're most
-likely to subclass RoundRobinNumRounds.
+around until the PlayerCondition has been met for each player.
+RoundRobinPassOut goes around skipping players who have passed until every
+player has passed. By default players are visited in PlayerIndex order, but
+override RoundRobinOrder to use reverse, snake, or priority order instead.
+These are the most complicated moves in the set; if you subclass one directly
+you're most likely to subclass RoundRobinNumRounds.
 
 FinishTurn
 
@@ -131,6 +134,63 @@ last move in your game's SetUp phase. In general you don't need to subclass
 this move directly at all; just use NewStartPhaseConfig to get a
 MoveTyepConfig that does what you want.
 
+If your delegate returns a boardgame.PhaseMachine from PhaseMachine(),
+StartPhase won't be legal if the transition from the current phase to the
+phase it would start wasn't declared on the machine.
+
+SimultaneousCommit, SimultaneousReveal, and SimultaneousRevealDeadline
+
+These moves are for games where every player secretly chooses something at
+the same time and then all choices are revealed at once, like
+rock-paper-scissors or sealed bids. SimultaneousCommit is a player move that
+any player may make at any time to record (or change) their hidden
+commitment. SimultaneousReveal is a fix-up move that reveals every
+commitment once everyone has committed and then calls your
+ResolveCommitments. If you want players to have a limited time to commit,
+override CommitDeadline and install a move that embeds
+SimultaneousRevealDeadline, which will reveal whatever has been committed
+when the deadline passes.
+
+StartAuction, AuctionBid, AuctionPass, and ResolveAuction
+
+These moves implement bidding. StartAuction is a fix-up move that starts an
+auction for a lot, configured by overriding methods like AuctionStyle,
+AuctionReserve, and AuctionIncrement on your embedding move. English, sealed,
+Dutch, and once-around auctions are supported. Players then make AuctionBid
+and AuctionPass moves, with turn order in open auctions going around the
+table skipping players who have dropped out, like RoundRobin. Once the
+auction is over ResolveAuction picks the winner (breaking ties in sealed
+auctions with AuctionTieBreak) and calls your AuctionResolved. Player budgets
+are enforced if your playerState implements AuctionBudgeter.
+
+ProposeTrade, CounterTrade, AcceptTrade, RejectTrade, and WithdrawTrade
+
+These moves implement trades between two players. A player proposes a trade
+to another player, who can then accept it, reject it, or counter with a
+different offer, and the proposer may withdraw it until they do. Pending
+offers are stored on both players' states and hidden from everyone else.
+Your playerState tells the moves what a player holds and how to hand it over
+by implementing TradeHolder, and AcceptTrade checks both sides still hold
+what they're trading right before the trade happens.
+
+OpenReactionWindow, Reaction, and ReactionPass
+
+These let players respond out of turn to something that just happened, like
+blocking an attack or countering a spell. The triggering move's Apply calls
+OpenReactionWindow, and each eligible player then gets a chance, in priority
+order, to either react with a move embedding Reaction (whose React you
+implement) or decline with ReactionPass. While the window is open every
+other move that embeds Base is illegal, so the game resumes right where it
+left off once the window closes. Windows may have a timeout, after which the
+responder automatically passes.
+
+Resign and TimeOut
+
+Resign lets a player drop out of the game, and TimeOut, which only the admin
+may make, drops out a player who has run out of time. Both eliminate the
+player, and if that finishes the game they set the game's Result reason to
+boardgame.EndReasonResignation or boardgame.EndReasonTimeout.
+
 ShuffleStack
 
 Shuffle stack is a simple move that just shuffles the stack denoted by
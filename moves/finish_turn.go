@@ -31,7 +31,8 @@ func (f *FinishTurn) ValidConfiguration(exampleState boardgame.MutableState) err
 	return nil
 }
 
-//Legal checks if the game's CurrentPlayer's TurnDone() returns true.
+//Legal checks if the game's CurrentPlayer's TurnDone() returns true, or if
+//the CurrentPlayer has been eliminated.
 func (f *FinishTurn) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
 
 	if err := f.Base.Legal(state, proposer); err != nil {
@@ -48,6 +49,11 @@ func (f *FinishTurn) Legal(state boardgame.State, proposer boardgame.PlayerIndex
 		return errors.New("Current player is not valid")
 	}
 
+	//Eliminated players' turns are always done.
+	if currentPlayerIndex.Eliminated(state) {
+		return nil
+	}
+
 	currentPlayer := state.PlayerStates()[currentPlayerIndex]
 
 	currentPlayerTurnFinisher, ok := currentPlayer.(moveinterfaces.PlayerTurnFinisher)
@@ -65,8 +71,8 @@ func (f *FinishTurn) Legal(state boardgame.State, proposer boardgame.PlayerIndex
 }
 
 //Aoply resets the current player via ResetForTurnEnd, then advances to the
-//next player (using game.SetCurrentPlayer), then calls ResetForTurnStart on
-//the new player.
+//next player who hasn't been eliminated (using game.SetCurrentPlayer), then
+//calls ResetForTurnStart on the new player.
 func (f *FinishTurn) Apply(state boardgame.MutableState) error {
 	currentPlayer := state.PlayerStates()[state.CurrentPlayerIndex()]
 
@@ -76,11 +82,13 @@ func (f *FinishTurn) Apply(state boardgame.MutableState) error {
 		return errors.New("The current player interface did not implement PlayerTurnFinisher")
 	}
 
-	if err := currentPlayerTurnFinisher.ResetForTurnEnd(); err != nil {
-		return errors.New("Couldn't reset for turn end: " + err.Error())
+	if !state.CurrentPlayerIndex().Eliminated(state) {
+		if err := currentPlayerTurnFinisher.ResetForTurnEnd(); err != nil {
+			return errors.New("Couldn't reset for turn end: " + err.Error())
+		}
 	}
 
-	newPlayerIndex := state.CurrentPlayerIndex().Next(state)
+	newPlayerIndex := state.CurrentPlayerIndex().NextActive(state)
 
 	playerSetter, ok := state.GameState().(moveinterfaces.CurrentPlayerSetter)
 
@@ -26,16 +26,25 @@ var phaseEnum = enums.MustAdd("Phase", map[int]string{
 //+autoreader
 type gameState struct {
 	moveinterfaces.RoundRobinBaseGameState
+	moveinterfaces.AuctionBaseGameState
+	moveinterfaces.ReactionBaseGameState
 	Phase         enum.MutableVal `enum:"Phase"`
 	CurrentPlayer boardgame.PlayerIndex
 	DrawStack     boardgame.MutableStack `stack:"cards"`
 	DiscardStack  boardgame.MutableStack `stack:"cards"`
 	Counter       int
+	Visits        []int
+	SCDeadline    boardgame.MutableTimer
 }
 
 //+autoreader
 type playerState struct {
 	boardgame.BaseSubState
+	boardgame.EliminatablePlayerState
+	moveinterfaces.SimultaneousCommitBasePlayerState
+	moveinterfaces.AuctionBasePlayerState
+	moveinterfaces.TradeBasePlayerState
+	moveinterfaces.ReactionBasePlayerState
 	playerIndex boardgame.PlayerIndex
 	Hand        boardgame.MutableStack `stack:"cards"`
 	OtherHand   boardgame.MutableStack `stack:"cards"`
@@ -50,6 +59,10 @@ func (g *gameState) SetCurrentPhase(phase int) {
 	g.Phase.SetValue(phase)
 }
 
+func (g *gameState) SimultaneousDeadlineTimer() boardgame.MutableTimer {
+	return g.SCDeadline
+}
+
 func concreteStates(state boardgame.State) (*gameState, []*playerState) {
 	game := state.GameState().(*gameState)
 
@@ -65,6 +78,21 @@ func concreteStates(state boardgame.State) (*gameState, []*playerState) {
 type gameDelegate struct {
 	boardgame.DefaultGameDelegate
 	moveInstaller func(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle
+	phaseMachine  *boardgame.PhaseMachine
+	//eliminated are the players who start the game already eliminated.
+	eliminated []boardgame.PlayerIndex
+}
+
+func (g *gameDelegate) FinishSetUp(state boardgame.MutableState) error {
+	_, players := concreteStates(state)
+	for _, index := range g.eliminated {
+		players[index].SetPlayerEliminated(true)
+	}
+	return nil
+}
+
+func (g *gameDelegate) PhaseMachine() *boardgame.PhaseMachine {
+	return g.phaseMachine
 }
 
 func (g *gameDelegate) Name() string {
@@ -100,12 +128,20 @@ func (g *gameDelegate) ConfigureMoves() *boardgame.MoveTypeConfigBundle {
 }
 
 func newGameManager(moveInstaller func(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle) (*boardgame.GameManager, error) {
+	return newGameManagerWithPhaseMachine(moveInstaller, nil)
+}
+
+func newGameManagerWithPhaseMachine(moveInstaller func(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle, machine *boardgame.PhaseMachine) (*boardgame.GameManager, error) {
+	return newGameManagerWithDelegate(&gameDelegate{moveInstaller: moveInstaller, phaseMachine: machine})
+}
+
+func newGameManagerWithDelegate(delegate *gameDelegate) (*boardgame.GameManager, error) {
 	chest := boardgame.NewComponentChest(enums)
 
 	if err := chest.AddDeck("cards", playingcards.NewDeck(false)); err != nil {
 		return nil, errors.New("couldn't add deck: " + err.Error())
 	}
 
-	return boardgame.NewGameManager(&gameDelegate{moveInstaller: moveInstaller}, chest, memory.NewStorageManager())
+	return boardgame.NewGameManager(delegate, chest, memory.NewStorageManager())
 
 }
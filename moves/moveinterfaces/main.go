@@ -11,6 +11,7 @@ package moveinterfaces
 
 import (
 	"github.com/jkomoros/boardgame"
+	"time"
 )
 
 //RoundRobinBaseGameState is designed to be embedded in your GameState
@@ -24,6 +25,7 @@ type RoundRobinBaseGameState struct {
 	RRStarterPlayer boardgame.PlayerIndex
 	RRRoundCount    int
 	RRHasStarted    bool
+	RRLastPosition  int
 }
 
 func (r *RoundRobinBaseGameState) RoundRobinLastPlayer() boardgame.PlayerIndex {
@@ -42,6 +44,10 @@ func (r *RoundRobinBaseGameState) RoundRobinHasStarted() bool {
 	return r.RRHasStarted
 }
 
+func (r *RoundRobinBaseGameState) RoundRobinLastPosition() int {
+	return r.RRLastPosition
+}
+
 func (r *RoundRobinBaseGameState) SetRoundRobinLastPlayer(nextPlayer boardgame.PlayerIndex) {
 	r.RRLastPlayer = nextPlayer
 }
@@ -57,6 +63,352 @@ func (r *RoundRobinBaseGameState) SetRoundRobinHasStarted(val bool) {
 	r.RRHasStarted = val
 }
 
+func (r *RoundRobinBaseGameState) SetRoundRobinLastPosition(position int) {
+	r.RRLastPosition = position
+}
+
+//SimultaneousCommitBasePlayerState is designed to be embedded in your
+//PlayerState anonymously to automatically satisfy the SimultaneousCommitter
+//interface, making it easy to use the SimultaneousCommit family of moves.
+//Unlike RoundRobinBaseGameState it does not embed boardgame.BaseSubState, so
+//you should embed it IN ADDITION to boardgame.BaseSubState. The pending
+//commitment is tagged as hidden so other players can see that a player has
+//committed but not what they committed to.
+type SimultaneousCommitBasePlayerState struct {
+	SCCommitment         int `sanitize:"hidden"`
+	SCHasCommitted       bool
+	SCRevealedCommitment int
+	SCHasRevealed        bool
+}
+
+func (s *SimultaneousCommitBasePlayerState) SimultaneousCommitment() int {
+	return s.SCCommitment
+}
+
+func (s *SimultaneousCommitBasePlayerState) SimultaneousHasCommitted() bool {
+	return s.SCHasCommitted
+}
+
+func (s *SimultaneousCommitBasePlayerState) SimultaneousRevealedCommitment() int {
+	return s.SCRevealedCommitment
+}
+
+func (s *SimultaneousCommitBasePlayerState) SimultaneousHasRevealed() bool {
+	return s.SCHasRevealed
+}
+
+func (s *SimultaneousCommitBasePlayerState) SetSimultaneousCommitment(commitment int) {
+	s.SCCommitment = commitment
+}
+
+func (s *SimultaneousCommitBasePlayerState) SetSimultaneousHasCommitted(val bool) {
+	s.SCHasCommitted = val
+}
+
+func (s *SimultaneousCommitBasePlayerState) SetSimultaneousRevealedCommitment(commitment int) {
+	s.SCRevealedCommitment = commitment
+}
+
+func (s *SimultaneousCommitBasePlayerState) SetSimultaneousHasRevealed(val bool) {
+	s.SCHasRevealed = val
+}
+
+//AuctionBaseGameState is designed to be embedded in your GameState
+//anonymously to automatically satisfy the AuctionProperties interface, making
+//it easy to use the Auction family of moves. It does not embed
+//boardgame.BaseSubState, so embed it alongside boardgame.BaseSubState (or
+//RoundRobinBaseGameState). All of these properties are public; only the
+//players' individual bids (see AuctionBasePlayerState) are hidden.
+type AuctionBaseGameState struct {
+	AUActive        bool
+	AUStyle         int
+	AULot           int
+	AUPrice         int
+	AUHighBidder    boardgame.PlayerIndex
+	AUCurrentBidder boardgame.PlayerIndex
+	AUStarterPlayer boardgame.PlayerIndex
+	AUIncrement     int
+	AUReserve       int
+}
+
+func (a *AuctionBaseGameState) AuctionActive() bool {
+	return a.AUActive
+}
+
+func (a *AuctionBaseGameState) AuctionStyle() int {
+	return a.AUStyle
+}
+
+func (a *AuctionBaseGameState) AuctionLot() int {
+	return a.AULot
+}
+
+func (a *AuctionBaseGameState) AuctionPrice() int {
+	return a.AUPrice
+}
+
+func (a *AuctionBaseGameState) AuctionHighBidder() boardgame.PlayerIndex {
+	return a.AUHighBidder
+}
+
+func (a *AuctionBaseGameState) AuctionCurrentBidder() boardgame.PlayerIndex {
+	return a.AUCurrentBidder
+}
+
+func (a *AuctionBaseGameState) AuctionStarterPlayer() boardgame.PlayerIndex {
+	return a.AUStarterPlayer
+}
+
+func (a *AuctionBaseGameState) AuctionIncrement() int {
+	return a.AUIncrement
+}
+
+func (a *AuctionBaseGameState) AuctionReserve() int {
+	return a.AUReserve
+}
+
+func (a *AuctionBaseGameState) SetAuctionActive(val bool) {
+	a.AUActive = val
+}
+
+func (a *AuctionBaseGameState) SetAuctionStyle(style int) {
+	a.AUStyle = style
+}
+
+func (a *AuctionBaseGameState) SetAuctionLot(lot int) {
+	a.AULot = lot
+}
+
+func (a *AuctionBaseGameState) SetAuctionPrice(price int) {
+	a.AUPrice = price
+}
+
+func (a *AuctionBaseGameState) SetAuctionHighBidder(player boardgame.PlayerIndex) {
+	a.AUHighBidder = player
+}
+
+func (a *AuctionBaseGameState) SetAuctionCurrentBidder(player boardgame.PlayerIndex) {
+	a.AUCurrentBidder = player
+}
+
+func (a *AuctionBaseGameState) SetAuctionStarterPlayer(player boardgame.PlayerIndex) {
+	a.AUStarterPlayer = player
+}
+
+func (a *AuctionBaseGameState) SetAuctionIncrement(increment int) {
+	a.AUIncrement = increment
+}
+
+func (a *AuctionBaseGameState) SetAuctionReserve(reserve int) {
+	a.AUReserve = reserve
+}
+
+//AuctionBasePlayerState is designed to be embedded in your PlayerState
+//anonymously to automatically satisfy the AuctionPlayerProperties interface.
+//Like SimultaneousCommitBasePlayerState, embed it alongside
+//boardgame.BaseSubState. AUBid is hidden from other players so that sealed
+//bids stay sealed; in open auctions the current high bid is mirrored on the
+//GameState.
+type AuctionBasePlayerState struct {
+	AUBid    int `sanitize:"hidden"`
+	AUHasBid bool
+	AUPassed bool
+}
+
+func (a *AuctionBasePlayerState) AuctionBid() int {
+	return a.AUBid
+}
+
+func (a *AuctionBasePlayerState) AuctionHasBid() bool {
+	return a.AUHasBid
+}
+
+func (a *AuctionBasePlayerState) AuctionPassed() bool {
+	return a.AUPassed
+}
+
+func (a *AuctionBasePlayerState) SetAuctionBid(bid int) {
+	a.AUBid = bid
+}
+
+func (a *AuctionBasePlayerState) SetAuctionHasBid(val bool) {
+	a.AUHasBid = val
+}
+
+func (a *AuctionBasePlayerState) SetAuctionPassed(val bool) {
+	a.AUPassed = val
+}
+
+//TradeBasePlayerState is designed to be embedded in your PlayerState
+//anonymously to automatically satisfy the TradeProperties interface, making
+//it easy to use the Trade family of moves. Embed it alongside
+//boardgame.BaseSubState. A pending offer is stored on both the proposer (as
+//Outgoing) and the recipient (as Incoming), with the contents hidden from
+//everyone else, so that only the two parties can see what is being offered.
+//Other players can still see that an offer is pending and between whom.
+type TradeBasePlayerState struct {
+	TROutgoingActive bool
+	TROutgoingTo     boardgame.PlayerIndex
+	TROutgoingGive   []int `sanitize:"hidden"`
+	TROutgoingTake   []int `sanitize:"hidden"`
+	TRIncomingActive bool
+	TRIncomingFrom   boardgame.PlayerIndex
+	TRIncomingGive   []int `sanitize:"hidden"`
+	TRIncomingTake   []int `sanitize:"hidden"`
+}
+
+func (t *TradeBasePlayerState) TradeOutgoingActive() bool {
+	return t.TROutgoingActive
+}
+
+//TradeOutgoingTo returns ObserverPlayerIndex if there is no outgoing offer,
+//since the zero value of TROutgoingTo is a real player.
+func (t *TradeBasePlayerState) TradeOutgoingTo() boardgame.PlayerIndex {
+	if !t.TROutgoingActive {
+		return boardgame.ObserverPlayerIndex
+	}
+	return t.TROutgoingTo
+}
+
+func (t *TradeBasePlayerState) TradeOutgoingGive() []int {
+	return t.TROutgoingGive
+}
+
+func (t *TradeBasePlayerState) TradeOutgoingTake() []int {
+	return t.TROutgoingTake
+}
+
+func (t *TradeBasePlayerState) TradeIncomingActive() bool {
+	return t.TRIncomingActive
+}
+
+//TradeIncomingFrom returns ObserverPlayerIndex if there is no incoming offer,
+//since the zero value of TRIncomingFrom is a real player.
+func (t *TradeBasePlayerState) TradeIncomingFrom() boardgame.PlayerIndex {
+	if !t.TRIncomingActive {
+		return boardgame.ObserverPlayerIndex
+	}
+	return t.TRIncomingFrom
+}
+
+func (t *TradeBasePlayerState) TradeIncomingGive() []int {
+	return t.TRIncomingGive
+}
+
+func (t *TradeBasePlayerState) TradeIncomingTake() []int {
+	return t.TRIncomingTake
+}
+
+func (t *TradeBasePlayerState) SetTradeOutgoingActive(active bool) {
+	t.TROutgoingActive = active
+}
+
+func (t *TradeBasePlayerState) SetTradeOutgoingTo(player boardgame.PlayerIndex) {
+	t.TROutgoingTo = player
+}
+
+func (t *TradeBasePlayerState) SetTradeOutgoingGive(resources []int) {
+	t.TROutgoingGive = resources
+}
+
+func (t *TradeBasePlayerState) SetTradeOutgoingTake(resources []int) {
+	t.TROutgoingTake = resources
+}
+
+func (t *TradeBasePlayerState) SetTradeIncomingActive(active bool) {
+	t.TRIncomingActive = active
+}
+
+func (t *TradeBasePlayerState) SetTradeIncomingFrom(player boardgame.PlayerIndex) {
+	t.TRIncomingFrom = player
+}
+
+func (t *TradeBasePlayerState) SetTradeIncomingGive(resources []int) {
+	t.TRIncomingGive = resources
+}
+
+func (t *TradeBasePlayerState) SetTradeIncomingTake(resources []int) {
+	t.TRIncomingTake = resources
+}
+
+//ReactionBaseGameState is designed to be embedded in your GameState
+//anonymously to automatically satisfy the ReactionProperties interface,
+//making it easy to use reaction windows (see moves.OpenReactionWindow). Embed
+//it alongside boardgame.BaseSubState. All of these properties are public;
+//which players are still waiting for their chance to respond is stored on
+//each player (see ReactionBasePlayerState) and hidden.
+type ReactionBaseGameState struct {
+	RWActive        bool
+	RWReacted       bool
+	RWTriggerPlayer boardgame.PlayerIndex
+	RWResponder     boardgame.PlayerIndex
+	//RWTimeout is in milliseconds.
+	RWTimeout int
+	RWTimer   boardgame.MutableTimer
+}
+
+func (r *ReactionBaseGameState) ReactionActive() bool {
+	return r.RWActive
+}
+
+func (r *ReactionBaseGameState) ReactionReacted() bool {
+	return r.RWReacted
+}
+
+func (r *ReactionBaseGameState) ReactionTriggerPlayer() boardgame.PlayerIndex {
+	return r.RWTriggerPlayer
+}
+
+func (r *ReactionBaseGameState) ReactionResponder() boardgame.PlayerIndex {
+	return r.RWResponder
+}
+
+func (r *ReactionBaseGameState) ReactionTimeout() time.Duration {
+	return time.Duration(r.RWTimeout) * time.Millisecond
+}
+
+func (r *ReactionBaseGameState) ReactionTimer() boardgame.MutableTimer {
+	return r.RWTimer
+}
+
+func (r *ReactionBaseGameState) SetReactionActive(active bool) {
+	r.RWActive = active
+}
+
+func (r *ReactionBaseGameState) SetReactionReacted(reacted bool) {
+	r.RWReacted = reacted
+}
+
+func (r *ReactionBaseGameState) SetReactionTriggerPlayer(player boardgame.PlayerIndex) {
+	r.RWTriggerPlayer = player
+}
+
+func (r *ReactionBaseGameState) SetReactionResponder(player boardgame.PlayerIndex) {
+	r.RWResponder = player
+}
+
+func (r *ReactionBaseGameState) SetReactionTimeout(timeout time.Duration) {
+	r.RWTimeout = int(timeout / time.Millisecond)
+}
+
+//ReactionBasePlayerState is designed to be embedded in your PlayerState
+//anonymously to automatically satisfy the ReactionPlayerProperties
+//interface. Embed it alongside boardgame.BaseSubState. RWPriority is hidden
+//from other players, so that they can't tell who else may respond to the
+//current window (for example, who is holding a counter card) until it is
+//that player's turn to.
+type ReactionBasePlayerState struct {
+	RWPriority int `sanitize:"hidden"`
+}
+
+func (r *ReactionBasePlayerState) ReactionPriority() int {
+	return r.RWPriority
+}
+
+func (r *ReactionBasePlayerState) SetReactionPriority(priority int) {
+	r.RWPriority = priority
+}
+
 //Moves should implement AllowMultipleInProgression if they want to
 //affirmatively communicate to moves.Base that in a move progression is it
 //legal to apply multiple. If the move does not implement this interface then
@@ -133,11 +485,17 @@ type RoundRobinProperties interface {
 	//RoundRobinHasStarted is true if the first move of a RoundRobin has been
 	//applied.
 	RoundRobinHasStarted() bool
+	//RoundRobinLastPosition is where the last successfully applied round robin
+	//player was in the overall order of turns, counting from 0 at the start
+	//of the round robin. Used to keep track of order modes like snake that
+	//can't be derived from the last player alone.
+	RoundRobinLastPosition() int
 
 	SetRoundRobinLastPlayer(nextPlayer boardgame.PlayerIndex)
 	SetRoundRobinStarterPlayer(index boardgame.PlayerIndex)
 	SetRoundRobinRoundCount(count int)
 	SetRoundRobinHasStarted(hasStarted bool)
+	SetRoundRobinLastPosition(position int)
 }
 
 //ConditionMetter should be implemented by moves that subclass
@@ -173,3 +531,207 @@ type BeforeLeavePhaser interface {
 type BeforeEnterPhaser interface {
 	BeforeEnterPhase(phase int, state boardgame.MutableState) error
 }
+
+//SimultaneousCommitter should be implemented by your PlayerState if you use
+//any of the SimultaneousCommit moves. Generally you simply embed
+//SimultaneousCommitBasePlayerState to satisfy this interface for free.
+type SimultaneousCommitter interface {
+	//The commitment the player has made this round but that has not yet
+	//been revealed. Should be hidden from other players.
+	SimultaneousCommitment() int
+	//Whether the player has committed this round.
+	SimultaneousHasCommitted() bool
+	//The commitment the player made in the most recently revealed round.
+	SimultaneousRevealedCommitment() int
+	//Whether the player had committed in the most recently revealed round.
+	//False if they missed the deadline.
+	SimultaneousHasRevealed() bool
+
+	SetSimultaneousCommitment(commitment int)
+	SetSimultaneousHasCommitted(hasCommitted bool)
+	SetSimultaneousRevealedCommitment(commitment int)
+	SetSimultaneousHasRevealed(hasRevealed bool)
+}
+
+//SimultaneousDeadliner should be implemented by your GameState if your
+//SimultaneousCommit move returns a non-zero CommitDeadline. The timer will be
+//started when the first player commits, and when it fires the commitments
+//will be revealed even if not every player has committed.
+type SimultaneousDeadliner interface {
+	SimultaneousDeadlineTimer() boardgame.MutableTimer
+}
+
+//SimultaneousResolver should be implemented by moves that embed
+//SimultaneousReveal or SimultaneousRevealDeadline. It is called after every
+//player's commitment has been revealed, and is where your game should act on
+//the revealed commitments.
+type SimultaneousResolver interface {
+	ResolveCommitments(state boardgame.MutableState) error
+}
+
+//AuctionProperties should be implemented by your GameState if you use any of
+//the Auction moves. Generally you simply embed AuctionBaseGameState to satisfy
+//this interface for free.
+type AuctionProperties interface {
+	//Whether an auction is currently underway.
+	AuctionActive() bool
+	//Which style of auction is underway, e.g. moves.AuctionEnglish.
+	AuctionStyle() int
+	//The game-specific identifier of the lot being auctioned.
+	AuctionLot() int
+	//In open auctions, the current high bid. In Dutch auctions, the current
+	//asking price. After the auction is resolved, the price the lot sold for.
+	AuctionPrice() int
+	//The player with the current high bid, or ObserverPlayerIndex if there
+	//isn't one. After the auction is resolved, the winner.
+	AuctionHighBidder() boardgame.PlayerIndex
+	//In auctions with turns, the player who may bid or pass next.
+	AuctionCurrentBidder() boardgame.PlayerIndex
+	//The player the auction started with. Also used to break ties.
+	AuctionStarterPlayer() boardgame.PlayerIndex
+	//The minimum amount a bid must raise the current high bid by, or in
+	//Dutch auctions how much the price drops each time every player passes.
+	AuctionIncrement() int
+	//The minimum opening bid, or the floor price of a Dutch auction.
+	AuctionReserve() int
+
+	SetAuctionActive(active bool)
+	SetAuctionStyle(style int)
+	SetAuctionLot(lot int)
+	SetAuctionPrice(price int)
+	SetAuctionHighBidder(player boardgame.PlayerIndex)
+	SetAuctionCurrentBidder(player boardgame.PlayerIndex)
+	SetAuctionStarterPlayer(player boardgame.PlayerIndex)
+	SetAuctionIncrement(increment int)
+	SetAuctionReserve(reserve int)
+}
+
+//AuctionPlayerProperties should be implemented by your PlayerState if you use
+//any of the Auction moves. Generally you simply embed AuctionBasePlayerState
+//to satisfy this interface for free.
+type AuctionPlayerProperties interface {
+	//The player's bid in the current auction.
+	AuctionBid() int
+	//Whether the player has bid in the current auction.
+	AuctionHasBid() bool
+	//Whether the player has passed (dropped out of) the current auction.
+	AuctionPassed() bool
+
+	SetAuctionBid(bid int)
+	SetAuctionHasBid(hasBid bool)
+	SetAuctionPassed(passed bool)
+}
+
+//AuctionBudgeter may be implemented by your PlayerState to limit how much a
+//player may bid. If it is not implemented bids are unlimited.
+type AuctionBudgeter interface {
+	AuctionBudget() int
+}
+
+//AuctionResolver should be implemented by moves that embed ResolveAuction.
+//It is called once the auction is over with the winner and the price they
+//must pay. If the lot went unsold, winner will be ObserverPlayerIndex.
+type AuctionResolver interface {
+	AuctionResolved(state boardgame.MutableState, winner boardgame.PlayerIndex, price int) error
+}
+
+//TradeProperties should be implemented by your PlayerState if you use any of
+//the Trade moves. Generally you simply embed TradeBasePlayerState to satisfy
+//this interface for free. Give and Take are always from the point of view of
+//the player who proposed the offer, and are indexed by whatever resource
+//types your game defines.
+type TradeProperties interface {
+	//Whether this player has an offer out to another player.
+	TradeOutgoingActive() bool
+	//The player this player's offer is to, or ObserverPlayerIndex if there
+	//is no outgoing offer.
+	TradeOutgoingTo() boardgame.PlayerIndex
+	//How many of each resource this player is offering to give.
+	TradeOutgoingGive() []int
+	//How many of each resource this player wants in return.
+	TradeOutgoingTake() []int
+	//Whether this player has an offer from another player to respond to.
+	TradeIncomingActive() bool
+	//The player who made the offer to this player, or ObserverPlayerIndex if
+	//there is no incoming offer.
+	TradeIncomingFrom() boardgame.PlayerIndex
+	//How many of each resource the other player is offering to give.
+	TradeIncomingGive() []int
+	//How many of each resource the other player wants in return.
+	TradeIncomingTake() []int
+
+	SetTradeOutgoingActive(active bool)
+	SetTradeOutgoingTo(player boardgame.PlayerIndex)
+	SetTradeOutgoingGive(resources []int)
+	SetTradeOutgoingTake(resources []int)
+	SetTradeIncomingActive(active bool)
+	SetTradeIncomingFrom(player boardgame.PlayerIndex)
+	SetTradeIncomingGive(resources []int)
+	SetTradeIncomingTake(resources []int)
+}
+
+//TradeHolder should be implemented by your PlayerState if you use any of the
+//Trade moves. It is how the trade moves find out what a player holds and how
+//to hand it over, whether your game tracks resources as ints or as
+//components in stacks.
+type TradeHolder interface {
+	//TradeHolds should return nil if the player holds at least the given
+	//amount of each resource, or a descriptive error if not.
+	TradeHolds(resources []int) error
+	//TradeTransfer should move the given amount of each resource from this
+	//player to the recipient.
+	TradeTransfer(resources []int, recipient boardgame.MutablePlayerState) error
+}
+
+//ReactionProperties should be implemented by your GameState if you use
+//reaction windows. Generally you simply embed ReactionBaseGameState to
+//satisfy this interface for free.
+type ReactionProperties interface {
+	//Whether a reaction window is open. While it is, moves that embed
+	//moves.Base are only legal if they are reaction moves.
+	ReactionActive() bool
+	//Whether any player reacted (instead of passing) in the most recent
+	//window. Left set after the window closes.
+	ReactionReacted() bool
+	//The player whose move opened the most recent window.
+	ReactionTriggerPlayer() boardgame.PlayerIndex
+	//The player who may react or pass right now.
+	ReactionResponder() boardgame.PlayerIndex
+	//How long each responder has before they automatically pass, or 0 for
+	//no limit.
+	ReactionTimeout() time.Duration
+	//The timer that automatically passes for the responder when
+	//ReactionTimeout is non-zero.
+	ReactionTimer() boardgame.MutableTimer
+
+	SetReactionActive(active bool)
+	SetReactionReacted(reacted bool)
+	SetReactionTriggerPlayer(player boardgame.PlayerIndex)
+	SetReactionResponder(player boardgame.PlayerIndex)
+	SetReactionTimeout(timeout time.Duration)
+}
+
+//ReactionPlayerProperties should be implemented by your PlayerState if you
+//use reaction windows. Generally you simply embed ReactionBasePlayerState to
+//satisfy this interface for free.
+type ReactionPlayerProperties interface {
+	//The player's place in the current window's priority order, starting
+	//at 1, or 0 if they aren't waiting for a chance to respond.
+	ReactionPriority() int
+	SetReactionPriority(priority int)
+}
+
+//Reacter should be implemented by moves that embed moves.Reaction. It is
+//called after the window has been closed (or passed on to the next
+//responder), and is where your game applies the reaction. It may open a new
+//window, for example to let players counter the counter.
+type Reacter interface {
+	React(state boardgame.MutableState) error
+}
+
+//RoundRobinPasser should be implemented by your PlayerState if you use
+//RoundRobinPassOut. Players who have passed are skipped until every player
+//has passed.
+type RoundRobinPasser interface {
+	RoundRobinPassed() bool
+}
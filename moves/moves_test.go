@@ -2,6 +2,7 @@ package moves
 
 import (
 	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/errors"
 	"github.com/workfit/tester/assert"
 	"testing"
 )
@@ -53,6 +54,24 @@ func (m *moveCurrentPlayerDraw) Apply(state boardgame.MutableState) error {
 	return game.DrawStack.MoveComponent(boardgame.FirstComponentIndex, p.Hand, boardgame.FirstSlotIndex)
 }
 
+//+autoreader
+type moveConstrainedFixUp struct {
+	Base
+	Amount int `constraint:"min=1,max=3"`
+}
+
+func (m *moveConstrainedFixUp) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Constrained Fix Up"
+}
+
+func (m *moveConstrainedFixUp) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return true
+}
+
+func (m *moveConstrainedFixUp) Apply(state boardgame.MutableState) error {
+	return nil
+}
+
 //+autoreader
 type moveStartPhaseDrawAgain struct {
 	StartPhase
@@ -188,3 +207,59 @@ func historicalMovesCount(t *testing.T, moveNames []string, counts []int, record
 		counterInMoveType++
 	}
 }
+
+func TestCurrentPlayerEliminated(t *testing.T) {
+	manager, err := newGameManagerWithDelegate(&gameDelegate{
+		moveInstaller: defaultMoveInstaller,
+		eliminated:    []boardgame.PlayerIndex{0},
+	})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	//The test delegate's CurrentPlayerIndex doesn't skip eliminated players,
+	//so it's still player 0's turn, but they can't move.
+	move := game.PlayerMoveByName("Draw Card").(*moveCurrentPlayerDraw)
+
+	assert.For(t).ThatActual(move.TargetPlayerIndex).Equals(boardgame.PlayerIndex(0))
+
+	err = <-game.ProposeMove(move, 0)
+
+	assert.For(t).ThatActual(err).IsNotNil()
+	assert.For(t).ThatActual(errors.CodeOf(err)).Equals(CodePlayerEliminated)
+
+}
+
+func TestBaseLegalChecksFieldConstraints(t *testing.T) {
+
+	manager, err := newGameManager(func(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle {
+		return boardgame.NewMoveTypeConfigBundle().AddMove(
+			MustDefaultConfig(manager, new(moveConstrainedFixUp)),
+		)
+	})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	move := game.FixUpMoveByName("Constrained Fix Up").(*moveConstrainedFixUp)
+
+	//The fix up move's Legal is what ProposeFixUpMove consults, so it has
+	//to reject fields that break the constraints.
+	move.Amount = 5
+
+	err = move.Legal(game.CurrentState(), boardgame.AdminPlayerIndex)
+
+	assert.For(t).ThatActual(err).IsNotNil()
+	assert.For(t).ThatActual(errors.CodeOf(err)).Equals(boardgame.CodeInvalidMoveField)
+
+	move.Amount = 2
+
+	assert.For(t).ThatActual(move.Legal(game.CurrentState(), boardgame.AdminPlayerIndex)).IsNil()
+
+}
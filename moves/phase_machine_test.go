@@ -0,0 +1,99 @@
+package moves
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/workfit/tester/assert"
+	"strings"
+	"testing"
+)
+
+func TestPhaseMachineValid(t *testing.T) {
+
+	tests := []struct {
+		description string
+		machine     *boardgame.PhaseMachine
+		valid       bool
+	}{
+		{
+			"Full machine",
+			boardgame.NewPhaseMachine(phaseSetUp).AddTransition(phaseSetUp, phaseNormalPlay).AddTransition(phaseNormalPlay, phaseDrawAgain),
+			true,
+		},
+		{
+			"Unreachable phase",
+			boardgame.NewPhaseMachine(phaseSetUp).AddTransition(phaseSetUp, phaseNormalPlay).AddPhase(phaseDrawAgain),
+			false,
+		},
+		{
+			"Undeclared phase with moves",
+			boardgame.NewPhaseMachine(phaseSetUp).AddTransition(phaseSetUp, phaseNormalPlay),
+			false,
+		},
+		{
+			"Phase not in enum",
+			boardgame.NewPhaseMachine(phaseSetUp).AddTransition(phaseSetUp, phaseNormalPlay, 7).AddTransition(phaseNormalPlay, phaseDrawAgain),
+			false,
+		},
+	}
+
+	for i, test := range tests {
+		_, err := newGameManagerWithPhaseMachine(defaultMoveInstaller, test.machine)
+		if test.valid {
+			assert.For(t, i, test.description).ThatActual(err).IsNil()
+		} else {
+			assert.For(t, i, test.description).ThatActual(err).IsNotNil()
+		}
+	}
+
+}
+
+func TestPhaseMachineTransitions(t *testing.T) {
+
+	//Draw Again is reachable, but not from Normal Play.
+	machine := boardgame.NewPhaseMachine(phaseSetUp).AddTransition(phaseSetUp, phaseNormalPlay, phaseDrawAgain)
+
+	manager, err := newGameManagerWithPhaseMachine(defaultMoveInstaller, machine)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	assert.For(t).ThatActual(manager.Delegate().CurrentPhase(game.CurrentState())).Equals(phaseNormalPlay)
+
+	move := game.PlayerMoveByName("Start Phase Draw Again")
+
+	//The illegal transition isn't offered as a legal move.
+	assert.For(t).ThatActual(move.Legal(game.CurrentState(), boardgame.AdminPlayerIndex)).IsNotNil()
+
+	assert.For(t).ThatActual(<-game.ProposeMove(move, 0)).IsNotNil()
+
+	assert.For(t).ThatActual(manager.Delegate().CurrentPhase(game.CurrentState())).Equals(phaseNormalPlay)
+
+}
+
+func TestPhaseMachineDOT(t *testing.T) {
+
+	machine := boardgame.NewPhaseMachine(phaseSetUp).AddTransition(phaseSetUp, phaseNormalPlay).AddTransition(phaseNormalPlay, phaseDrawAgain)
+
+	manager, err := newGameManagerWithPhaseMachine(defaultMoveInstaller, machine)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	dot := machine.DOT(manager)
+
+	assert.For(t).ThatActual(strings.HasPrefix(dot, "digraph \"tester\" {\n")).IsTrue()
+
+	for i, line := range []string{
+		"\t\"__start\" -> \"Set Up\";",
+		"\t\"Set Up\" -> \"Normal Play\";",
+		"\t\"Normal Play\" -> \"Draw Again\";",
+		"\t\"Normal Play\" [label=\"Normal Play\\n\\nDraw Card\\l\\nStart Phase Draw Again\\l\"];",
+		"\\n1. Deal Components From Game Stack DrawStack To Player Stack Hand To Each Player 2 Times\\l",
+		"\\n3. Start Phase Normal Play\\l",
+	} {
+		assert.For(t, i).ThatActual(strings.Contains(dot, line)).IsTrue()
+	}
+
+}
@@ -0,0 +1,65 @@
+package moves
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/workfit/tester/assert"
+	"strings"
+	"testing"
+)
+
+func progressionDrawConfig(name string) *boardgame.MoveTypeConfig {
+	return &boardgame.MoveTypeConfig{
+		Name: name,
+		MoveConstructor: func() boardgame.Move {
+			return new(moveCurrentPlayerDraw)
+		},
+	}
+}
+
+func TestStructuredMoveProgression(t *testing.T) {
+
+	manager, err := newGameManager(func(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle {
+		return boardgame.NewMoveTypeConfigBundle().AddOrderedMovesForPhase(phaseSetUp,
+			progressionDrawConfig("Draw A"),
+			boardgame.ProgressionRepeat(boardgame.ProgressionAlternate(
+				progressionDrawConfig("Draw B"),
+				progressionDrawConfig("Draw C"),
+			), 1, 2),
+			boardgame.ProgressionOptional(progressionDrawConfig("Draw D")),
+		)
+	})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	steps := []struct {
+		move          string
+		legal         bool
+		errorContains string
+	}{
+		{"Draw B", false, "Expected Draw A."},
+		{"Draw A", true, ""},
+		{"Draw A", false, "Expected one of Draw B, Draw C."},
+		{"Draw C", true, ""},
+		{"Draw B", true, ""},
+		{"Draw C", false, "Expected Draw D."},
+		{"Draw D", true, ""},
+		{"Draw A", false, "No more moves are expected in this phase."},
+	}
+
+	for i, step := range steps {
+		move := game.PlayerMoveByName(step.move)
+		assert.For(t, i).ThatActual(move).IsNotNil()
+		err := <-game.ProposeMove(move, 0)
+		if step.legal {
+			assert.For(t, i, step.move).ThatActual(err).IsNil()
+			continue
+		}
+		assert.For(t, i, step.move).ThatActual(err).IsNotNil()
+		assert.For(t, i, step.move, err).ThatActual(strings.Contains(err.Error(), step.errorContains)).IsTrue()
+	}
+
+}
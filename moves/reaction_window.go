@@ -0,0 +1,463 @@
+package moves
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/errors"
+	"github.com/jkomoros/boardgame/i18n"
+	"github.com/jkomoros/boardgame/moves/moveinterfaces"
+	"time"
+)
+
+//reactionWindowMove is implemented by the moves that may be made while a
+//reaction window is open. We can keep it private because embedders already
+//have it satisfied.
+type reactionWindowMove interface {
+	allowedInReactionWindow()
+}
+
+type reactionWindowCloser interface {
+	ReactionClosesWindow() bool
+}
+
+type reactionPasser interface {
+	reactionPass()
+}
+
+/*
+
+OpenReactionWindow gives players a chance to respond to something that just
+happened, out of turn, before the game continues. It is designed to be called
+from the Apply of the move that triggers the window, for example an attack
+that may be blocked or a spell that may be countered.
+
+Your gameState must implement moveinterfaces.ReactionProperties and your
+playerStates moveinterfaces.ReactionPlayerProperties; generally you simply
+embed moveinterfaces.ReactionBaseGameState and
+moveinterfaces.ReactionBasePlayerState.
+
+Eligible is the players who may respond, in priority order. If it is nil,
+every active player except trigger may respond, in turn order starting after
+trigger. Each eligible player in turn becomes the ReactionResponder and may
+either react, with a move embedding Reaction, or pass, with a move embedding
+ReactionPass. The window closes once every eligible player has passed, or
+when a player reacts (unless the reaction move's ReactionClosesWindow returns
+false).
+
+While the window is open every move that embeds Base (which includes all of
+the moves in this package, like CurrentPlayer and FinishTurn) is illegal
+except the reaction moves, so the main flow of the game resumes exactly where
+it left off once the window closes. If what the triggering move does should
+only happen if nobody reacted, do it in a FixUp move that checks
+ReactionReacted once ReactionActive is false.
+
+If timeout is non-zero, each responder has that long before they
+automatically pass. This requires a move embedding ReactionPass to be
+installed, which the timer will propose on their behalf.
+
+If no players are eligible, the window isn't opened.
+
+*/
+func OpenReactionWindow(state boardgame.MutableState, trigger boardgame.PlayerIndex, eligible []boardgame.PlayerIndex, timeout time.Duration) error {
+
+	game, ok := state.MutableGameState().(moveinterfaces.ReactionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement ReactionProperties")
+	}
+
+	if eligible == nil {
+		eligible = defaultReactionPriority(state, trigger)
+	}
+
+	playerStates := state.MutablePlayerStates()
+
+	for _, player := range playerStates {
+		reactor, ok := player.(moveinterfaces.ReactionPlayerProperties)
+		if !ok {
+			return errors.New("PlayerState does not implement ReactionPlayerProperties")
+		}
+		reactor.SetReactionPriority(0)
+	}
+
+	priority := 0
+
+	for _, player := range eligible {
+		if !player.Valid(state) || player < 0 {
+			return errors.New("Eligible player " + player.String() + " is not valid")
+		}
+		reactor := playerStates[player].(moveinterfaces.ReactionPlayerProperties)
+		if reactor.ReactionPriority() != 0 {
+			//Listed twice; the first place counts.
+			continue
+		}
+		priority++
+		reactor.SetReactionPriority(priority)
+	}
+
+	game.SetReactionReacted(false)
+	game.SetReactionTriggerPlayer(trigger)
+	game.SetReactionTimeout(timeout)
+
+	if priority == 0 {
+		game.SetReactionActive(false)
+		game.SetReactionResponder(boardgame.ObserverPlayerIndex)
+		return nil
+	}
+
+	game.SetReactionActive(true)
+
+	return advanceReactionWindow(state, game)
+
+}
+
+//CloseReactionWindow closes the open reaction window, if there is one,
+//without giving any remaining eligible players a chance to respond.
+func CloseReactionWindow(state boardgame.MutableState) error {
+
+	game, ok := state.MutableGameState().(moveinterfaces.ReactionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement ReactionProperties")
+	}
+
+	for _, player := range state.MutablePlayerStates() {
+		reactor, ok := player.(moveinterfaces.ReactionPlayerProperties)
+		if !ok {
+			return errors.New("PlayerState does not implement ReactionPlayerProperties")
+		}
+		reactor.SetReactionPriority(0)
+	}
+
+	closeReactionWindow(game)
+
+	return nil
+
+}
+
+/*
+
+Reaction is an embeddable move for a player to respond to an open reaction
+window (see OpenReactionWindow) out of turn. It is legal only for the current
+ReactionResponder. Your embedding move must implement moveinterfaces.Reacter,
+whose React is called to apply the reaction after the window has been closed,
+so React may open another window, for example to allow a counter to the
+counter.
+
+By default reacting closes the window, so that the first player to respond
+gets to, like a counterspell. Override ReactionClosesWindow to return false
+to instead pass the window on to the next eligible player, so that every
+eligible player may respond.
+
+Typically you'd override Legal, calling Reaction.Legal first, to check that
+the player can react (for example, that they hold a counter card).
+
+*/
+type Reaction struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+}
+
+func (r *Reaction) allowedInReactionWindow() {}
+
+func (r *Reaction) ValidConfiguration(exampleState boardgame.MutableState) error {
+	if err := validReactionConfiguration(exampleState); err != nil {
+		return err
+	}
+	if _, ok := r.TopLevelStruct().(moveinterfaces.Reacter); !ok {
+		return errors.New("Embedding move doesn't implement Reacter")
+	}
+	return nil
+}
+
+//Legal checks that a reaction window is open and that TargetPlayerIndex is
+//the proposer and the current ReactionResponder.
+func (r *Reaction) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := r.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	return legalReactionResponder(state, r.TargetPlayerIndex, proposer)
+
+}
+
+//Apply closes the window (or passes it to the next eligible player if
+//ReactionClosesWindow returns false), and then calls the embedding move's
+//React.
+func (r *Reaction) Apply(state boardgame.MutableState) error {
+
+	game, ok := state.MutableGameState().(moveinterfaces.ReactionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement ReactionProperties")
+	}
+
+	closes := true
+
+	if closer, ok := r.TopLevelStruct().(reactionWindowCloser); ok {
+		closes = closer.ReactionClosesWindow()
+	}
+
+	if closes {
+		if err := CloseReactionWindow(state); err != nil {
+			return err
+		}
+	} else {
+		state.MutablePlayerStates()[r.TargetPlayerIndex].(moveinterfaces.ReactionPlayerProperties).SetReactionPriority(0)
+		if err := advanceReactionWindow(state, game); err != nil {
+			return err
+		}
+	}
+
+	game.SetReactionReacted(true)
+
+	reacter, ok := r.TopLevelStruct().(moveinterfaces.Reacter)
+
+	if !ok {
+		return errors.New("Embedding move doesn't implement Reacter")
+	}
+
+	return reacter.React(state)
+}
+
+//ReactionClosesWindow returns whether reacting closes the window for every
+//other eligible player. Defaults to true.
+func (r *Reaction) ReactionClosesWindow() bool {
+	return true
+}
+
+//DefaultsForState sets TargetPlayerIndex to the current ReactionResponder.
+func (r *Reaction) DefaultsForState(state boardgame.State) {
+	if game, ok := state.GameState().(moveinterfaces.ReactionProperties); ok {
+		r.TargetPlayerIndex = game.ReactionResponder()
+	}
+}
+
+func (r *Reaction) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Reaction"
+}
+
+func (r *Reaction) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Responds to what just happened, out of turn."
+}
+
+func (r *Reaction) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+/*
+
+ReactionPass is the move a player makes to decline to respond to an open
+reaction window, which then passes to the next eligible player or closes if
+there isn't one. If the window has a timeout, this is also the move the
+timer proposes on the responder's behalf, so install it whenever you use
+timeouts. It can generally be installed as is, with DefaultConfig.
+
+*/
+type ReactionPass struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+}
+
+func (r *ReactionPass) allowedInReactionWindow() {}
+
+func (r *ReactionPass) reactionPass() {}
+
+func (r *ReactionPass) ValidConfiguration(exampleState boardgame.MutableState) error {
+	return validReactionConfiguration(exampleState)
+}
+
+//Legal checks that a reaction window is open and that TargetPlayerIndex is
+//the proposer and the current ReactionResponder.
+func (r *ReactionPass) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := r.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	return legalReactionResponder(state, r.TargetPlayerIndex, proposer)
+
+}
+
+//Apply passes the window on to the next eligible player, or closes it.
+func (r *ReactionPass) Apply(state boardgame.MutableState) error {
+
+	game, ok := state.MutableGameState().(moveinterfaces.ReactionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement ReactionProperties")
+	}
+
+	state.MutablePlayerStates()[r.TargetPlayerIndex].(moveinterfaces.ReactionPlayerProperties).SetReactionPriority(0)
+
+	return advanceReactionWindow(state, game)
+}
+
+//DefaultsForState sets TargetPlayerIndex to the current ReactionResponder.
+func (r *ReactionPass) DefaultsForState(state boardgame.State) {
+	if game, ok := state.GameState().(moveinterfaces.ReactionProperties); ok {
+		r.TargetPlayerIndex = game.ReactionResponder()
+	}
+}
+
+func (r *ReactionPass) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Reaction Pass"
+}
+
+func (r *ReactionPass) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Declines to respond to what just happened."
+}
+
+func (r *ReactionPass) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+func validReactionConfiguration(exampleState boardgame.MutableState) error {
+
+	if _, ok := exampleState.GameState().(moveinterfaces.ReactionProperties); !ok {
+		return errors.New("GameState does not implement ReactionProperties")
+	}
+
+	if _, ok := exampleState.PlayerStates()[0].(moveinterfaces.ReactionPlayerProperties); !ok {
+		return errors.New("PlayerState does not implement ReactionPlayerProperties")
+	}
+
+	return nil
+}
+
+//legalReactionResponder checks that a window is open and that target is the
+//proposer and the current responder.
+func legalReactionResponder(state boardgame.State, target boardgame.PlayerIndex, proposer boardgame.PlayerIndex) error {
+
+	game, ok := state.GameState().(moveinterfaces.ReactionProperties)
+
+	if !ok {
+		return errors.New("GameState does not implement ReactionProperties")
+	}
+
+	if !game.ReactionActive() {
+		return errors.New("There is nothing to respond to right now")
+	}
+
+	if !target.Valid(state) || target < 0 {
+		return i18n.NewError(MessageInvalidTarget, "The specified target player is not valid", nil).WithCode(CodeInvalidTarget, errors.IllegalMove)
+	}
+
+	if target != game.ReactionResponder() || !target.Equivalent(proposer) {
+		return i18n.NewError(MessageNotYourTurn, "It's not your turn!", nil).WithCode(CodeNotYourTurn, errors.NotYourTurn)
+	}
+
+	return nil
+}
+
+//legalInReactionWindow returns an error if a reaction window is open and
+//move isn't one of the moves that may be made in it.
+func legalInReactionWindow(state boardgame.State, move boardgame.Move) error {
+
+	game, ok := state.GameState().(moveinterfaces.ReactionProperties)
+
+	if !ok || !game.ReactionActive() {
+		return nil
+	}
+
+	if _, ok := move.(reactionWindowMove); ok {
+		return nil
+	}
+
+	return i18n.NewError(MessageReactionPending, "Players are still responding to the last move.", nil).WithCode(CodeReactionPending, errors.IllegalMove)
+}
+
+//defaultReactionPriority returns every active player other than trigger, in
+//turn order starting after trigger.
+func defaultReactionPriority(state boardgame.State, trigger boardgame.PlayerIndex) []boardgame.PlayerIndex {
+
+	numPlayers := len(state.PlayerStates())
+
+	start := 0
+
+	if trigger.Valid(state) && trigger >= 0 {
+		start = int(trigger) + 1
+	}
+
+	var result []boardgame.PlayerIndex
+
+	for i := 0; i < numPlayers; i++ {
+		player := boardgame.PlayerIndex((start + i) % numPlayers)
+		if player == trigger || player.Eliminated(state) {
+			continue
+		}
+		result = append(result, player)
+	}
+
+	return result
+}
+
+//advanceReactionWindow makes the eligible player with the best priority the
+//responder, restarting the timeout for them, or closes the window if there
+//isn't one.
+func advanceReactionWindow(state boardgame.MutableState, game moveinterfaces.ReactionProperties) error {
+
+	next := boardgame.ObserverPlayerIndex
+	best := 0
+
+	for i, player := range state.PlayerStates() {
+		priority := player.(moveinterfaces.ReactionPlayerProperties).ReactionPriority()
+		if priority == 0 {
+			continue
+		}
+		if best == 0 || priority < best {
+			best = priority
+			next = boardgame.PlayerIndex(i)
+		}
+	}
+
+	if best == 0 {
+		closeReactionWindow(game)
+		return nil
+	}
+
+	game.SetReactionResponder(next)
+
+	timeout := game.ReactionTimeout()
+
+	if timeout <= 0 {
+		return nil
+	}
+
+	timer := game.ReactionTimer()
+
+	if timer == nil {
+		return errors.New("The reaction window has a timeout but the GameState has no ReactionTimer")
+	}
+
+	move := reactionPassMove(state)
+
+	if move == nil {
+		return errors.New("The reaction window has a timeout but there is no move embedding ReactionPass installed to start the timer with")
+	}
+
+	timer.Start(timeout, move)
+
+	return nil
+}
+
+func closeReactionWindow(game moveinterfaces.ReactionProperties) {
+	game.SetReactionActive(false)
+	game.SetReactionResponder(boardgame.ObserverPlayerIndex)
+	if timer := game.ReactionTimer(); timer != nil && timer.Active() {
+		timer.Cancel()
+	}
+}
+
+//reactionPassMove returns a new move embedding ReactionPass for the current
+//responder, for the timeout timer to propose.
+func reactionPassMove(state boardgame.State) boardgame.Move {
+	for _, moveType := range state.Game().Manager().PlayerMoveTypes() {
+		move := moveType.NewMove(state)
+		if _, ok := move.(reactionPasser); ok {
+			return move
+		}
+	}
+	return nil
+}
+
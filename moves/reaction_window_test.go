@@ -0,0 +1,175 @@
+package moves
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/errors"
+	"github.com/workfit/tester/assert"
+	"testing"
+	"time"
+)
+
+//+autoreader
+type moveAttack struct {
+	CurrentPlayer
+}
+
+func (m *moveAttack) Apply(state boardgame.MutableState) error {
+	return OpenReactionWindow(state, m.TargetPlayerIndex, nil, 0)
+}
+
+//+autoreader
+type moveTimedAttack struct {
+	CurrentPlayer
+}
+
+func (m *moveTimedAttack) Apply(state boardgame.MutableState) error {
+	return OpenReactionWindow(state, m.TargetPlayerIndex, []boardgame.PlayerIndex{2, 1}, time.Hour)
+}
+
+//+autoreader
+type moveBlock struct {
+	Reaction
+}
+
+func (m *moveBlock) React(state boardgame.MutableState) error {
+	game, _ := concreteStates(state)
+	game.Counter++
+	return nil
+}
+
+//+autoreader
+type moveReactionPass struct {
+	ReactionPass
+}
+
+func reactionMoveInstaller(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle {
+	return boardgame.NewMoveTypeConfigBundle().AddMoves(
+		&boardgame.MoveTypeConfig{
+			Name: "Attack",
+			MoveConstructor: func() boardgame.Move {
+				return new(moveAttack)
+			},
+		},
+		&boardgame.MoveTypeConfig{
+			Name: "Timed Attack",
+			MoveConstructor: func() boardgame.Move {
+				return new(moveTimedAttack)
+			},
+		},
+		MustDefaultConfig(manager, new(moveBlock)),
+		MustDefaultConfig(manager, new(moveReactionPass)),
+	)
+}
+
+func TestReactionWindow(t *testing.T) {
+	manager, err := newGameManager(reactionMoveInstaller)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	respond := func(name string, player boardgame.PlayerIndex) error {
+		move := game.PlayerMoveByName(name)
+		assert.For(t, name).ThatActual(move.ReadSetter().SetPlayerIndexProp("TargetPlayerIndex", player)).IsNil()
+		return <-game.ProposeMove(move, player)
+	}
+
+	//Nothing to respond to yet.
+	assert.For(t).ThatActual(respond("Reaction Pass", 1)).IsNotNil()
+
+	assert.For(t).ThatActual(<-game.ProposeMove(game.PlayerMoveByName("Attack"), 0)).IsNil()
+
+	gameState, players := concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(gameState.RWActive).IsTrue()
+	assert.For(t).ThatActual(gameState.RWTriggerPlayer).Equals(boardgame.PlayerIndex(0))
+	assert.For(t).ThatActual(gameState.RWResponder).Equals(boardgame.PlayerIndex(1))
+	assert.For(t).ThatActual(players[0].RWPriority).Equals(0)
+	assert.For(t).ThatActual(players[3].RWPriority).Equals(3)
+
+	_, sanitizedPlayers := concreteStates(game.CurrentState().SanitizedForPlayer(1))
+
+	assert.For(t).ThatActual(sanitizedPlayers[3].RWPriority).Equals(0)
+
+	//The main flow of the game waits for the window to close.
+	attack := game.PlayerMoveByName("Attack")
+
+	err = attack.Legal(game.CurrentState(), 0)
+
+	assert.For(t).ThatActual(errors.CodeOf(err)).Equals(CodeReactionPending)
+
+	//Only the responder may respond.
+	block := game.PlayerMoveByName("Reaction")
+	block.(*moveBlock).TargetPlayerIndex = 2
+
+	err = block.Legal(game.CurrentState(), 2)
+
+	assert.For(t).ThatActual(errors.CodeOf(err)).Equals(CodeNotYourTurn)
+
+	assert.For(t).ThatActual(respond("Reaction Pass", 1)).IsNil()
+
+	gameState, players = concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(gameState.RWResponder).Equals(boardgame.PlayerIndex(2))
+	assert.For(t).ThatActual(players[1].RWPriority).Equals(0)
+
+	assert.For(t).ThatActual(respond("Reaction", 2)).IsNil()
+
+	gameState, players = concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(gameState.RWActive).IsFalse()
+	assert.For(t).ThatActual(gameState.RWReacted).IsTrue()
+	assert.For(t).ThatActual(gameState.RWResponder).Equals(boardgame.ObserverPlayerIndex)
+	assert.For(t).ThatActual(gameState.Counter).Equals(1)
+	assert.For(t).ThatActual(players[3].RWPriority).Equals(0)
+
+	//Player 3 never got a chance to respond.
+	assert.For(t).ThatActual(respond("Reaction", 3)).IsNotNil()
+
+	//The game picks up where it left off, and a window where everyone passes
+	//closes without a reaction.
+	assert.For(t).ThatActual(<-game.ProposeMove(game.PlayerMoveByName("Attack"), 0)).IsNil()
+
+	for _, player := range []boardgame.PlayerIndex{1, 2, 3} {
+		assert.For(t, player).ThatActual(respond("Reaction Pass", player)).IsNil()
+	}
+
+	gameState, _ = concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(gameState.RWActive).IsFalse()
+	assert.For(t).ThatActual(gameState.RWReacted).IsFalse()
+	assert.For(t).ThatActual(gameState.Counter).Equals(1)
+
+}
+
+func TestReactionWindowTimeout(t *testing.T) {
+	manager, err := newGameManager(reactionMoveInstaller)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	assert.For(t).ThatActual(<-game.ProposeMove(game.PlayerMoveByName("Timed Attack"), 0)).IsNil()
+
+	gameState, _ := concreteStates(game.CurrentState())
+
+	//Eligible players respond in the order given.
+	assert.For(t).ThatActual(gameState.RWResponder).Equals(boardgame.PlayerIndex(2))
+	assert.For(t).ThatActual(gameState.RWTimeout).Equals(int(time.Hour / time.Millisecond))
+	assert.For(t).ThatActual(gameState.RWTimer.Active()).IsTrue()
+
+	block := game.PlayerMoveByName("Reaction")
+	block.(*moveBlock).TargetPlayerIndex = 2
+
+	assert.For(t).ThatActual(<-game.ProposeMove(block, 2)).IsNil()
+
+	gameState, _ = concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(gameState.RWActive).IsFalse()
+	assert.For(t).ThatActual(gameState.RWTimer.Active()).IsFalse()
+
+}
@@ -0,0 +1,132 @@
+package moves
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/errors"
+	"github.com/jkomoros/boardgame/i18n"
+)
+
+//eliminationSetter is implemented by player states that embed
+//boardgame.EliminatablePlayerState.
+type eliminationSetter interface {
+	boardgame.PlayerEliminator
+	SetPlayerEliminated(eliminated bool)
+}
+
+/*
+
+Resign is a move that lets a player resign from the game at any point, even
+when it isn't their turn. The player denoted by TargetPlayerIndex, who must
+be equivalent to the proposer, is eliminated; if that finishes the game the
+game's Result has the reason boardgame.EndReasonResignation.
+
+Your playerStates must implement boardgame.PlayerEliminator and have a
+SetPlayerEliminated method, generally by embedding
+boardgame.EliminatablePlayerState. Whether the game is finished is still up
+to your delegate's CheckGameFinished; DefaultGameDelegate's finishes the game
+once only one player hasn't been eliminated.
+
+*/
+type Resign struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+}
+
+func (r *Resign) ValidConfiguration(exampleState boardgame.MutableState) error {
+	if _, ok := exampleState.PlayerStates()[0].(eliminationSetter); !ok {
+		return errors.New("PlayerState does not implement PlayerEliminator and SetPlayerEliminated")
+	}
+	return nil
+}
+
+//Legal checks that TargetPlayerIndex is a player equivalent to the proposer
+//who hasn't already been eliminated.
+func (r *Resign) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := r.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	if !r.TargetPlayerIndex.Valid(state) || r.TargetPlayerIndex < 0 {
+		return i18n.NewError(MessageInvalidTarget, "The specified target player is not valid", nil).WithCode(CodeInvalidTarget, errors.IllegalMove)
+	}
+
+	if r.TargetPlayerIndex.Eliminated(state) {
+		return i18n.NewError(MessagePlayerEliminated, "The specified target player has been eliminated", nil).WithCode(CodePlayerEliminated, errors.IllegalMove)
+	}
+
+	if !r.TargetPlayerIndex.Equivalent(proposer) {
+		return i18n.NewError(MessageNotYourTurn, "You can't resign for another player", nil).WithCode(CodeNotYourTurn, errors.NotYourTurn)
+	}
+
+	return nil
+
+}
+
+//Apply eliminates TargetPlayerIndex.
+func (r *Resign) Apply(state boardgame.MutableState) error {
+
+	player, ok := state.MutablePlayerStates()[r.TargetPlayerIndex].(eliminationSetter)
+
+	if !ok {
+		return errors.New("PlayerState does not implement SetPlayerEliminated")
+	}
+
+	player.SetPlayerEliminated(true)
+
+	return nil
+}
+
+//EndReason returns boardgame.EndReasonResignation.
+func (r *Resign) EndReason() boardgame.EndReason {
+	return boardgame.EndReasonResignation
+}
+
+func (r *Resign) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Resign"
+}
+
+func (r *Resign) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Resigns from the game."
+}
+
+func (r *Resign) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+/*
+
+TimeOut is like Resign, but for a player who has run out of time: only the
+admin may make it, typically by starting a Timer with a TimeOut move whose
+TargetPlayerIndex is the player whose clock is running. If it finishes the
+game the game's Result has the reason boardgame.EndReasonTimeout.
+
+*/
+type TimeOut struct {
+	Resign
+}
+
+//Legal checks that the proposer is the admin, and then everything that
+//Resign.Legal does.
+func (t *TimeOut) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if proposer != boardgame.AdminPlayerIndex {
+		return i18n.NewError(MessageNotYourTurn, "Only the admin may time out a player", nil).WithCode(CodeNotYourTurn, errors.NotYourTurn)
+	}
+
+	return t.Resign.Legal(state, proposer)
+
+}
+
+//EndReason returns boardgame.EndReasonTimeout.
+func (t *TimeOut) EndReason() boardgame.EndReason {
+	return boardgame.EndReasonTimeout
+}
+
+func (t *TimeOut) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Time Out"
+}
+
+func (t *TimeOut) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Eliminates a player who has run out of time."
+}
@@ -0,0 +1,95 @@
+package moves
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+//+autoreader
+type moveResign struct {
+	Resign
+}
+
+//+autoreader
+type moveTimeOut struct {
+	TimeOut
+}
+
+func resignMoveInstaller(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle {
+	return boardgame.NewMoveTypeConfigBundle().AddMoves(
+		MustDefaultConfig(manager, new(moveResign)),
+		MustDefaultConfig(manager, new(moveTimeOut)),
+	)
+}
+
+func TestResign(t *testing.T) {
+
+	manager, err := newGameManagerWithDelegate(&gameDelegate{
+		moveInstaller: resignMoveInstaller,
+		eliminated:    []boardgame.PlayerIndex{3},
+	})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	resign := func(target, proposer boardgame.PlayerIndex) error {
+		move := game.PlayerMoveByName("Resign")
+		assert.For(t).ThatActual(move.ReadSetter().SetPlayerIndexProp("TargetPlayerIndex", target)).IsNil()
+		return <-game.ProposeMove(move, proposer)
+	}
+
+	//Players can't resign for someone else.
+	assert.For(t).ThatActual(resign(1, 2)).IsNotNil()
+
+	//Player 3 is already out.
+	assert.For(t).ThatActual(resign(3, 3)).IsNotNil()
+
+	//It doesn't have to be your turn to resign.
+	assert.For(t).ThatActual(resign(1, 1)).IsNil()
+
+	_, players := concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(players[1].Eliminated).IsTrue()
+	assert.For(t).ThatActual(game.Finished()).IsFalse()
+
+	assert.For(t).ThatActual(resign(2, 2)).IsNil()
+
+	assert.For(t).ThatActual(game.Finished()).IsTrue()
+	assert.For(t).ThatActual(game.Winners()).Equals([]boardgame.PlayerIndex{0})
+	assert.For(t).ThatActual(game.Result().Reason).Equals(boardgame.EndReasonResignation)
+
+}
+
+func TestTimeOut(t *testing.T) {
+
+	manager, err := newGameManagerWithDelegate(&gameDelegate{
+		moveInstaller: resignMoveInstaller,
+		eliminated:    []boardgame.PlayerIndex{2, 3},
+	})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	timeOut := func(target, proposer boardgame.PlayerIndex) error {
+		move := game.PlayerMoveByName("Time Out")
+		assert.For(t).ThatActual(move.ReadSetter().SetPlayerIndexProp("TargetPlayerIndex", target)).IsNil()
+		return <-game.ProposeMove(move, proposer)
+	}
+
+	//Only the admin may time players out, not even the player themselves.
+	assert.For(t).ThatActual(timeOut(0, 0)).IsNotNil()
+
+	assert.For(t).ThatActual(timeOut(0, boardgame.AdminPlayerIndex)).IsNil()
+
+	assert.For(t).ThatActual(game.Finished()).IsTrue()
+	assert.For(t).ThatActual(game.Winners()).Equals([]boardgame.PlayerIndex{1})
+	assert.For(t).ThatActual(game.Result().Reason).Equals(boardgame.EndReasonTimeout)
+
+}
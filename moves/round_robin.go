@@ -4,14 +4,39 @@ import (
 	"errors"
 	"github.com/jkomoros/boardgame"
 	"github.com/jkomoros/boardgame/moves/moveinterfaces"
+	"sort"
 	"strconv"
 )
 
+//The orders that RoundRobin can visit players in, returned from
+//RoundRobinOrder.
+const (
+	//RoundRobinOrderForward goes around in PlayerIndex order: 0, 1, 2, 0, 1,
+	//2.
+	RoundRobinOrderForward = iota
+	//RoundRobinOrderReverse goes around in reverse PlayerIndex order: 0, 2,
+	//1, 0, 2, 1.
+	RoundRobinOrderReverse
+	//RoundRobinOrderSnake goes forward, then back the way it came, as in a
+	//draft: 0, 1, 2, 2, 1, 0, 0, 1, 2.
+	RoundRobinOrderSnake
+	//RoundRobinOrderPriority goes around in order of
+	//RoundRobinPlayerPriority, highest first, each round. Ties are broken
+	//by forward order from the starter player.
+	RoundRobinOrderPriority
+)
+
 //We can keep these private because embedders already will have the interface
 //satisfied so don't need to be confused by them.
 type roundRobinStarterPlayer interface {
 	RoundRobinStarterPlayer(state boardgame.State) boardgame.PlayerIndex
 }
+type roundRobinOrderer interface {
+	RoundRobinOrder() int
+}
+type roundRobinPlayerPrioritizer interface {
+	RoundRobinPlayerPriority(playerState boardgame.PlayerState) int
+}
 type playerConditionMet interface {
 	//PlayerConditionMet should return whether the condition for the round
 	//robin to be over has been met for this player.
@@ -62,8 +87,9 @@ some action. Other moves in this package embed RoundRobin, and it's more
 common to use those directly.
 
 Round Robin moves start at a given player and goes around. It will skip
-players for whom move.PlayerConditionMet() has already returned
-true. When it finds a player whose end condition is not met, it will apply
+players for whom move.PlayerConditionMet() has already returned true, as well
+as players who have been eliminated (see boardgame.PlayerEliminator). When it
+finds a player whose end condition is not met, it will apply
 RoundRobinAction() to them, and then advance to the next player. Every time it
 makes a circuit around the list of players, it will increment
 RoundRobinRoundCount. By default, once all players have had their player
@@ -75,6 +101,9 @@ RoundRobinProperties interface. Generally it's easiest to simply embed
 moveinterfaces.RoundRobinBaseGameState in your GameState anonymously to
 implement the interface automatically.
 
+By default players are visited in PlayerIndex order. Override RoundRobinOrder
+to visit them in reverse, snake (as in a draft), or priority order instead.
+
 The embeding move should implement moveinterfaces.RoundRobinActioner.
 
 */
@@ -82,15 +111,31 @@ type RoundRobin struct {
 	ApplyUntil
 }
 
+//RoundRobinOrder is the order players will be visited in, for example
+//RoundRobinOrderSnake. Defaults to RoundRobinOrderForward. Override this
+//method if you want a different order.
+func (r *RoundRobin) RoundRobinOrder() int {
+	return RoundRobinOrderForward
+}
+
+//RoundRobinPlayerPriority is consulted for each player at the start of each
+//round if RoundRobinOrder returns RoundRobinOrderPriority. Players with
+//higher priorities go first. Defaults to 0 for every player. Priorities are
+//read each time the next player is computed, so they shouldn't change in the
+//middle of a round.
+func (r *RoundRobin) RoundRobinPlayerPriority(playerState boardgame.PlayerState) int {
+	return 0
+}
+
 //RoundRobinStarterPlayer by default will return delegate.CurrentPlayer.
 //Override this method if you want a different starter.
 func (s *RoundRobin) RoundRobinStarterPlayer(state boardgame.State) boardgame.PlayerIndex {
 	return state.Game().Manager().Delegate().CurrentPlayerIndex(state)
 }
 
-//ConditionMet  goes around and returns nil if all players have had their
-//player condition met, meaning that there are no more legal players to
-//select. Because this condition is almost always an important base no matter
+//ConditionMet  goes around and returns nil if all players (other than those
+//who have been eliminated) have had their player condition met, meaning that
+//there are no more legal players to select. Because this condition is almost always an important base no matter
 //the other conditions you are considering (it's not possible to select
 //players who have already had their player condition met), if you override
 //CondtionMet you should also call this implementation.
@@ -103,6 +148,9 @@ func (r *RoundRobin) ConditionMet(state boardgame.State) error {
 	}
 
 	for i, player := range state.PlayerStates() {
+		if boardgame.PlayerIndex(i).Eliminated(state) {
+			continue
+		}
 		if !conditionsMet.PlayerConditionMet(player) {
 			return errors.New("Player " + strconv.Itoa(i) + " does not have their player condition met.")
 		}
@@ -155,6 +203,7 @@ func (r *RoundRobin) startRoundRobin(state boardgame.MutableState) error {
 	roundRobiner.SetRoundRobinLastPlayer(starterPlayer.Previous(state))
 	roundRobiner.SetRoundRobinStarterPlayer(starterPlayer)
 	roundRobiner.SetRoundRobinRoundCount(0)
+	roundRobiner.SetRoundRobinLastPosition(-1)
 	roundRobiner.SetRoundRobinHasStarted(true)
 
 	return nil
@@ -173,32 +222,93 @@ func (r *RoundRobin) finishRoundRobin(state boardgame.MutableState) error {
 	return nil
 }
 
+//roundOrder returns the order players will be visited in during the given
+//round, based on RoundRobinOrder.
+func (r *RoundRobin) roundOrder(state boardgame.State, starter boardgame.PlayerIndex, round int) []boardgame.PlayerIndex {
+
+	mode := RoundRobinOrderForward
+
+	if orderer, ok := r.TopLevelStruct().(roundRobinOrderer); ok {
+		mode = orderer.RoundRobinOrder()
+	}
+
+	order := playerOrder(state, starter, mode == RoundRobinOrderReverse)
+
+	switch mode {
+	case RoundRobinOrderSnake:
+		if round%2 == 1 {
+			for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+				order[i], order[j] = order[j], order[i]
+			}
+		}
+	case RoundRobinOrderPriority:
+		if prioritizer, ok := r.TopLevelStruct().(roundRobinPlayerPrioritizer); ok {
+			sort.SliceStable(order, func(i, j int) bool {
+				return prioritizer.RoundRobinPlayerPriority(state.PlayerStates()[order[i]]) > prioritizer.RoundRobinPlayerPriority(state.PlayerStates()[order[j]])
+			})
+		}
+	}
+
+	return order
+}
+
+//playerOrder returns every player once, starting at starter and going around
+//in PlayerIndex order, or in reverse PlayerIndex order if reverse is true.
+func playerOrder(state boardgame.State, starter boardgame.PlayerIndex, reverse bool) []boardgame.PlayerIndex {
+
+	order := make([]boardgame.PlayerIndex, len(state.PlayerStates()))
+
+	player := starter
+
+	for i := range order {
+		order[i] = player
+		if reverse {
+			player = player.Previous(state)
+		} else {
+			player = player.Next(state)
+		}
+	}
+
+	return order
+}
+
+//roundForPosition returns which round the given position is in. Position -1
+//(before the round robin has started) is in round -1.
+func roundForPosition(position int, numPlayers int) int {
+	if position < 0 {
+		return -1
+	}
+	return position / numPlayers
+}
+
 //nextPlayerIndex returns the next playerIndex that the round robin will
-//operate on.  Also returns roundSkip true if the player we end on is the
-//first player in the round robin, or if we skipped over them to find the next
-//valid player.
-func (r *RoundRobin) nextPlayerIndex(state boardgame.State) (player boardgame.PlayerIndex, roundSkip bool) {
+//operate on, and its position in the overall order. Also returns roundSkip
+//true if the player we end on is in a later round than the last player, or if
+//no player is legal.
+func (r *RoundRobin) nextPlayerIndex(state boardgame.State) (player boardgame.PlayerIndex, position int, roundSkip bool) {
 
-	var currentPlayer boardgame.PlayerIndex
+	var starter boardgame.PlayerIndex
 
 	roundRobiner, ok := state.GameState().(moveinterfaces.RoundRobinProperties)
 
 	if !ok {
-		return boardgame.ObserverPlayerIndex, true
+		return boardgame.ObserverPlayerIndex, -1, true
 	}
 
-	if r.roundRobinHasStarted(state) {
+	lastPosition := -1
 
-		currentPlayer = roundRobiner.RoundRobinLastPlayer()
+	if r.roundRobinHasStarted(state) {
+		starter = roundRobiner.RoundRobinStarterPlayer()
+		lastPosition = roundRobiner.RoundRobinLastPosition()
 	} else {
 
 		starterPlayer, ok := r.TopLevelStruct().(roundRobinStarterPlayer)
 
 		if !ok {
-			return boardgame.ObserverPlayerIndex, true
+			return boardgame.ObserverPlayerIndex, -1, true
 		}
 
-		currentPlayer = starterPlayer.RoundRobinStarterPlayer(state).Previous(state)
+		starter = starterPlayer.RoundRobinStarterPlayer(state)
 	}
 
 	//If the PlayerConditionMet for that player is already true, we know that
@@ -214,34 +324,63 @@ func (r *RoundRobin) nextPlayerIndex(state boardgame.State) (player boardgame.Pl
 
 	if !ok {
 		//This should be extremely rare since we ourselves have the right method.
-		return boardgame.ObserverPlayerIndex, true
+		return boardgame.ObserverPlayerIndex, -1, true
 	}
 
-	counter := 0
-	roundSkip = false
+	numPlayers := len(state.PlayerStates())
 
-	//Advance around, but if we loop back just leave it.
-	for counter <= len(state.PlayerStates()) {
+	player, position, found := nextPlayerInOrder(state, lastPosition, func(round int) []boardgame.PlayerIndex {
+		return r.roundOrder(state, starter, round)
+	}, conditionsMet.PlayerConditionMet)
 
-		currentPlayer = currentPlayer.Next(state)
+	if !found {
+		//No players are legal
+		return player, position, true
+	}
 
-		if currentPlayer == roundRobiner.RoundRobinStarterPlayer() {
-			roundSkip = true
-		}
+	return player, position, roundForPosition(position, numPlayers) > roundForPosition(lastPosition, numPlayers)
 
-		if !conditionsMet.PlayerConditionMet(state.PlayerStates()[currentPlayer]) {
-			break
+}
+
+//nextPlayerInOrder returns the first player after lastPosition who isn't
+//eliminated and for whom skip returns false, and that player's position.
+//Position p is the player at p % numPlayers in order(p / numPlayers). This is
+//the logic RoundRobin uses to pick the next player; other moves that go
+//around the players, like auctions, share it so that they follow the same
+//turn rules. If no player is found it returns false.
+func nextPlayerInOrder(state boardgame.State, lastPosition int, order func(round int) []boardgame.PlayerIndex, skip func(playerState boardgame.PlayerState) bool) (player boardgame.PlayerIndex, position int, found bool) {
+
+	numPlayers := len(state.PlayerStates())
+
+	position = lastPosition
+	var roundOrder []boardgame.PlayerIndex
+	orderRound := -1
+
+	//Advance around, but if we loop back just leave it. Snake order needs two
+	//rounds to be sure every player has been considered.
+	for counter := 0; counter <= 2*numPlayers; counter++ {
+
+		position++
+
+		round := roundForPosition(position, numPlayers)
+
+		if roundOrder == nil || round != orderRound {
+			roundOrder = order(round)
+			orderRound = round
 		}
 
-		counter++
-	}
+		player = roundOrder[position%numPlayers]
 
-	if counter > len(state.PlayerStates()) {
-		//No players are legal
-		return currentPlayer, true
+		if player.Eliminated(state) {
+			continue
+		}
+
+		if !skip(state.PlayerStates()[player]) {
+			return player, position, true
+		}
 	}
 
-	return currentPlayer, roundSkip
+	return player, position, false
 
 }
 
@@ -336,7 +475,7 @@ func (r *RoundRobin) Apply(state boardgame.MutableState) error {
 		return errors.New("The round robin was found to be finished in our Apply, but it should have been marked finished before!")
 	}
 
-	nextPlayer, _ := r.nextPlayerIndex(state)
+	nextPlayer, position, _ := r.nextPlayerIndex(state)
 
 	actioner, ok := r.TopLevelStruct().(moveinterfaces.RoundRobinActioner)
 
@@ -357,8 +496,9 @@ func (r *RoundRobin) Apply(state boardgame.MutableState) error {
 	}
 
 	roundRobiner.SetRoundRobinLastPlayer(nextPlayer)
+	roundRobiner.SetRoundRobinLastPosition(position)
 
-	nextPlayer, roundSkip := r.nextPlayerIndex(state)
+	_, _, roundSkip := r.nextPlayerIndex(state)
 
 	if roundSkip {
 		roundRobiner.SetRoundRobinRoundCount(roundRobiner.RoundRobinRoundCount() + 1)
@@ -459,3 +599,48 @@ func (r *RoundRobinNumRounds) MoveTypeHelpText(manager *boardgame.GameManager) s
 	}
 	return "A round robin move that makes " + strconv.Itoa(numRounds.NumRounds()) + " circuits."
 }
+
+/*
+
+RoundRobinPassOut is a subclass of RoundRobin for the common pattern where
+players keep taking turns, dropping out as they pass, until every player has
+passed--for example placing workers or bidding. Your playerStates must
+implement moveinterfaces.RoundRobinPasser; your RoundRobinAction decides
+whether the player passes. Players who have passed are skipped, and once every
+player has passed the round robin is done. RoundRobinPassOut doesn't reset the
+passed flags for you; do that before starting the round robin.
+
+*/
+type RoundRobinPassOut struct {
+	RoundRobin
+}
+
+func (r *RoundRobinPassOut) ValidConfiguration(exampleState boardgame.MutableState) error {
+	if err := r.RoundRobin.ValidConfiguration(exampleState); err != nil {
+		return err
+	}
+	if _, ok := exampleState.PlayerStates()[0].(moveinterfaces.RoundRobinPasser); !ok {
+		return errors.New("PlayerState does not implement RoundRobinPasser")
+	}
+	return nil
+}
+
+//PlayerConditionMet returns true if the player has passed.
+func (r *RoundRobinPassOut) PlayerConditionMet(playerState boardgame.PlayerState) bool {
+	passer, ok := playerState.(moveinterfaces.RoundRobinPasser)
+
+	if !ok {
+		//Unexpected!
+		return true
+	}
+
+	return passer.RoundRobinPassed()
+}
+
+func (r *RoundRobinPassOut) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Round Robin Until All Passed"
+}
+
+func (r *RoundRobinPassOut) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "A round robin move that continues until every player has passed."
+}
@@ -0,0 +1,117 @@
+package moves
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+func (p *playerState) RoundRobinPassed() bool {
+	return p.Counter > int(p.playerIndex)
+}
+
+//+autoreader
+type moveRoundRobinOrder struct {
+	RoundRobinNumRounds
+	order     int
+	numRounds int
+}
+
+func (m *moveRoundRobinOrder) RoundRobinOrder() int {
+	return m.order
+}
+
+func (m *moveRoundRobinOrder) RoundRobinPlayerPriority(pState boardgame.PlayerState) int {
+	return int(pState.(*playerState).playerIndex)
+}
+
+func (m *moveRoundRobinOrder) NumRounds() int {
+	return m.numRounds
+}
+
+func (m *moveRoundRobinOrder) RoundRobinAction(pState boardgame.MutablePlayerState) error {
+	return recordVisit(pState)
+}
+
+//+autoreader
+type moveRoundRobinPassOut struct {
+	RoundRobinPassOut
+}
+
+func (m *moveRoundRobinPassOut) RoundRobinAction(pState boardgame.MutablePlayerState) error {
+	return recordVisit(pState)
+}
+
+func recordVisit(pState boardgame.MutablePlayerState) error {
+	player := pState.(*playerState)
+	player.Counter++
+	game := player.State().(boardgame.MutableState).MutableGameState().(*gameState)
+	game.Visits = append(game.Visits, int(player.playerIndex))
+	return nil
+}
+
+func TestRoundRobinOrder(t *testing.T) {
+
+	tests := []struct {
+		description    string
+		move           boardgame.Move
+		expectedVisits []int
+	}{
+		{
+			"Forward",
+			&moveRoundRobinOrder{order: RoundRobinOrderForward, numRounds: 2},
+			[]int{0, 1, 2, 3, 0, 1, 2, 3},
+		},
+		{
+			"Reverse",
+			&moveRoundRobinOrder{order: RoundRobinOrderReverse, numRounds: 1},
+			[]int{0, 3, 2, 1},
+		},
+		{
+			"Snake",
+			&moveRoundRobinOrder{order: RoundRobinOrderSnake, numRounds: 3},
+			[]int{0, 1, 2, 3, 3, 2, 1, 0, 0, 1, 2, 3},
+		},
+		{
+			"Priority",
+			&moveRoundRobinOrder{order: RoundRobinOrderPriority, numRounds: 1},
+			[]int{3, 2, 1, 0},
+		},
+		{
+			"Pass Out",
+			new(moveRoundRobinPassOut),
+			[]int{0, 1, 2, 3, 1, 2, 3, 2, 3, 3},
+		},
+	}
+
+	for i, test := range tests {
+
+		move := test.move
+
+		manager, err := newGameManager(func(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle {
+			config := MustDefaultConfig(manager, move)
+			config.MoveConstructor = func() boardgame.Move {
+				switch m := move.(type) {
+				case *moveRoundRobinOrder:
+					return &moveRoundRobinOrder{order: m.order, numRounds: m.numRounds}
+				}
+				return new(moveRoundRobinPassOut)
+			}
+			return boardgame.NewMoveTypeConfigBundle().AddMoves(config)
+		})
+
+		assert.For(t, i, test.description).ThatActual(err).IsNil()
+
+		game := manager.NewGame()
+
+		err = game.SetUp(0, nil, nil)
+
+		assert.For(t, i, test.description).ThatActual(err).IsNil()
+
+		gameState, _ := concreteStates(game.CurrentState())
+
+		assert.For(t, i, test.description).ThatActual(gameState.Visits).Equals(test.expectedVisits)
+		assert.For(t, i, test.description).ThatActual(gameState.RRHasStarted).IsFalse()
+	}
+
+}
@@ -0,0 +1,381 @@
+package moves
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/moves/moveinterfaces"
+	"time"
+)
+
+//We can keep these private because embedders already will have the interface
+//satisfied so don't need to be confused by them.
+type commitmentLegaler interface {
+	LegalCommitment(state boardgame.State, commitment int) error
+}
+
+type commitDeadliner interface {
+	CommitDeadline() time.Duration
+}
+
+type revealBeforeDeadliner interface {
+	RevealBeforeDeadline() bool
+}
+
+/*
+
+SimultaneousCommit is a move that allows every player to secretly commit to a
+choice (encoded as an int) at the same time, without having to wait for their
+turn. Commitments are stored on each player's state via the
+SimultaneousCommitter interface; generally you simply embed
+moveinterfaces.SimultaneousCommitBasePlayerState in your playerState
+(alongside boardgame.BaseSubState) to get this for free. That struct tags the
+pending commitment as hidden, so other players can see THAT a player has
+committed, but not WHAT they committed to.
+
+The commitment itself is not serialized with the move record (which every
+player can see), so it does not leak via the move history either.
+
+A player may propose this move as many times as they want before the reveal;
+each new commitment overwrites their previous one.
+
+Override LegalCommitment on your embedding move to restrict which commitments
+are legal. If you want a deadline, override CommitDeadline to return a non-zero
+duration, have your gameState implement moveinterfaces.SimultaneousDeadliner,
+and install a move embedding SimultaneousRevealDeadline. The timer is started
+when the first player of the round commits.
+
+You'll typically pair this with a move that embeds SimultaneousReveal, which
+will reveal all commitments once everyone has committed.
+
+*/
+type SimultaneousCommit struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+	Commitment        int `json:"-"`
+}
+
+func (s *SimultaneousCommit) ValidConfiguration(exampleState boardgame.MutableState) error {
+
+	if _, ok := exampleState.PlayerStates()[0].(moveinterfaces.SimultaneousCommitter); !ok {
+		return errors.New("PlayerState does not implement SimultaneousCommitter")
+	}
+
+	deadliner, ok := s.TopLevelStruct().(commitDeadliner)
+
+	if !ok || deadliner.CommitDeadline() <= 0 {
+		return nil
+	}
+
+	if _, ok := exampleState.GameState().(moveinterfaces.SimultaneousDeadliner); !ok {
+		return errors.New("CommitDeadline is non-zero but GameState does not implement SimultaneousDeadliner")
+	}
+
+	return nil
+}
+
+//Legal returns an error if the TargetPlayerIndex is not a valid player, if
+//it is not equivalent to the proposer, or if the embedding move's
+//LegalCommitment returns an error.
+func (s *SimultaneousCommit) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := s.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	if !s.TargetPlayerIndex.Valid(state) {
+		return errors.New("The specified target player is not valid")
+	}
+
+	if s.TargetPlayerIndex < 0 {
+		return errors.New("The specified target player is not valid")
+	}
+
+	if !s.TargetPlayerIndex.Equivalent(proposer) {
+		return errors.New("You can't commit on behalf of another player")
+	}
+
+	if _, ok := state.PlayerStates()[s.TargetPlayerIndex].(moveinterfaces.SimultaneousCommitter); !ok {
+		return errors.New("The player state did not implement SimultaneousCommitter")
+	}
+
+	legaler, ok := s.TopLevelStruct().(commitmentLegaler)
+
+	if !ok {
+		return errors.New("Unexpectedly the top level struct didn't have LegalCommitment")
+	}
+
+	return legaler.LegalCommitment(state, s.Commitment)
+
+}
+
+//Apply records the commitment on the target player's state, overwriting any
+//previous commitment they made this round. If CommitDeadline is non-zero and
+//the deadline timer isn't already running it will be started.
+func (s *SimultaneousCommit) Apply(state boardgame.MutableState) error {
+
+	committer, ok := state.MutablePlayerStates()[s.TargetPlayerIndex].(moveinterfaces.SimultaneousCommitter)
+
+	if !ok {
+		return errors.New("The player state did not implement SimultaneousCommitter")
+	}
+
+	committer.SetSimultaneousCommitment(s.Commitment)
+	committer.SetSimultaneousHasCommitted(true)
+
+	deadliner, ok := s.TopLevelStruct().(commitDeadliner)
+
+	if !ok {
+		return errors.New("Unexpectedly the top level struct didn't have CommitDeadline")
+	}
+
+	duration := deadliner.CommitDeadline()
+
+	if duration <= 0 {
+		return nil
+	}
+
+	gameDeadliner, ok := state.MutableGameState().(moveinterfaces.SimultaneousDeadliner)
+
+	if !ok {
+		return errors.New("GameState does not implement SimultaneousDeadliner")
+	}
+
+	timer := gameDeadliner.SimultaneousDeadlineTimer()
+
+	if timer.Active() {
+		return nil
+	}
+
+	move := deadlineMove(state)
+
+	if move == nil {
+		return errors.New("There is no legal move embedding SimultaneousRevealDeadline to start the deadline timer with")
+	}
+
+	timer.Start(duration, move)
+
+	return nil
+}
+
+//LegalCommitment is consulted by Legal to see if the given commitment is
+//allowed. The default returns nil for every commitment; override it in your
+//embedding move to restrict the choices.
+func (s *SimultaneousCommit) LegalCommitment(state boardgame.State, commitment int) error {
+	return nil
+}
+
+//CommitDeadline is how long players have to commit once the first player in
+//a round has committed. The default is 0, which means there is no deadline.
+func (s *SimultaneousCommit) CommitDeadline() time.Duration {
+	return 0
+}
+
+func (s *SimultaneousCommit) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Simultaneous Commit"
+}
+
+func (s *SimultaneousCommit) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Secretly commits the player to a choice that will be revealed once everyone has committed."
+}
+
+func (s *SimultaneousCommit) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+/*
+
+SimultaneousReveal is a FixUp move that becomes legal once every player has
+committed via SimultaneousCommit. When applied it copies each player's
+commitment into their revealed commitment, clears the pending commitments for
+the next round, cancels the deadline timer if there is one, and then calls
+ResolveCommitments on the embedding move, which must implement
+moveinterfaces.SimultaneousResolver.
+
+If a deadline timer is running, by default the reveal waits for it to fire so
+that players may change their commitments until the deadline. Override
+RevealBeforeDeadline to return true to reveal as soon as everyone has
+committed instead.
+
+*/
+type SimultaneousReveal struct {
+	Base
+}
+
+func (s *SimultaneousReveal) ValidConfiguration(exampleState boardgame.MutableState) error {
+
+	if _, ok := exampleState.PlayerStates()[0].(moveinterfaces.SimultaneousCommitter); !ok {
+		return errors.New("PlayerState does not implement SimultaneousCommitter")
+	}
+
+	if _, ok := s.TopLevelStruct().(moveinterfaces.SimultaneousResolver); !ok {
+		return errors.New("Embedding move doesn't implement SimultaneousResolver")
+	}
+
+	return nil
+}
+
+//Legal returns nil once every player has committed, unless a deadline timer
+//is running and RevealBeforeDeadline returns false.
+func (s *SimultaneousReveal) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := s.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	for _, player := range state.PlayerStates() {
+		committer, ok := player.(moveinterfaces.SimultaneousCommitter)
+		if !ok {
+			return errors.New("The player state did not implement SimultaneousCommitter")
+		}
+		if !committer.SimultaneousHasCommitted() {
+			return errors.New("Not every player has committed yet")
+		}
+	}
+
+	revealer, ok := s.TopLevelStruct().(revealBeforeDeadliner)
+
+	if !ok {
+		return errors.New("Unexpectedly the top level struct didn't have RevealBeforeDeadline")
+	}
+
+	if revealer.RevealBeforeDeadline() {
+		return nil
+	}
+
+	if deadliner, ok := state.GameState().(moveinterfaces.SimultaneousDeadliner); ok {
+		if deadliner.SimultaneousDeadlineTimer().Active() {
+			return errors.New("Players may still change their commitments until the deadline")
+		}
+	}
+
+	return nil
+
+}
+
+//Apply reveals every player's commitment and then calls ResolveCommitments.
+func (s *SimultaneousReveal) Apply(state boardgame.MutableState) error {
+	return revealCommitments(s.TopLevelStruct(), state)
+}
+
+//RevealBeforeDeadline is consulted by Legal when a deadline timer is running.
+//The default returns false, which means the reveal waits for the deadline.
+func (s *SimultaneousReveal) RevealBeforeDeadline() bool {
+	return false
+}
+
+func (s *SimultaneousReveal) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Simultaneous Reveal"
+}
+
+func (s *SimultaneousReveal) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Reveals every player's commitment once everyone has committed."
+}
+
+func (s *SimultaneousReveal) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return true
+}
+
+/*
+
+SimultaneousRevealDeadline is the move that is proposed by the deadline timer
+started by SimultaneousCommit. It reveals commitments just like
+SimultaneousReveal, except that it is legal as long as at least one player has
+committed. Players who have not committed when it fires will have
+SimultaneousHasRevealed set to false, so your ResolveCommitments can decide
+what to do with them.
+
+It is a player move (because timers propose moves as the AdminPlayerIndex),
+but only the AdminPlayerIndex may make it.
+
+*/
+type SimultaneousRevealDeadline struct {
+	SimultaneousReveal
+}
+
+//Legal returns an error if the proposer isn't the AdminPlayerIndex or if no
+//player has committed yet.
+func (s *SimultaneousRevealDeadline) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := s.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	if proposer != boardgame.AdminPlayerIndex {
+		return errors.New("Only the deadline timer may force commitments to be revealed")
+	}
+
+	for _, player := range state.PlayerStates() {
+		committer, ok := player.(moveinterfaces.SimultaneousCommitter)
+		if !ok {
+			return errors.New("The player state did not implement SimultaneousCommitter")
+		}
+		if committer.SimultaneousHasCommitted() {
+			return nil
+		}
+	}
+
+	return errors.New("No player has committed yet")
+
+}
+
+func (s *SimultaneousRevealDeadline) isSimultaneousRevealDeadline() {}
+
+func (s *SimultaneousRevealDeadline) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Simultaneous Reveal Deadline"
+}
+
+func (s *SimultaneousRevealDeadline) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Reveals every player's commitment when the deadline passes, even if not everyone has committed."
+}
+
+func (s *SimultaneousRevealDeadline) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+type simultaneousRevealDeadliner interface {
+	isSimultaneousRevealDeadline()
+}
+
+//deadlineMove returns a new move of the first player move type that embeds
+//SimultaneousRevealDeadline and would be legal to apply in the given state.
+func deadlineMove(state boardgame.State) boardgame.Move {
+	for _, moveType := range state.Game().Manager().PlayerMoveTypes() {
+		move := moveType.NewMove(state)
+		if _, ok := move.(simultaneousRevealDeadliner); !ok {
+			continue
+		}
+		if err := move.Legal(state, boardgame.AdminPlayerIndex); err != nil {
+			continue
+		}
+		return move
+	}
+	return nil
+}
+
+//revealCommitments is the shared logic for SimultaneousReveal and
+//SimultaneousRevealDeadline.
+func revealCommitments(topLevelStruct boardgame.Move, state boardgame.MutableState) error {
+
+	resolver, ok := topLevelStruct.(moveinterfaces.SimultaneousResolver)
+
+	if !ok {
+		return errors.New("Embedding move doesn't implement SimultaneousResolver")
+	}
+
+	for _, player := range state.MutablePlayerStates() {
+		committer, ok := player.(moveinterfaces.SimultaneousCommitter)
+		if !ok {
+			return errors.New("The player state did not implement SimultaneousCommitter")
+		}
+		committer.SetSimultaneousHasRevealed(committer.SimultaneousHasCommitted())
+		committer.SetSimultaneousRevealedCommitment(committer.SimultaneousCommitment())
+		committer.SetSimultaneousCommitment(0)
+		committer.SetSimultaneousHasCommitted(false)
+	}
+
+	if deadliner, ok := state.MutableGameState().(moveinterfaces.SimultaneousDeadliner); ok {
+		deadliner.SimultaneousDeadlineTimer().Cancel()
+	}
+
+	return resolver.ResolveCommitments(state)
+}
@@ -0,0 +1,181 @@
+package moves
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/workfit/tester/assert"
+	"testing"
+	"time"
+)
+
+//+autoreader
+type moveCommit struct {
+	SimultaneousCommit
+}
+
+func (m *moveCommit) LegalCommitment(state boardgame.State, commitment int) error {
+	if commitment < 0 || commitment > 2 {
+		return errors.New("Commitment must be between 0 and 2")
+	}
+	return nil
+}
+
+//+autoreader
+type moveReveal struct {
+	SimultaneousReveal
+}
+
+func (m *moveReveal) ResolveCommitments(state boardgame.MutableState) error {
+	game, players := concreteStates(state)
+
+	for _, player := range players {
+		game.Counter += player.SCRevealedCommitment
+	}
+
+	return nil
+}
+
+//+autoreader
+type moveDeadlineCommit struct {
+	SimultaneousCommit
+}
+
+func (m *moveDeadlineCommit) CommitDeadline() time.Duration {
+	return 50 * time.Millisecond
+}
+
+//+autoreader
+type moveRevealDeadline struct {
+	SimultaneousRevealDeadline
+}
+
+func (m *moveRevealDeadline) ResolveCommitments(state boardgame.MutableState) error {
+	return new(moveReveal).ResolveCommitments(state)
+}
+
+func simultaneousMoveInstaller(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle {
+	return boardgame.NewMoveTypeConfigBundle().AddMoves(
+		MustDefaultConfig(manager, new(moveCommit)),
+		MustDefaultConfig(manager, new(moveReveal)),
+	)
+}
+
+func TestSimultaneousCommit(t *testing.T) {
+	manager, err := newGameManager(simultaneousMoveInstaller)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	err = game.SetUp(0, nil, nil)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	commit := func(player boardgame.PlayerIndex, commitment int) error {
+		move := game.PlayerMoveByName("Simultaneous Commit").(*moveCommit)
+		move.TargetPlayerIndex = player
+		move.Commitment = commitment
+		return <-game.ProposeMove(move, player)
+	}
+
+	move := game.PlayerMoveByName("Simultaneous Commit").(*moveCommit)
+	move.TargetPlayerIndex = 1
+	move.Commitment = 1
+
+	assert.For(t).ThatActual(move.Legal(game.CurrentState(), 0)).IsNotNil()
+
+	assert.For(t).ThatActual(commit(0, 3)).IsNotNil()
+
+	assert.For(t).ThatActual(commit(0, 1)).IsNil()
+	assert.For(t).ThatActual(commit(1, 2)).IsNil()
+	assert.For(t).ThatActual(commit(2, 2)).IsNil()
+
+	_, players := concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(players[0].SCHasCommitted).IsTrue()
+	assert.For(t).ThatActual(players[0].SCCommitment).Equals(1)
+	assert.For(t).ThatActual(players[3].SCHasCommitted).IsFalse()
+
+	_, sanitizedPlayers := concreteStates(game.CurrentState().SanitizedForPlayer(1))
+
+	assert.For(t).ThatActual(sanitizedPlayers[0].SCHasCommitted).IsTrue()
+	assert.For(t).ThatActual(sanitizedPlayers[0].SCCommitment).Equals(0)
+	assert.For(t).ThatActual(sanitizedPlayers[1].SCCommitment).Equals(2)
+
+	//Players may change their mind before the reveal.
+	assert.For(t).ThatActual(commit(0, 2)).IsNil()
+
+	assert.For(t).ThatActual(commit(3, 0)).IsNil()
+
+	gameState, players := concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(gameState.Counter).Equals(6)
+
+	expected := []int{2, 2, 2, 0}
+
+	for i, player := range players {
+		assert.For(t, i).ThatActual(player.SCHasCommitted).IsFalse()
+		assert.For(t, i).ThatActual(player.SCHasRevealed).IsTrue()
+		assert.For(t, i).ThatActual(player.SCRevealedCommitment).Equals(expected[i])
+	}
+
+	lastMove := game.MoveRecords(-1)
+	assert.For(t).ThatActual(lastMove[len(lastMove)-1].Name).Equals("Simultaneous Reveal")
+
+}
+
+func TestSimultaneousCommitDeadline(t *testing.T) {
+	manager, err := newGameManager(func(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle {
+		return boardgame.NewMoveTypeConfigBundle().AddMoves(
+			MustDefaultConfig(manager, new(moveDeadlineCommit)),
+			MustDefaultConfig(manager, new(moveReveal)),
+			MustDefaultConfig(manager, new(moveRevealDeadline)),
+		)
+	})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	commit := func(player boardgame.PlayerIndex, commitment int) error {
+		move := game.PlayerMoveByName("Simultaneous Commit").(*moveDeadlineCommit)
+		move.TargetPlayerIndex = player
+		move.Commitment = commitment
+		return <-game.ProposeMove(move, player)
+	}
+
+	//Players can't force the reveal themselves.
+	deadline := game.PlayerMoveByName("Simultaneous Reveal Deadline")
+
+	assert.For(t).ThatActual(deadline.Legal(game.CurrentState(), 0)).IsNotNil()
+
+	assert.For(t).ThatActual(commit(0, 1)).IsNil()
+
+	gameState, _ := concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(gameState.SCDeadline.Active()).IsTrue()
+
+	assert.For(t).ThatActual(commit(2, 2)).IsNil()
+
+	<-time.After(300 * time.Millisecond)
+
+	game.Refresh()
+
+	gameState, players := concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(gameState.SCDeadline.Active()).IsFalse()
+	assert.For(t).ThatActual(gameState.Counter).Equals(3)
+
+	revealed := []bool{true, false, true, false}
+
+	for i, player := range players {
+		assert.For(t, i).ThatActual(player.SCHasCommitted).IsFalse()
+		assert.For(t, i).ThatActual(player.SCHasRevealed).Equals(revealed[i])
+	}
+
+	lastMove := game.MoveRecords(-1)
+	assert.For(t).ThatActual(lastMove[len(lastMove)-1].Name).Equals("Simultaneous Reveal Deadline")
+
+}
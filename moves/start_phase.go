@@ -40,6 +40,38 @@ func (s *StartPhase) ValidConfiguration(exampleState boardgame.MutableState) err
 	return nil
 }
 
+//Legal returns an error if the game's PhaseMachine doesn't allow a
+//transition from the current phase to the one returned by PhaseToStart, in
+//addition to the checks in Base.Legal.
+func (s *StartPhase) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := s.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	machine := state.Game().Manager().Delegate().PhaseMachine()
+
+	if machine == nil {
+		return nil
+	}
+
+	phaseEnterer, ok := s.TopLevelStruct().(phaseToStarter)
+
+	if !ok {
+		return errors.New("The embedding move does not have PhaseToStart()")
+	}
+
+	currentPhase := state.Game().Manager().Delegate().CurrentPhase(state)
+
+	phaseToEnter := phaseEnterer.PhaseToStart(currentPhase)
+
+	if !machine.LegalTransition(currentPhase, phaseToEnter) {
+		return errors.New("The phase machine doesn't allow a transition from phase " + strconv.Itoa(currentPhase) + " to " + strconv.Itoa(phaseToEnter))
+	}
+
+	return nil
+}
+
 //PhaseToStart uses the Phase provided via StartPhaseMoveConfig constructor
 //(or 0 if NewStartPhaseConfig wasn't used). If you want a different behavior,
 //override PhaseToStart in your embedding move.
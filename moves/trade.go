@@ -0,0 +1,503 @@
+package moves
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/moves/moveinterfaces"
+)
+
+/*
+
+ProposeTrade is a move that offers a trade to another player: the proposer
+will give Give in return for Take. Give and Take are indexed by whatever
+resource types your game defines, for example one slot per resource in
+Catan.
+
+Your playerStates must implement moveinterfaces.TradeProperties (generally by
+embedding moveinterfaces.TradeBasePlayerState) and
+moveinterfaces.TradeHolder. The offer is stored on both the proposer and the
+recipient, hidden from every other player. For the same reason Give and Take
+aren't serialized with the move record, which everyone can see.
+
+Each player may have at most one outgoing offer and one incoming offer
+pending at a time. The recipient responds with AcceptTrade, RejectTrade, or
+CounterTrade, and the proposer may WithdrawTrade at any point before then.
+
+*/
+type ProposeTrade struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+	Recipient         boardgame.PlayerIndex
+	Give              []int `json:"-"`
+	Take              []int `json:"-"`
+}
+
+func (p *ProposeTrade) ValidConfiguration(exampleState boardgame.MutableState) error {
+	return validTradeConfiguration(exampleState)
+}
+
+//Legal checks that TargetPlayerIndex is the proposer and has no other
+//outgoing offer, that Recipient is another player with no other incoming
+//offer, and that the proposer holds everything in Give.
+func (p *ProposeTrade) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := p.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	return legalTradeOffer(state, proposer, p.TargetPlayerIndex, p.Recipient, p.Give, p.Take)
+
+}
+
+//Apply records the offer on both the proposer and the recipient.
+func (p *ProposeTrade) Apply(state boardgame.MutableState) error {
+	return setTradeOffer(state, p.TargetPlayerIndex, p.Recipient, p.Give, p.Take)
+}
+
+func (p *ProposeTrade) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Propose Trade"
+}
+
+func (p *ProposeTrade) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Offers a trade to another player."
+}
+
+func (p *ProposeTrade) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+/*
+
+CounterTrade is a move the recipient of an offer can make to decline it and
+in the same move propose a different trade back to the original proposer.
+Give and Take are from the point of view of the player making the counter
+offer.
+
+*/
+type CounterTrade struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+	Give              []int `json:"-"`
+	Take              []int `json:"-"`
+}
+
+func (c *CounterTrade) ValidConfiguration(exampleState boardgame.MutableState) error {
+	return validTradeConfiguration(exampleState)
+}
+
+//Legal checks that TargetPlayerIndex has an incoming offer and that the
+//counter offer would be a legal offer back to the original proposer.
+func (c *CounterTrade) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := c.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	player, err := tradePlayer(state, c.TargetPlayerIndex, proposer)
+
+	if err != nil {
+		return err
+	}
+
+	if !player.TradeIncomingActive() {
+		return errors.New("You don't have an offer to counter")
+	}
+
+	return legalTradeOffer(state, proposer, c.TargetPlayerIndex, player.TradeIncomingFrom(), c.Give, c.Take)
+
+}
+
+//Apply clears the original offer and records the counter offer in its place.
+func (c *CounterTrade) Apply(state boardgame.MutableState) error {
+
+	player, ok := state.MutablePlayerStates()[c.TargetPlayerIndex].(moveinterfaces.TradeProperties)
+
+	if !ok {
+		return errors.New("PlayerState does not implement TradeProperties")
+	}
+
+	from := player.TradeIncomingFrom()
+
+	if err := clearTradeOffer(state, from); err != nil {
+		return err
+	}
+
+	return setTradeOffer(state, c.TargetPlayerIndex, from, c.Give, c.Take)
+}
+
+func (c *CounterTrade) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Counter Trade"
+}
+
+func (c *CounterTrade) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Declines a trade offer and proposes a different one in return."
+}
+
+func (c *CounterTrade) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+/*
+
+AcceptTrade is a move the recipient of an offer makes to accept it. Right
+before the trade happens it checks, via moveinterfaces.TradeHolder, that the
+proposer still holds everything they offered and that the recipient holds
+everything that was asked for, since either may have changed since the offer
+was made. It then calls TradeTransfer on both players.
+
+*/
+type AcceptTrade struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+}
+
+func (a *AcceptTrade) ValidConfiguration(exampleState boardgame.MutableState) error {
+	return validTradeConfiguration(exampleState)
+}
+
+//Legal checks that TargetPlayerIndex has an incoming offer and that both
+//sides still hold what they'd be trading.
+func (a *AcceptTrade) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := a.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	player, err := tradePlayer(state, a.TargetPlayerIndex, proposer)
+
+	if err != nil {
+		return err
+	}
+
+	if !player.TradeIncomingActive() {
+		return errors.New("You don't have an offer to accept")
+	}
+
+	from := state.PlayerStates()[player.TradeIncomingFrom()]
+
+	if err := from.(moveinterfaces.TradeHolder).TradeHolds(player.TradeIncomingGive()); err != nil {
+		return errors.New("The other player no longer holds what they offered: " + err.Error())
+	}
+
+	if err := player.(moveinterfaces.TradeHolder).TradeHolds(player.TradeIncomingTake()); err != nil {
+		return errors.New("You no longer hold what was asked for: " + err.Error())
+	}
+
+	return nil
+
+}
+
+//Apply transfers the resources in both directions and clears the offer.
+func (a *AcceptTrade) Apply(state boardgame.MutableState) error {
+
+	playerStates := state.MutablePlayerStates()
+
+	recipient := playerStates[a.TargetPlayerIndex]
+
+	player, ok := recipient.(moveinterfaces.TradeProperties)
+
+	if !ok {
+		return errors.New("PlayerState does not implement TradeProperties")
+	}
+
+	from := player.TradeIncomingFrom()
+	proposer := playerStates[from]
+
+	give := player.TradeIncomingGive()
+	take := player.TradeIncomingTake()
+
+	if err := clearTradeOffer(state, from); err != nil {
+		return err
+	}
+
+	if err := proposer.(moveinterfaces.TradeHolder).TradeTransfer(give, recipient); err != nil {
+		return errors.New("Couldn't transfer offered resources: " + err.Error())
+	}
+
+	if err := recipient.(moveinterfaces.TradeHolder).TradeTransfer(take, proposer); err != nil {
+		return errors.New("Couldn't transfer requested resources: " + err.Error())
+	}
+
+	return nil
+}
+
+func (a *AcceptTrade) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Accept Trade"
+}
+
+func (a *AcceptTrade) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Accepts a trade offer."
+}
+
+func (a *AcceptTrade) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+/*
+
+RejectTrade is a move the recipient of an offer makes to decline it.
+
+*/
+type RejectTrade struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+}
+
+func (r *RejectTrade) ValidConfiguration(exampleState boardgame.MutableState) error {
+	return validTradeConfiguration(exampleState)
+}
+
+//Legal checks that TargetPlayerIndex has an incoming offer.
+func (r *RejectTrade) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := r.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	player, err := tradePlayer(state, r.TargetPlayerIndex, proposer)
+
+	if err != nil {
+		return err
+	}
+
+	if !player.TradeIncomingActive() {
+		return errors.New("You don't have an offer to reject")
+	}
+
+	return nil
+
+}
+
+//Apply clears the offer from both players.
+func (r *RejectTrade) Apply(state boardgame.MutableState) error {
+
+	player, ok := state.MutablePlayerStates()[r.TargetPlayerIndex].(moveinterfaces.TradeProperties)
+
+	if !ok {
+		return errors.New("PlayerState does not implement TradeProperties")
+	}
+
+	return clearTradeOffer(state, player.TradeIncomingFrom())
+}
+
+func (r *RejectTrade) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Reject Trade"
+}
+
+func (r *RejectTrade) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Rejects a trade offer."
+}
+
+func (r *RejectTrade) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+/*
+
+WithdrawTrade is a move the proposer of an offer makes to take it back before
+the recipient has responded.
+
+*/
+type WithdrawTrade struct {
+	Base
+	TargetPlayerIndex boardgame.PlayerIndex
+}
+
+func (w *WithdrawTrade) ValidConfiguration(exampleState boardgame.MutableState) error {
+	return validTradeConfiguration(exampleState)
+}
+
+//Legal checks that TargetPlayerIndex has an outgoing offer.
+func (w *WithdrawTrade) Legal(state boardgame.State, proposer boardgame.PlayerIndex) error {
+
+	if err := w.Base.Legal(state, proposer); err != nil {
+		return err
+	}
+
+	player, err := tradePlayer(state, w.TargetPlayerIndex, proposer)
+
+	if err != nil {
+		return err
+	}
+
+	if !player.TradeOutgoingActive() {
+		return errors.New("You don't have an offer to withdraw")
+	}
+
+	return nil
+
+}
+
+//Apply clears the offer from both players.
+func (w *WithdrawTrade) Apply(state boardgame.MutableState) error {
+	return clearTradeOffer(state, w.TargetPlayerIndex)
+}
+
+func (w *WithdrawTrade) MoveTypeName(manager *boardgame.GameManager) string {
+	return "Withdraw Trade"
+}
+
+func (w *WithdrawTrade) MoveTypeHelpText(manager *boardgame.GameManager) string {
+	return "Withdraws a trade offer that hasn't been responded to yet."
+}
+
+func (w *WithdrawTrade) MoveTypeIsFixUp(manager *boardgame.GameManager) bool {
+	return false
+}
+
+func validTradeConfiguration(exampleState boardgame.MutableState) error {
+
+	if _, ok := exampleState.PlayerStates()[0].(moveinterfaces.TradeProperties); !ok {
+		return errors.New("PlayerState does not implement TradeProperties")
+	}
+
+	if _, ok := exampleState.PlayerStates()[0].(moveinterfaces.TradeHolder); !ok {
+		return errors.New("PlayerState does not implement TradeHolder")
+	}
+
+	return nil
+}
+
+//tradePlayer checks that target is a real player the proposer may act for
+//and returns their TradeProperties.
+func tradePlayer(state boardgame.State, target boardgame.PlayerIndex, proposer boardgame.PlayerIndex) (moveinterfaces.TradeProperties, error) {
+
+	if !target.Valid(state) || target < 0 {
+		return nil, errors.New("The specified target player is not valid")
+	}
+
+	if !target.Equivalent(proposer) {
+		return nil, errors.New("You can't trade on behalf of another player")
+	}
+
+	player, ok := state.PlayerStates()[target].(moveinterfaces.TradeProperties)
+
+	if !ok {
+		return nil, errors.New("PlayerState does not implement TradeProperties")
+	}
+
+	return player, nil
+}
+
+//legalTradeOffer checks whether from may offer the given trade to to.
+func legalTradeOffer(state boardgame.State, proposer boardgame.PlayerIndex, from boardgame.PlayerIndex, to boardgame.PlayerIndex, give []int, take []int) error {
+
+	fromPlayer, err := tradePlayer(state, from, proposer)
+
+	if err != nil {
+		return err
+	}
+
+	if !to.Valid(state) || to < 0 {
+		return errors.New("The specified recipient is not valid")
+	}
+
+	if to == from {
+		return errors.New("You can't trade with yourself")
+	}
+
+	toPlayer, ok := state.PlayerStates()[to].(moveinterfaces.TradeProperties)
+
+	if !ok {
+		return errors.New("PlayerState does not implement TradeProperties")
+	}
+
+	if fromPlayer.TradeOutgoingActive() {
+		return errors.New("You already have an offer pending")
+	}
+
+	if toPlayer.TradeIncomingActive() {
+		return errors.New("That player already has an offer pending")
+	}
+
+	empty := true
+
+	for _, resources := range [][]int{give, take} {
+		for _, count := range resources {
+			if count < 0 {
+				return errors.New("You can't trade a negative amount")
+			}
+			if count > 0 {
+				empty = false
+			}
+		}
+	}
+
+	if empty {
+		return errors.New("The offer doesn't trade anything")
+	}
+
+	if err := fromPlayer.(moveinterfaces.TradeHolder).TradeHolds(give); err != nil {
+		return errors.New("You don't hold what you're offering: " + err.Error())
+	}
+
+	return nil
+}
+
+func setTradeOffer(state boardgame.MutableState, from boardgame.PlayerIndex, to boardgame.PlayerIndex, give []int, take []int) error {
+
+	fromPlayer, ok := state.MutablePlayerStates()[from].(moveinterfaces.TradeProperties)
+
+	if !ok {
+		return errors.New("PlayerState does not implement TradeProperties")
+	}
+
+	toPlayer, ok := state.MutablePlayerStates()[to].(moveinterfaces.TradeProperties)
+
+	if !ok {
+		return errors.New("PlayerState does not implement TradeProperties")
+	}
+
+	fromPlayer.SetTradeOutgoingActive(true)
+	fromPlayer.SetTradeOutgoingTo(to)
+	fromPlayer.SetTradeOutgoingGive(copyInts(give))
+	fromPlayer.SetTradeOutgoingTake(copyInts(take))
+
+	toPlayer.SetTradeIncomingActive(true)
+	toPlayer.SetTradeIncomingFrom(from)
+	toPlayer.SetTradeIncomingGive(copyInts(give))
+	toPlayer.SetTradeIncomingTake(copyInts(take))
+
+	return nil
+}
+
+//clearTradeOffer clears the outgoing offer from the given player, as well as
+//the matching incoming offer on its recipient.
+func clearTradeOffer(state boardgame.MutableState, from boardgame.PlayerIndex) error {
+
+	fromPlayer, ok := state.MutablePlayerStates()[from].(moveinterfaces.TradeProperties)
+
+	if !ok {
+		return errors.New("PlayerState does not implement TradeProperties")
+	}
+
+	to := fromPlayer.TradeOutgoingTo()
+
+	fromPlayer.SetTradeOutgoingActive(false)
+	fromPlayer.SetTradeOutgoingTo(boardgame.ObserverPlayerIndex)
+	fromPlayer.SetTradeOutgoingGive(nil)
+	fromPlayer.SetTradeOutgoingTake(nil)
+
+	if !to.Valid(state) || to < 0 {
+		return nil
+	}
+
+	toPlayer, ok := state.MutablePlayerStates()[to].(moveinterfaces.TradeProperties)
+
+	if !ok {
+		return errors.New("PlayerState does not implement TradeProperties")
+	}
+
+	toPlayer.SetTradeIncomingActive(false)
+	toPlayer.SetTradeIncomingFrom(boardgame.ObserverPlayerIndex)
+	toPlayer.SetTradeIncomingGive(nil)
+	toPlayer.SetTradeIncomingTake(nil)
+
+	return nil
+}
+
+func copyInts(input []int) []int {
+	result := make([]int, len(input))
+	copy(result, input)
+	return result
+}
@@ -0,0 +1,164 @@
+package moves
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+//In the tests, the only tradeable resource is the player's Counter.
+func (p *playerState) TradeHolds(resources []int) error {
+	if len(resources) > 0 && resources[0] > p.Counter {
+		return errors.New("Not enough in Counter")
+	}
+	return nil
+}
+
+func (p *playerState) TradeTransfer(resources []int, recipient boardgame.MutablePlayerState) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	p.Counter -= resources[0]
+	recipient.(*playerState).Counter += resources[0]
+	return nil
+}
+
+//+autoreader
+type moveProposeTrade struct {
+	ProposeTrade
+}
+
+//+autoreader
+type moveCounterTrade struct {
+	CounterTrade
+}
+
+//+autoreader
+type moveAcceptTrade struct {
+	AcceptTrade
+}
+
+//+autoreader
+type moveRejectTrade struct {
+	RejectTrade
+}
+
+//+autoreader
+type moveWithdrawTrade struct {
+	WithdrawTrade
+}
+
+func tradeMoveInstaller(manager *boardgame.GameManager) *boardgame.MoveTypeConfigBundle {
+	return boardgame.NewMoveTypeConfigBundle().AddMoves(
+		MustDefaultConfig(manager, new(moveProposeTrade)),
+		MustDefaultConfig(manager, new(moveCounterTrade)),
+		MustDefaultConfig(manager, new(moveAcceptTrade)),
+		MustDefaultConfig(manager, new(moveRejectTrade)),
+		MustDefaultConfig(manager, new(moveWithdrawTrade)),
+	)
+}
+
+func TestTrade(t *testing.T) {
+	manager, err := newGameManager(tradeMoveInstaller)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	propose := func(from, to boardgame.PlayerIndex, give, take int) error {
+		move := game.PlayerMoveByName("Propose Trade").(*moveProposeTrade)
+		move.TargetPlayerIndex = from
+		move.Recipient = to
+		move.Give = []int{give}
+		move.Take = []int{take}
+		return <-game.ProposeMove(move, from)
+	}
+
+	respond := func(name string, player boardgame.PlayerIndex) error {
+		move := game.PlayerMoveByName(name)
+		assert.For(t, name).ThatActual(move.ReadSetter().SetPlayerIndexProp("TargetPlayerIndex", player)).IsNil()
+		return <-game.ProposeMove(move, player)
+	}
+
+	_, players := concreteStates(game.CurrentState())
+
+	//Before any offer, no one is a trade partner, not even player 0.
+	assert.For(t).ThatActual(players[1].TradeIncomingFrom()).Equals(boardgame.ObserverPlayerIndex)
+	assert.For(t).ThatActual(players[0].TradeOutgoingTo()).Equals(boardgame.ObserverPlayerIndex)
+
+	//Player 0 doesn't have anything to give yet.
+	assert.For(t).ThatActual(propose(0, 1, 1, 0)).IsNotNil()
+
+	//An offer that trades nothing is illegal.
+	assert.For(t).ThatActual(propose(0, 1, 0, 0)).IsNotNil()
+
+	assert.For(t).ThatActual(propose(0, 1, 0, 2)).IsNil()
+
+	//Player 2 can't make an offer to player 1 while one is pending.
+	assert.For(t).ThatActual(propose(2, 1, 0, 1)).IsNotNil()
+
+	_, players = concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(players[0].TROutgoingActive).IsTrue()
+	assert.For(t).ThatActual(players[1].TRIncomingActive).IsTrue()
+	assert.For(t).ThatActual(players[1].TRIncomingFrom).Equals(boardgame.PlayerIndex(0))
+	assert.For(t).ThatActual(players[1].TRIncomingTake).Equals([]int{2})
+
+	_, sanitizedPlayers := concreteStates(game.CurrentState().SanitizedForPlayer(2))
+
+	assert.For(t).ThatActual(sanitizedPlayers[1].TRIncomingActive).IsTrue()
+	assert.For(t).ThatActual(sanitizedPlayers[1].TRIncomingTake).Equals([]int{})
+	assert.For(t).ThatActual(sanitizedPlayers[0].TROutgoingTake).Equals([]int{})
+
+	//Player 1 doesn't hold 2 yet, so can't accept.
+	assert.For(t).ThatActual(respond("Accept Trade", 1)).IsNotNil()
+
+	assert.For(t).ThatActual(respond("Withdraw Trade", 0)).IsNil()
+
+	_, players = concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(players[0].TROutgoingActive).IsFalse()
+	assert.For(t).ThatActual(players[1].TRIncomingActive).IsFalse()
+	assert.For(t).ThatActual(players[0].TROutgoingTo).Equals(boardgame.ObserverPlayerIndex)
+	assert.For(t).ThatActual(players[1].TRIncomingFrom).Equals(boardgame.ObserverPlayerIndex)
+
+	assert.For(t).ThatActual(propose(0, 1, 0, 2)).IsNil()
+	assert.For(t).ThatActual(respond("Reject Trade", 1)).IsNil()
+
+	assert.For(t).ThatActual(propose(0, 1, 0, 2)).IsNil()
+
+	//Give player 1 something to trade via a counter offer, then accept it.
+	counter := game.PlayerMoveByName("Counter Trade").(*moveCounterTrade)
+	counter.TargetPlayerIndex = 1
+	counter.Give = []int{0}
+	counter.Take = []int{0}
+
+	assert.For(t).ThatActual(<-game.ProposeMove(counter, 1)).IsNotNil()
+
+	game.CurrentState().PlayerStates()[1].(*playerState).Counter = 3
+
+	counter.Take = []int{0}
+	counter.Give = []int{1}
+
+	assert.For(t).ThatActual(<-game.ProposeMove(counter, 1)).IsNil()
+
+	_, players = concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(players[0].TROutgoingActive).IsFalse()
+	assert.For(t).ThatActual(players[0].TRIncomingActive).IsTrue()
+	assert.For(t).ThatActual(players[0].TRIncomingFrom).Equals(boardgame.PlayerIndex(1))
+	assert.For(t).ThatActual(players[1].TROutgoingActive).IsTrue()
+
+	assert.For(t).ThatActual(respond("Accept Trade", 0)).IsNil()
+
+	_, players = concreteStates(game.CurrentState())
+
+	assert.For(t).ThatActual(players[0].Counter).Equals(1)
+	assert.For(t).ThatActual(players[1].Counter).Equals(2)
+	assert.For(t).ThatActual(players[0].TRIncomingActive).IsFalse()
+	assert.For(t).ThatActual(players[1].TROutgoingActive).IsFalse()
+
+}
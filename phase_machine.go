@@ -0,0 +1,262 @@
+package boardgame
+
+import (
+	"github.com/jkomoros/boardgame/errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//PhaseMachine is a declarative description of a game's flow: which phases
+//exist, which phase the game starts in, and which phases may follow each
+//phase. Which move types belong to each phase is not declared here; that
+//comes from each move type's LegalPhases, which AddMovesForPhase and
+//AddOrderedMovesForPhase set for you. Return one from your delegate's
+//PhaseMachine method and NewGameManager will verify that every phase is
+//reachable and every move type is only legal in declared phases, and
+//moves.StartPhase will refuse to make transitions that weren't declared.
+//Create one with NewPhaseMachine.
+type PhaseMachine struct {
+	initial     int
+	phases      []int
+	transitions map[int][]int
+}
+
+//NewPhaseMachine returns a new PhaseMachine that starts in the given phase,
+//which is declared automatically.
+func NewPhaseMachine(initial int) *PhaseMachine {
+	result := &PhaseMachine{
+		initial:     initial,
+		transitions: make(map[int][]int),
+	}
+	result.AddPhase(initial)
+	return result
+}
+
+//AddPhase declares the given phases. Declaring a phase more than once is a
+//no-op. Returns itself for convenient chaining.
+func (p *PhaseMachine) AddPhase(phase ...int) *PhaseMachine {
+	for _, item := range phase {
+		if p.HasPhase(item) {
+			continue
+		}
+		p.phases = append(p.phases, item)
+	}
+	return p
+}
+
+//AddTransition declares that moving from phase from to each of the phases in
+//to is allowed. Any phases that haven't yet been declared will be declared.
+//Returns itself for convenient chaining.
+func (p *PhaseMachine) AddTransition(from int, to ...int) *PhaseMachine {
+	p.AddPhase(from)
+	p.AddPhase(to...)
+	for _, item := range to {
+		if p.LegalTransition(from, item) {
+			continue
+		}
+		p.transitions[from] = append(p.transitions[from], item)
+	}
+	return p
+}
+
+//Initial returns the phase the game starts in.
+func (p *PhaseMachine) Initial() int {
+	return p.initial
+}
+
+//Phases returns all of the declared phases, in the order they were declared.
+func (p *PhaseMachine) Phases() []int {
+	return p.phases
+}
+
+//HasPhase returns true if the given phase was declared.
+func (p *PhaseMachine) HasPhase(phase int) bool {
+	for _, item := range p.phases {
+		if item == phase {
+			return true
+		}
+	}
+	return false
+}
+
+//Transitions returns the phases that may follow the given phase, in the
+//order they were declared.
+func (p *PhaseMachine) Transitions(from int) []int {
+	return p.transitions[from]
+}
+
+//LegalTransition returns true if a transition from phase from to phase to
+//was declared.
+func (p *PhaseMachine) LegalTransition(from, to int) bool {
+	for _, item := range p.transitions[from] {
+		if item == to {
+			return true
+		}
+	}
+	return false
+}
+
+//reachable returns the set of phases that can be reached from the initial
+//phase.
+func (p *PhaseMachine) reachable() map[int]bool {
+	result := map[int]bool{
+		p.initial: true,
+	}
+
+	queue := []int{p.initial}
+
+	for len(queue) > 0 {
+		phase := queue[0]
+		queue = queue[1:]
+		for _, next := range p.transitions[phase] {
+			if result[next] {
+				continue
+			}
+			result[next] = true
+			queue = append(queue, next)
+		}
+	}
+
+	return result
+}
+
+//Valid returns an error if the machine is not consistent with the given
+//manager: if any phase isn't a valid value in the delegate's PhaseEnum, if
+//any phase can't be reached from the initial phase, or if any installed move
+//type is legal in (or has a move progression for) a phase that wasn't
+//declared. NewGameManager calls this automatically.
+func (p *PhaseMachine) Valid(manager *GameManager) error {
+
+	phaseEnum := manager.Delegate().PhaseEnum()
+
+	if phaseEnum != nil {
+		for _, phase := range p.phases {
+			if !phaseEnum.Valid(phase) {
+				return errors.New("Phase " + strconv.Itoa(phase) + " is not a valid value in the phase enum")
+			}
+		}
+	}
+
+	reachable := p.reachable()
+
+	for _, phase := range p.phases {
+		if !reachable[phase] {
+			return errors.New("Phase " + p.phaseName(manager, phase) + " can't be reached from the initial phase")
+		}
+	}
+
+	for _, moveType := range p.moveTypes(manager) {
+		for _, phase := range moveType.LegalPhases() {
+			if !p.HasPhase(phase) {
+				return errors.New("Move " + moveType.Name() + " is legal in phase " + p.phaseName(manager, phase) + ", which isn't declared")
+			}
+		}
+	}
+
+	for _, phase := range p.phases {
+		progression := manager.Delegate().PhaseMoveProgression(phase)
+		if progression == nil {
+			continue
+		}
+		for _, moveName := range progression.MoveNames() {
+			lowerName := strings.ToLower(moveName)
+			if manager.fixUpMovesByName[lowerName] == nil && manager.playerMovesByName[lowerName] == nil {
+				return errors.New("The move progression for phase " + p.phaseName(manager, phase) + " includes " + moveName + ", which isn't installed")
+			}
+		}
+	}
+
+	return nil
+}
+
+//moveTypes returns every move type installed on manager. It reads the
+//manager's fields directly because Valid is called before the manager is
+//initialized, when the public accessors still return nil.
+func (p *PhaseMachine) moveTypes(manager *GameManager) []*MoveType {
+	result := make([]*MoveType, 0, len(manager.fixUpMoves)+len(manager.playerMoves))
+	result = append(result, manager.fixUpMoves...)
+	return append(result, manager.playerMoves...)
+}
+
+func (p *PhaseMachine) phaseName(manager *GameManager, phase int) string {
+	phaseEnum := manager.Delegate().PhaseEnum()
+	if phaseEnum == nil {
+		return strconv.Itoa(phase)
+	}
+	return phaseEnum.String(phase)
+}
+
+//movesForPhase returns the names of the move types that are legal in the
+//given phase. If the phase has a move progression, the moves are in the
+//order they first appear in it; otherwise they are sorted alphabetically.
+//Move types that are legal in every phase are not included.
+func (p *PhaseMachine) movesForPhase(manager *GameManager, phase int) []string {
+	if progression := manager.Delegate().PhaseMoveProgression(phase); progression != nil {
+		return progression.MoveNames()
+	}
+
+	var result []string
+
+	for _, moveType := range p.moveTypes(manager) {
+		for _, legalPhase := range moveType.LegalPhases() {
+			if legalPhase == phase {
+				result = append(result, moveType.Name())
+				break
+			}
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+//DOT returns a Graphviz description of the machine, suitable for rendering
+//with `dot -Tsvg`. Each phase is a node labeled with the moves that are
+//legal in it (numbered, followed by the progression's expression, if the
+//phase has an ordered move progression), and each declared transition is an
+//edge.
+func (p *PhaseMachine) DOT(manager *GameManager) string {
+
+	var lines []string
+
+	lines = append(lines, "digraph "+dotQuote(manager.Delegate().Name())+" {")
+	lines = append(lines, "\tnode [shape=box];")
+	lines = append(lines, "\t"+dotQuote("__start")+" [shape=point];")
+
+	for _, phase := range p.phases {
+		label := p.phaseName(manager, phase) + "\\n"
+
+		progression := manager.Delegate().PhaseMoveProgression(phase)
+
+		for i, moveName := range p.movesForPhase(manager, phase) {
+			if progression != nil {
+				moveName = strconv.Itoa(i+1) + ". " + moveName
+			}
+			label += "\\n" + strings.Replace(moveName, "\"", "\\\"", -1) + "\\l"
+		}
+
+		if progression != nil {
+			label += "\\nOrder: " + strings.Replace(progression.String(), "\"", "\\\"", -1) + "\\l"
+		}
+
+		lines = append(lines, "\t"+dotQuote(p.phaseName(manager, phase))+" [label=\""+label+"\"];")
+	}
+
+	lines = append(lines, "\t"+dotQuote("__start")+" -> "+dotQuote(p.phaseName(manager, p.initial))+";")
+
+	for _, phase := range p.phases {
+		for _, next := range p.transitions[phase] {
+			lines = append(lines, "\t"+dotQuote(p.phaseName(manager, phase))+" -> "+dotQuote(p.phaseName(manager, next))+";")
+		}
+	}
+
+	lines = append(lines, "}")
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func dotQuote(in string) string {
+	return "\"" + strings.Replace(in, "\"", "\\\"", -1) + "\""
+}
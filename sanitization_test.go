@@ -241,7 +241,7 @@ func TestSanitization(t *testing.T) {
 
 		assert.For(t).ThatActual(err).IsNil()
 
-		state, err := game.manager.stateFromRecord(inputBlob)
+		state, err := game.manager.stateFromRecord(inputBlob, nil)
 
 		if !assert.For(t).ThatActual(err).IsNil().Passed() {
 			log.Println(test.inputFileName)
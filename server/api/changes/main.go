@@ -0,0 +1,313 @@
+/*
+
+changes describes the change feed of server.StorageManager: an ordered record
+of every version of every game that's saved, which every process sharing the
+same storage can follow, for example to tell web sockets about new versions
+no matter which process made the move. It's in a separate package, like
+listing, to avoid circular dependencies.
+
+A storage manager records a Change each time SaveGameAndCurrentState saves a
+version. ChangesSince returns the changes after a cursor, so a process can
+catch up on what it missed, and Watch returns a Watcher that receives changes
+as they happen. Storage managers whose changes can be saved by other
+processes implement Watch with a Poller over their own ChangesSince; ones
+that only ever have one process can publish directly to a Hub.
+
+The feed isn't kept forever: storage managers prune all but the most recent
+MaxRetained changes as new ones are recorded.
+
+*/
+package changes
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+//Latest, passed to ChangesSince, returns no changes, but the cursor of the
+//most recent change, to start following the feed from now.
+const Latest int64 = -1
+
+//MaxChanges is the most changes ChangesSince will return at once.
+const MaxChanges = 1000
+
+//MaxRetained is how many of the most recent changes storage managers keep.
+//A cursor older than the oldest change that's left catches up from there.
+const MaxRetained = 10000
+
+//DefaultPollInterval is how often a Poller checks for new changes if it's
+//given an interval of 0.
+const DefaultPollInterval = time.Second
+
+//watcherBufferSize is how many changes a Watcher can fall behind by before
+//changes are dropped.
+const watcherBufferSize = 256
+
+//Change records that a version of a game was saved.
+type Change struct {
+	//Cursor is the change's position in the feed. Cursors increase with
+	//each change, but may skip values.
+	Cursor  int64
+	GameId  string
+	Version int
+}
+
+//Result is a batch of changes, oldest first.
+type Result struct {
+	Changes []*Change
+	//Cursor is the cursor to pass to the next call to ChangesSince.
+	Cursor int64
+}
+
+//Watcher receives the changes to one game, or to every game, until it's
+//closed.
+type Watcher struct {
+	gameId  string
+	changes chan *Change
+	hub     *Hub
+	//missed is whether a change has been dropped since the last call to
+	//Missed. Guarded by hub.lock.
+	missed bool
+}
+
+//Changes returns the channel changes are sent on, which is closed when the
+//Watcher is. If the receiver falls too far behind, changes are dropped
+//rather than holding up the storage manager; see Missed.
+func (w *Watcher) Changes() <-chan *Change {
+	return w.changes
+}
+
+//Missed returns whether any changes have been dropped since the last call to
+//Missed. Receivers that need every change should check it after each change
+//they receive, and catch up with ChangesSince from the cursor of the last
+//change they got.
+func (w *Watcher) Missed() bool {
+
+	w.hub.lock.Lock()
+	defer w.hub.lock.Unlock()
+
+	missed := w.missed
+	w.missed = false
+
+	return missed
+}
+
+//Close stops the Watcher and closes its channel. It's safe to call more than
+//once.
+func (w *Watcher) Close() {
+	w.hub.remove(w)
+}
+
+//Hub sends the changes published to it to the Watchers that are interested
+//in them. The zero value is ready to use.
+type Hub struct {
+	lock     sync.Mutex
+	watchers map[*Watcher]bool
+}
+
+//Watch returns a new Watcher for changes to the game with the given id, or
+//to every game if gameId is "".
+func (h *Hub) Watch(gameId string) *Watcher {
+
+	watcher := &Watcher{
+		gameId:  gameId,
+		changes: make(chan *Change, watcherBufferSize),
+		hub:     h,
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.watchers == nil {
+		h.watchers = make(map[*Watcher]bool)
+	}
+
+	h.watchers[watcher] = true
+
+	return watcher
+}
+
+//Publish sends change to every interested Watcher, without blocking. Watchers
+//whose buffers are full miss it; see Watcher.Missed.
+func (h *Hub) Publish(change *Change) {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for watcher := range h.watchers {
+		//Game ids are case-insensitive in the persistent storage managers.
+		if watcher.gameId != "" && !strings.EqualFold(watcher.gameId, change.GameId) {
+			continue
+		}
+		select {
+		case watcher.changes <- change:
+		default:
+			watcher.missed = true
+		}
+	}
+}
+
+//Close closes every Watcher.
+func (h *Hub) Close() {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for watcher := range h.watchers {
+		close(watcher.changes)
+	}
+
+	h.watchers = nil
+}
+
+func (h *Hub) remove(watcher *Watcher) {
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if !h.watchers[watcher] {
+		return
+	}
+
+	delete(h.watchers, watcher)
+	close(watcher.changes)
+}
+
+//Poller publishes the changes returned by a storage manager's ChangesSince
+//to its Watchers, for storage managers whose changes may be saved by other
+//processes. It only polls while it has been watched, starting from the first
+//call to Watch. Storage managers should call Notify after saving a change,
+//so that changes made in this process are published right away.
+type Poller struct {
+	hub          Hub
+	changesSince func(cursor int64) (*Result, error)
+	interval     time.Duration
+	lock         sync.Mutex
+	started      bool
+	closed       bool
+	done         chan bool
+	stopped      chan bool
+	notify       chan bool
+}
+
+//NewPoller returns a Poller that calls changesSince every interval, or
+//every DefaultPollInterval if interval is 0.
+func NewPoller(changesSince func(cursor int64) (*Result, error), interval time.Duration) *Poller {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &Poller{
+		changesSince: changesSince,
+		interval:     interval,
+		done:         make(chan bool),
+		stopped:      make(chan bool),
+		notify:       make(chan bool, 1),
+	}
+}
+
+//Watch is like Hub.Watch. Every change saved after the first call to Watch
+//returns is published.
+func (p *Poller) Watch(gameId string) *Watcher {
+
+	watcher := p.hub.Watch(gameId)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		watcher.Close()
+		return watcher
+	}
+
+	if !p.started {
+		p.started = true
+		cursor := Latest
+		if result, err := p.changesSince(Latest); err == nil {
+			cursor = result.Cursor
+		}
+		go p.pollLoop(cursor)
+	}
+
+	return watcher
+}
+
+//Notify tells the Poller that a change was just saved, so it polls right
+//away instead of waiting for the next interval. It never blocks.
+func (p *Poller) Notify() {
+	select {
+	case p.notify <- true:
+	default:
+	}
+}
+
+//Close stops polling and closes every Watcher. Once it returns,
+//changesSince won't be called again, so the storage manager can close its
+//database.
+func (p *Poller) Close() {
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	p.closed = true
+	close(p.done)
+
+	if p.started {
+		<-p.stopped
+	}
+
+	p.hub.Close()
+}
+
+func (p *Poller) pollLoop(cursor int64) {
+
+	ticker := time.NewTicker(p.interval)
+
+	defer func() {
+		ticker.Stop()
+		close(p.stopped)
+	}()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			cursor = p.poll(cursor)
+		case <-p.notify:
+			cursor = p.poll(cursor)
+		}
+	}
+}
+
+//poll publishes every change after cursor, returning the new cursor. Errors
+//are retried at the next interval.
+func (p *Poller) poll(cursor int64) int64 {
+
+	for {
+
+		result, err := p.changesSince(cursor)
+
+		if err != nil {
+			return cursor
+		}
+
+		//If the poller couldn't find out where the feed started, it starts
+		//from wherever the feed is now.
+		if cursor != Latest {
+			for _, change := range result.Changes {
+				p.hub.Publish(change)
+			}
+		}
+
+		cursor = result.Cursor
+
+		if len(result.Changes) < MaxChanges {
+			return cursor
+		}
+	}
+}
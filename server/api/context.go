@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/query"
 	"github.com/jkomoros/boardgame/server/api/users"
 	"log"
 	"strconv"
+	"time"
 )
 
 const (
@@ -31,6 +33,25 @@ const (
 	qryOpen                 = "open"
 	qryVisible              = "visible"
 	qryFromVersion          = "from"
+	qryLocale               = "locale"
+)
+
+//The parameters of list/query. Flags are "1" or "0", and times are in unix
+//seconds.
+const (
+	qryQueryFinished      = "finished"
+	qryQueryMine          = "mine"
+	qryQueryUser          = "user"
+	qryQueryAgents        = "agents"
+	qryQueryCreatedAfter  = "created_after"
+	qryQueryCreatedBefore = "created_before"
+	qryQueryActiveAfter   = "active_after"
+	qryQueryActiveBefore  = "active_before"
+	qryQueryOwner         = "owner"
+	qryQuerySort          = "sort"
+	qryQueryAscending     = "asc"
+	qryQueryLimit         = "limit"
+	qryQueryCursor        = "cursor"
 )
 
 const (
@@ -75,25 +96,18 @@ func (s *Server) getRequestGameVersion(c *gin.Context) int {
 }
 
 //getRequestConfig will get the various config
-func (s *Server) getRequestConfig(c *gin.Context, configs map[string][]string) map[string]string {
+func (s *Server) getRequestConfig(c *gin.Context, schema boardgame.ConfigSchema) map[string]string {
 	result := make(map[string]string)
 
-	for key, vals := range configs {
-		if formVal := c.PostForm("config_" + key); formVal != "" {
+	for _, option := range schema {
+		if formVal := c.PostForm("config_" + option.Key); formVal != "" {
 			//We were given a formval. Sanity check it was one of the ones
 			//htat's legal for this game.
-			legal := false
-			for _, val := range vals {
-				if val == formVal {
-					legal = true
-				}
-			}
-
-			if legal {
-				result[key] = formVal
+			if err := option.ValidValue(formVal); err == nil {
+				result[option.Key] = formVal
 			} else {
 				//TODO: what's the idiomatic way to log this?
-				log.Println("Illegal value provided for key " + key + ": " + formVal + " skipping...")
+				log.Println("Illegal value provided for key " + option.Key + ": " + formVal + " skipping...")
 			}
 		}
 	}
@@ -151,6 +165,70 @@ func (s *Server) getRequestVisible(c *gin.Context) bool {
 	return visibleInt > 0
 }
 
+func (s *Server) getRequestLocale(c *gin.Context) string {
+	return c.PostForm(qryLocale)
+}
+
+//effectiveLocale returns the locale to render the given manager's text in for
+//this request: the user's saved locale if they have one, otherwise the best
+//match for the Accept-Language header among the locales the game ships.
+func (s *Server) effectiveLocale(c *gin.Context, manager *boardgame.GameManager) string {
+	if user := s.getUser(c); user != nil && user.Locale != "" {
+		return user.Locale
+	}
+	return manager.Messages().Negotiate(c.GetHeader("Accept-Language"))
+}
+
+func (s *Server) getRequestQueryFlag(c *gin.Context, key string) query.Flag {
+	switch c.Query(key) {
+	case "1":
+		return query.Yes
+	case "0":
+		return query.No
+	}
+	return query.Any
+}
+
+func (s *Server) getRequestQueryTime(c *gin.Context, key string) time.Time {
+	seconds, err := strconv.ParseInt(c.Query(key), 10, 64)
+
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.Unix(seconds, 0)
+}
+
+//getRequestQuery returns the query described by the request's parameters.
+//Its UserId is left for the caller to fill in, since only admins may query
+//for other users' games.
+func (s *Server) getRequestQuery(c *gin.Context) *query.Query {
+
+	result := &query.Query{
+		GameType:      s.getRequestGameName(c),
+		Finished:      s.getRequestQueryFlag(c, qryQueryFinished),
+		HasAgents:     s.getRequestQueryFlag(c, qryQueryAgents),
+		CreatedAfter:  s.getRequestQueryTime(c, qryQueryCreatedAfter),
+		CreatedBefore: s.getRequestQueryTime(c, qryQueryCreatedBefore),
+		ActiveAfter:   s.getRequestQueryTime(c, qryQueryActiveAfter),
+		ActiveBefore:  s.getRequestQueryTime(c, qryQueryActiveBefore),
+		Open:          s.getRequestQueryFlag(c, qryOpen),
+		Visible:       s.getRequestQueryFlag(c, qryVisible),
+		Owner:         c.Query(qryQueryOwner),
+		Ascending:     c.Query(qryQueryAscending) == "1",
+		Cursor:        c.Query(qryQueryCursor),
+	}
+
+	result.NumPlayers, _ = strconv.Atoi(c.Query(qryNumPlayersKey))
+	result.Limit, _ = strconv.Atoi(c.Query(qryQueryLimit))
+
+	if c.Query(qryQuerySort) == "created" {
+		result.Sort = query.Created
+	}
+
+	return result
+}
+
 func (s *Server) getRequestGameId(c *gin.Context) string {
 	return c.Param(qryGameIdKey)
 }
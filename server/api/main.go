@@ -8,8 +8,10 @@ import (
 	"github.com/itsjamie/gin-cors"
 	"github.com/jkomoros/boardgame"
 	"github.com/jkomoros/boardgame/errors"
+	"github.com/jkomoros/boardgame/i18n"
 	"github.com/jkomoros/boardgame/server/api/extendedgame"
 	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/server/api/query"
 	"github.com/jkomoros/boardgame/server/api/users"
 	"github.com/jkomoros/boardgame/server/config"
 	"net/http"
@@ -150,10 +152,18 @@ func (r *Renderer) Error(f *errors.Friendly) {
 
 	r.writeCookie()
 
-	r.c.JSON(http.StatusOK, gin.H{
+	friendlyError := f.FriendlyError()
+
+	if game := r.s.getGame(r.c); game != nil {
+		friendlyError = r.Localizer(game.Manager()).Error(f)
+	}
+
+	r.c.JSON(httpStatus(f.Category()), gin.H{
 		"Status":        "Failure",
 		"Error":         f.Error(),
-		"FriendlyError": f.FriendlyError(),
+		"FriendlyError": friendlyError,
+		"Code":          f.Code(),
+		"Category":      f.Category().String(),
 	})
 
 	fields := logrus.Fields{}
@@ -162,6 +172,8 @@ func (r *Renderer) Error(f *errors.Friendly) {
 		fields[key] = val
 	}
 
+	fields["Code"] = f.Code()
+	fields["Category"] = f.Category().String()
 	fields["Friendly"] = f.FriendlyError()
 	fields["Error"] = f.Error()
 	fields["Secure"] = f.SecureError()
@@ -171,6 +183,31 @@ func (r *Renderer) Error(f *errors.Friendly) {
 	r.rendered = true
 }
 
+//httpStatus returns the HTTP status to send for an error of the given
+//category. Uncategorized errors are sent with 200, as all errors used to be,
+//so clients must still check the Status field.
+func httpStatus(category errors.Category) int {
+	switch category {
+	case errors.IllegalMove:
+		return http.StatusUnprocessableEntity
+	case errors.NotYourTurn:
+		return http.StatusForbidden
+	case errors.NotFound:
+		return http.StatusNotFound
+	case errors.Conflict:
+		return http.StatusConflict
+	case errors.Internal:
+		return http.StatusInternalServerError
+	}
+	return http.StatusOK
+}
+
+//Localizer returns a Localizer for manager in the locale of the user who
+//made this request.
+func (r *Renderer) Localizer(manager *boardgame.GameManager) *boardgame.Localizer {
+	return manager.Localizer(r.s.effectiveLocale(r.c, manager))
+}
+
 func (r *Renderer) Success(keys gin.H) {
 
 	if r.rendered {
@@ -352,7 +389,7 @@ func (s *Server) joinGameHandler(c *gin.Context) {
 	game := s.getGame(c)
 
 	if game == nil {
-		r.Error(errors.NewFriendly("No such game"))
+		r.Error(errors.NewFriendly("No such game").WithCode(boardgame.CodeGameNotFound, errors.NotFound))
 		return
 	}
 
@@ -421,7 +458,7 @@ func (s *Server) newGameHandler(c *gin.Context) {
 		return
 	}
 
-	config := s.getRequestConfig(c, manager.Delegate().Configs())
+	config := s.getRequestConfig(c, manager.Delegate().ConfigSchema())
 
 	if numPlayers == 0 && manager != nil {
 		numPlayers = manager.Delegate().DefaultNumPlayers()
@@ -531,7 +568,10 @@ type gameStorageRecordWithUsers struct {
 }
 
 func (s *Server) listGamesWithUsers(max int, list listing.Type, userId string, gameName string) []*gameStorageRecordWithUsers {
-	games := s.storage.ListGames(max, list, userId, gameName)
+	return s.gamesWithUsers(s.storage.ListGames(max, list, userId, gameName))
+}
+
+func (s *Server) gamesWithUsers(games []*extendedgame.CombinedStorageRecord) []*gameStorageRecordWithUsers {
 
 	result := make([]*gameStorageRecordWithUsers, len(games))
 
@@ -556,6 +596,49 @@ func (s *Server) listGamesWithUsers(max int, list listing.Type, userId string, g
 
 }
 
+func (s *Server) queryGamesHandler(c *gin.Context) {
+
+	r := s.NewRenderer(c)
+
+	user := s.getUser(c)
+
+	adminAllowed := s.getAdminAllowed(c)
+	requestAdmin := s.getRequestAdmin(c)
+
+	isAdmin := s.calcIsAdmin(adminAllowed, requestAdmin)
+
+	q := s.getRequestQuery(c)
+
+	if user != nil && c.Query(qryQueryMine) == "1" {
+		q.UserId = user.Id
+	} else if isAdmin {
+		q.UserId = c.Query(qryQueryUser)
+	}
+
+	s.doQueryGames(r, user, q, isAdmin)
+}
+
+func (s *Server) doQueryGames(r *Renderer, user *users.StorageRecord, q *query.Query, isAdmin bool) {
+
+	//Everyone can see the games they're in, but only admins can see other
+	//games that aren't visible.
+	if !isAdmin && (user == nil || q.UserId != user.Id) {
+		q.Visible = query.Yes
+	}
+
+	result, err := s.storage.QueryGames(q)
+
+	if err != nil {
+		r.Error(errors.NewFriendly("Invalid query").WithError(err.Error()))
+		return
+	}
+
+	r.Success(gin.H{
+		"Games":      s.gamesWithUsers(result.Games),
+		"NextCursor": result.NextCursor,
+	})
+}
+
 func (s *Server) listManagerHandler(c *gin.Context) {
 	r := s.NewRenderer(c)
 	s.doListManager(r)
@@ -571,37 +654,32 @@ func (s *Server) doListManager(r *Renderer) {
 				"DisplayName": agent.DisplayName(),
 			}
 		}
-		var config []interface{}
-
-		configs := manager.Delegate().Configs()
-
-		sortedKeys := make([]string, len(configs))
-
-		i := 0
-
-		for key, _ := range configs {
-			sortedKeys[i] = key
-			i++
-		}
+		localizer := r.Localizer(manager)
 
-		sort.Strings(sortedKeys)
+		var config []interface{}
 
-		for _, key := range sortedKeys {
+		for _, option := range manager.Delegate().ConfigSchema() {
 
-			vals := configs[key]
+			displayName, description := localizer.ConfigOption(option)
 
 			part := make(map[string]interface{})
-			part["Name"] = key
-			displayName, description := manager.Delegate().ConfigKeyDisplay(key)
+			part["Name"] = option.Key
 			part["DisplayName"] = displayName
 			part["Description"] = description
+			part["Type"] = option.Type.String()
+			part["Default"] = option.Default
+			part["HasRange"] = option.HasRange
+			part["Min"] = option.Min
+			part["Max"] = option.Max
+			part["DependsOn"] = option.DependsOn
+			part["DependsOnValue"] = option.DependsOnValue
 
 			var valueInfo []interface{}
 
-			for _, val := range vals {
+			for _, val := range option.Values {
 				valuePart := make(map[string]interface{})
 
-				displayName, description := manager.Delegate().ConfigValueDisplay(key, val)
+				displayName, description := localizer.ConfigValue(option.Key, val)
 
 				valuePart["Value"] = val
 				valuePart["DisplayName"] = displayName
@@ -616,6 +694,19 @@ func (s *Server) doListManager(r *Renderer) {
 			config = append(config, part)
 		}
 
+		var moveTypes []interface{}
+
+		for _, list := range [][]*boardgame.MoveType{manager.PlayerMoveTypes(), manager.FixUpMoveTypes()} {
+			for _, moveType := range list {
+				moveTypes = append(moveTypes, map[string]interface{}{
+					"Name":     moveType.Name(),
+					"HelpText": localizer.HelpText(moveType),
+					"IsFixUp":  moveType.IsFixUp(),
+					"Schema":   moveType.JSONSchema(),
+				})
+			}
+		}
+
 		managers = append(managers, map[string]interface{}{
 			"Name":              name,
 			"DisplayName":       manager.Delegate().DisplayName(),
@@ -625,6 +716,9 @@ func (s *Server) doListManager(r *Renderer) {
 			"MaxNumPlayers":     manager.Delegate().MaxNumPlayers(),
 			"Agents":            agents,
 			"Config":            config,
+			"MoveTypes":         moveTypes,
+			"Locale":            localizer.Locale(),
+			"Locales":           manager.Messages().Locales(),
 		})
 	}
 
@@ -658,7 +752,7 @@ func (s *Server) gameVersionHandler(c *gin.Context) {
 
 func (s *Server) doGameVersion(r *Renderer, game *boardgame.Game, version, fromVersion int, playerIndex boardgame.PlayerIndex, autoCurrentPlayer bool) {
 	if game == nil {
-		r.Error(errors.NewFriendly("Couldn't find game"))
+		r.Error(errors.NewFriendly("Couldn't find game").WithCode(boardgame.CodeGameNotFound, errors.NotFound))
 		return
 	}
 
@@ -711,9 +805,10 @@ func (s *Server) doGameVersion(r *Renderer, game *boardgame.Game, version, fromV
 		bundle := gin.H{
 			"Game":            game.JSONForPlayer(playerIndex, state),
 			"Move":            move,
+			"MoveDescription": s.moveDescription(r, game, move),
 			"Delay":           delay,
 			"ViewingAsPlayer": playerIndex,
-			"Forms":           s.generateForms(game),
+			"Forms":           s.generateForms(r, game),
 		}
 
 		bundles = append(bundles, bundle)
@@ -787,6 +882,38 @@ func (s *Server) doConfigureGame(r *Renderer, user *users.StorageRecord, isAdmin
 
 }
 
+func (s *Server) setLocaleHandler(c *gin.Context) {
+	user := s.getUser(c)
+
+	locale := s.getRequestLocale(c)
+
+	r := s.NewRenderer(c)
+
+	s.doSetLocale(r, user, locale)
+}
+
+//doSetLocale saves the user's preferred locale. An empty locale clears it, so
+//future requests go back to using the Accept-Language header.
+func (s *Server) doSetLocale(r *Renderer, user *users.StorageRecord, locale string) {
+
+	if user == nil {
+		r.Error(errors.New("No user provided"))
+		return
+	}
+
+	user.Locale = i18n.Normalize(locale)
+
+	if err := s.storage.UpdateUser(user); err != nil {
+		r.Error(errors.New("Couldn't update user: " + err.Error()))
+		return
+	}
+
+	r.Success(gin.H{
+		"Locale": user.Locale,
+	})
+
+}
+
 //gameInfo is the first payload when a game is loaded, including immutables
 //like chest, but also the initial game state payload as a convenience.
 func (s *Server) gameInfoHandler(c *gin.Context) {
@@ -883,7 +1010,7 @@ func (s *Server) gamePlayerInfo(game *boardgame.GameStorageRecord, manager *boar
 
 func (s *Server) doGameInfo(r *Renderer, game *boardgame.Game, playerIndex boardgame.PlayerIndex, hasEmptySlots bool, gameInfo *extendedgame.StorageRecord, user *users.StorageRecord) {
 	if game == nil {
-		r.Error(errors.New("Couldn't find game"))
+		r.Error(errors.New("Couldn't find game").WithCode(boardgame.CodeGameNotFound, errors.NotFound))
 		return
 	}
 
@@ -905,7 +1032,8 @@ func (s *Server) doGameInfo(r *Renderer, game *boardgame.Game, playerIndex board
 
 	args := gin.H{
 		"Chest":           s.renderChest(game),
-		"Forms":           s.generateForms(game),
+		"Enums":           r.Localizer(game.Manager()).Enums(game.Chest().Enums()),
+		"Forms":           s.generateForms(r, game),
 		"Game":            game.JSONForPlayer(playerIndex, nil),
 		"Error":           s.lastErrorMessage,
 		"Players":         s.gamePlayerInfo(game.StorageRecord(), game.Manager()),
@@ -934,7 +1062,7 @@ func (s *Server) moveHandler(c *gin.Context) {
 	game := s.getGame(c)
 
 	if game == nil {
-		r.Error(errors.New("Game not found"))
+		r.Error(errors.New("Game not found").WithCode(boardgame.CodeGameNotFound, errors.NotFound))
 		return
 	}
 
@@ -977,15 +1105,31 @@ func (s *Server) doMakeMove(r *Renderer, game *boardgame.Game, proposer boardgam
 	r.Success(nil)
 }
 
-func (s *Server) generateForms(game *boardgame.Game) []*MoveForm {
+//moveDescription returns the description of the move in record, in the
+//locale of the user who made this request.
+func (s *Server) moveDescription(r *Renderer, game *boardgame.Game, record *boardgame.MoveStorageRecord) string {
+
+	move, err := game.Move(record.Version)
+
+	if err != nil {
+		//The record itself is still useful without a description.
+		return ""
+	}
+
+	return r.Localizer(game.Manager()).MoveDescription(move)
+}
+
+func (s *Server) generateForms(r *Renderer, game *boardgame.Game) []*MoveForm {
 
 	var result []*MoveForm
 
+	localizer := r.Localizer(game.Manager())
+
 	for _, moveType := range game.Manager().PlayerMoveTypes() {
 
 		moveItem := &MoveForm{
 			Name:     moveType.Name(),
-			HelpText: moveType.HelpText(),
+			HelpText: localizer.HelpText(moveType),
 			Fields:   formFields(moveType.NewMove(game.CurrentState())),
 		}
 		result = append(result, moveItem)
@@ -1025,6 +1169,11 @@ func (s *Server) renderChest(game *boardgame.Game) map[string][]interface{} {
 		values := make([]interface{}, len(components))
 
 		for i, component := range components {
+			//Components that aren't part of this game are left nil so that
+			//the rest still line up with their DeckIndex.
+			if !game.ComponentSelection().Includes(name, i) {
+				continue
+			}
 			values[i] = struct {
 				Index  int
 				Values interface{}
@@ -1107,6 +1256,7 @@ func (s *Server) Start() {
 
 	{
 		mainGroup.GET("list/game", s.listGamesHandler)
+		mainGroup.GET("list/query", s.queryGamesHandler)
 		mainGroup.GET("list/manager", s.listManagerHandler)
 
 		mainGroup.POST("auth", s.authCookieHandler)
@@ -1114,6 +1264,7 @@ func (s *Server) Start() {
 		protectedMainGroup := mainGroup.Group("")
 		protectedMainGroup.Use(s.requireLoggedIn)
 		protectedMainGroup.POST("new/game", s.newGameHandler)
+		protectedMainGroup.POST("user/locale", s.setLocaleHandler)
 
 		gameAPIGroup := mainGroup.Group("game/:name/:id")
 		gameAPIGroup.Use(s.gameAPISetup)
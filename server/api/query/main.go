@@ -0,0 +1,328 @@
+/*
+
+query describes general queries for games, with filters, sorting and
+cursor-based pagination, for server.StorageManager's QueryGames. It's in a
+separate package, like listing, to avoid circular dependencies.
+
+Results are ordered by the Query's Sort field, with ties broken by game Id,
+and each page's NextCursor records the position of its last game. Paging
+with cursors is stable: games created after the first page was fetched
+won't shift later pages. A game whose sort field changes while paging (for
+example its LastActivity, when a move is made) may be skipped or seen twice.
+
+Storage managers that can't do better than looking at every game can use
+Apply, which implements the whole query in memory.
+
+*/
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/jkomoros/boardgame/server/api/extendedgame"
+	"sort"
+	"time"
+)
+
+//DefaultLimit is the number of games returned if a Query's Limit is 0.
+const DefaultLimit = 100
+
+//MaxLimit is the most games that will be returned in one page.
+const MaxLimit = 1000
+
+//Flag filters on a boolean property of a game.
+type Flag int
+
+const (
+	//Any doesn't filter on the property at all.
+	Any Flag = iota
+	//Yes only matches games where the property is true.
+	Yes
+	//No only matches games where the property is false.
+	No
+)
+
+//Matches returns true if a game whose property is val passes the filter.
+func (f Flag) Matches(val bool) bool {
+	switch f {
+	case Yes:
+		return val
+	case No:
+		return !val
+	}
+	return true
+}
+
+//SortField is the field that games are ordered by.
+type SortField int
+
+const (
+	//LastActivity orders games by the extended game's LastActivity.
+	LastActivity SortField = iota
+	//Created orders games by when they were created.
+	Created
+)
+
+//Query describes which games to return, and in what order. The zero value
+//matches every game, most recently active first.
+type Query struct {
+	//GameType, if not "", only matches games of that type.
+	GameType string
+	Finished Flag
+	//UserId, if not "", only matches games that user is a player in.
+	UserId string
+	//HasAgents filters on whether any player is an agent.
+	HasAgents Flag
+	//CreatedAfter and CreatedBefore, if not zero, only match games created
+	//at or after, or before, those times.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	//ActiveAfter and ActiveBefore are like CreatedAfter and CreatedBefore,
+	//but for LastActivity.
+	ActiveAfter  time.Time
+	ActiveBefore time.Time
+	//NumPlayers, if not 0, only matches games with that many players.
+	NumPlayers int
+	Open       Flag
+	Visible    Flag
+	//Owner, if not "", only matches games owned by that user.
+	Owner string
+	Sort  SortField
+	//Ascending returns the oldest games first instead of the newest.
+	Ascending bool
+	//Limit is the most games to return. 0 means DefaultLimit, and it's
+	//capped at MaxLimit.
+	Limit int
+	//Cursor is the NextCursor of the previous page, or "" for the first
+	//page.
+	Cursor string
+}
+
+//Result is one page of games matching a Query.
+type Result struct {
+	Games []*extendedgame.CombinedStorageRecord
+	//NextCursor, if not "", can be set as the Query's Cursor to fetch the
+	//next page.
+	NextCursor string
+}
+
+//Position is the place in a Query's order that a cursor represents. The next
+//page starts just after it.
+type Position struct {
+	Sort SortField
+	//Key is the value of the Sort field, in nanoseconds.
+	Key int64
+	Id  string
+}
+
+//PageSize returns the number of games that should be returned for the
+//query, taking into account DefaultLimit and MaxLimit.
+func (q *Query) PageSize() int {
+	if q.Limit < 1 {
+		return DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		return MaxLimit
+	}
+	return q.Limit
+}
+
+//Start decodes the query's Cursor, returning nil if the query is for the
+//first page. It returns an error if the cursor is malformed or is for a
+//different Sort.
+func (q *Query) Start() (*Position, error) {
+
+	if q.Cursor == "" {
+		return nil, nil
+	}
+
+	blob, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+
+	if err != nil {
+		return nil, errors.New("Invalid cursor: " + err.Error())
+	}
+
+	var pos Position
+
+	if err := json.Unmarshal(blob, &pos); err != nil {
+		return nil, errors.New("Invalid cursor: " + err.Error())
+	}
+
+	if pos.Sort != q.Sort || pos.Id == "" {
+		return nil, errors.New("Cursor is not for this query's sort")
+	}
+
+	return &pos, nil
+}
+
+//SortKey returns the value of the query's Sort field for game.
+func (q *Query) SortKey(game *extendedgame.CombinedStorageRecord) int64 {
+	if q.Sort == Created {
+		return game.Created.UnixNano()
+	}
+	return game.LastActivity
+}
+
+//CursorFor returns the cursor that fetches the games after game.
+func (q *Query) CursorFor(game *extendedgame.CombinedStorageRecord) string {
+	blob, _ := json.Marshal(&Position{
+		Sort: q.Sort,
+		Key:  q.SortKey(game),
+		Id:   game.Id,
+	})
+	return base64.RawURLEncoding.EncodeToString(blob)
+}
+
+//Before returns true if a game with the given sort key and id comes strictly
+//before pos in the query's order.
+func (q *Query) Before(key int64, id string, pos *Position) bool {
+	if key == pos.Key {
+		if q.Ascending {
+			return id < pos.Id
+		}
+		return id > pos.Id
+	}
+	if q.Ascending {
+		return key < pos.Key
+	}
+	return key > pos.Key
+}
+
+//After returns true if game comes strictly after pos in the query's order.
+//Every game comes after a nil pos.
+func (q *Query) After(pos *Position, game *extendedgame.CombinedStorageRecord) bool {
+	if pos == nil {
+		return true
+	}
+	key := q.SortKey(game)
+	if key == pos.Key && game.Id == pos.Id {
+		return false
+	}
+	return !q.Before(key, game.Id, pos)
+}
+
+//Less returns true if a comes before b in the query's order.
+func (q *Query) Less(a, b *extendedgame.CombinedStorageRecord) bool {
+	return q.Before(q.SortKey(a), a.Id, &Position{Key: q.SortKey(b), Id: b.Id})
+}
+
+//Matches returns true if game passes all of the query's filters. userIds are
+//the users playing the game, as returned by UserIdsForGame, and are only
+//consulted if the query has a UserId.
+func (q *Query) Matches(game *extendedgame.CombinedStorageRecord, userIds []string) bool {
+
+	if q.GameType != "" && game.Name != q.GameType {
+		return false
+	}
+
+	if !q.Finished.Matches(game.Finished) {
+		return false
+	}
+
+	if !q.HasAgents.Matches(hasAgents(game.Agents)) {
+		return false
+	}
+
+	if !q.Open.Matches(game.Open) || !q.Visible.Matches(game.Visible) {
+		return false
+	}
+
+	if q.NumPlayers != 0 && game.NumPlayers != q.NumPlayers {
+		return false
+	}
+
+	if q.Owner != "" && game.Owner != q.Owner {
+		return false
+	}
+
+	if !inRange(game.Created.UnixNano(), q.CreatedAfter, q.CreatedBefore) {
+		return false
+	}
+
+	if !inRange(game.LastActivity, q.ActiveAfter, q.ActiveBefore) {
+		return false
+	}
+
+	if q.UserId != "" {
+		for _, userId := range userIds {
+			if userId == q.UserId {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+func hasAgents(agents []string) bool {
+	for _, agent := range agents {
+		if agent != "" {
+			return true
+		}
+	}
+	return false
+}
+
+//inRange returns true if the time in nanoseconds is at or after after and
+//before before, ignoring either bound if it's zero.
+func inRange(nanos int64, after, before time.Time) bool {
+	if !after.IsZero() && nanos < after.UnixNano() {
+		return false
+	}
+	if !before.IsZero() && nanos >= before.UnixNano() {
+		return false
+	}
+	return true
+}
+
+//Page returns the Result for games, which must already be filtered and in
+//the query's order, starting after the query's cursor. If there are more
+//games than fit in the page, NextCursor is set.
+func (q *Query) Page(games []*extendedgame.CombinedStorageRecord) (*Result, error) {
+
+	start, err := q.Start()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for len(games) > 0 && !q.After(start, games[0]) {
+		games = games[1:]
+	}
+
+	result := &Result{
+		Games: games,
+	}
+
+	if len(games) > q.PageSize() {
+		result.Games = games[:q.PageSize()]
+		result.NextCursor = q.CursorFor(result.Games[len(result.Games)-1])
+	}
+
+	return result, nil
+}
+
+//Apply runs q against games, which may be in any order, looking up each
+//game's players with userIdsForGame if the query has a UserId.
+func Apply(q *Query, games []*extendedgame.CombinedStorageRecord, userIdsForGame func(gameId string) []string) (*Result, error) {
+
+	var matched []*extendedgame.CombinedStorageRecord
+
+	for _, game := range games {
+		var userIds []string
+		if q.UserId != "" {
+			userIds = userIdsForGame(game.Id)
+		}
+		if q.Matches(game, userIds) {
+			matched = append(matched, game)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return q.Less(matched[i], matched[j])
+	})
+
+	return q.Page(matched)
+}
@@ -11,6 +11,10 @@ type StorageRecord struct {
 	DisplayName string
 	PhotoUrl    string
 	Email       string
+	//Locale is the user's preferred locale for game text, e.g. "fr-CA". If
+	//it is empty the locale is negotiated from each request's
+	//Accept-Language header instead.
+	Locale string
 }
 
 func (s *StorageRecord) EffectiveDisplayName() string {
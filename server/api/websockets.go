@@ -6,6 +6,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/jkomoros/boardgame"
 	"github.com/jkomoros/boardgame/errors"
+	"github.com/jkomoros/boardgame/server/api/changes"
 	"net/http"
 	"strconv"
 	"time"
@@ -16,6 +17,13 @@ const (
 	writeWait      = 10 * time.Second
 	pongWait       = 60 * time.Second
 	pingPeriod     = (pongWait * 9) / 10
+	//runWait is how long the notifier waits after a change for more, so that
+	//a move and the fix up moves it triggers, which are saved one right after
+	//another, notify sockets once.
+	runWait = 100 * time.Millisecond
+	//maxRunWait is the longest the notifier holds on to changes while more
+	//keep coming.
+	maxRunWait = time.Second
 )
 
 type gameVersionChanged struct {
@@ -29,7 +37,11 @@ type versionNotifier struct {
 	unregister    chan *socket
 	notifyVersion chan gameVersionChanged
 	doneChan      chan bool
-	server        *Server
+	watcher       *changes.Watcher
+	//cursor is where the change feed was when watcher was created, to catch
+	//up from if it misses changes before it gets any.
+	cursor int64
+	server *Server
 }
 
 type socket struct {
@@ -57,7 +69,7 @@ func (s *Server) socketHandler(c *gin.Context) {
 	renderer := s.NewRenderer(c)
 
 	if game == nil {
-		renderer.Error(errors.New("No such game"))
+		renderer.Error(errors.New("No such game").WithCode(boardgame.CodeGameNotFound, errors.NotFound))
 		return
 	}
 
@@ -159,26 +171,176 @@ func (s *socket) SendMessage(message gameVersionChanged) {
 }
 
 func newVersionNotifier(s *Server) *versionNotifier {
+
+	cursor := changes.Latest
+
+	if latest, err := s.storage.ChangesSince(changes.Latest); err == nil {
+		cursor = latest.Cursor
+	}
+
 	result := &versionNotifier{
 		sockets:       make(map[string]map[*socket]bool),
 		register:      make(chan *socket),
 		unregister:    make(chan *socket),
 		notifyVersion: make(chan gameVersionChanged),
 		doneChan:      make(chan bool),
-		server:        s,
+		//Watch every game, not just the ones moves are made on in this
+		//process, so sockets hear about moves made by other servers sharing
+		//the same storage.
+		watcher: s.storage.Watch(""),
+		cursor:  cursor,
+		server:  s,
 	}
 	go result.workLoop()
+	go result.watchLoop()
 	return result
 }
 
-func (v *versionNotifier) gameChanged(game *boardgame.GameStorageRecord) {
-	v.notifyVersion <- gameVersionChanged{
-		Id:      game.Id,
-		Version: game.Version,
+//watchLoop passes along the changes from the storage's change feed to
+//workLoop, until the watcher is closed. Changes that arrive together are
+//gathered, so that sockets are told about the latest version of each game
+//once per run of moves, instead of once per version. If the watcher missed
+//changes, or a game's versions skip, it catches up with ChangesSince.
+func (v *versionNotifier) watchLoop() {
+
+	//latest is the last version sockets were told about, for each game.
+	latest := make(map[string]int)
+
+	cursor := v.cursor
+
+	//behind is whether changes were missed and haven't been caught up on
+	//yet.
+	behind := false
+
+	for {
+
+		change, ok := <-v.watcher.Changes()
+
+		if !ok {
+			return
+		}
+
+		pending := make(map[string]int)
+
+		add := func(change *changes.Change) {
+			if change.Cursor > cursor {
+				cursor = change.Cursor
+			}
+			last, seen := pending[change.GameId]
+			if !seen {
+				last, seen = latest[change.GameId]
+			}
+			if seen && change.Version > last+1 {
+				behind = true
+			}
+			if !seen || change.Version > last {
+				pending[change.GameId] = change.Version
+			}
+		}
+
+		add(change)
+
+		closed := v.gatherRun(add)
+
+		if v.watcher.Missed() {
+			behind = true
+		}
+
+		if behind {
+			var err error
+			if cursor, err = v.catchUp(cursor, add); err != nil {
+				v.server.logger.Errorln("Couldn't catch up on missed changes: " + err.Error())
+			} else {
+				behind = false
+			}
+		}
+
+		//Once the feed has pruned a game's changes, there's nothing to
+		//catch up on for it anyway.
+		if len(latest) > changes.MaxRetained {
+			latest = make(map[string]int)
+		}
+
+		for id, version := range pending {
+			if last, ok := latest[id]; ok && version <= last {
+				continue
+			}
+			latest[id] = version
+			select {
+			case v.notifyVersion <- gameVersionChanged{
+				Id:      id,
+				Version: version,
+			}:
+			case <-v.doneChan:
+				return
+			}
+		}
+
+		if closed {
+			return
+		}
+	}
+}
+
+//gatherRun passes changes to add until none have arrived for runWait, or
+//for at most maxRunWait. It returns true if the watcher was closed or the
+//notifier is done.
+func (v *versionNotifier) gatherRun(add func(change *changes.Change)) bool {
+
+	quiet := time.NewTimer(runWait)
+	defer quiet.Stop()
+
+	timeout := time.NewTimer(maxRunWait)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case change, ok := <-v.watcher.Changes():
+			if !ok {
+				return true
+			}
+			add(change)
+			if !quiet.Stop() {
+				<-quiet.C
+			}
+			quiet.Reset(runWait)
+		case <-quiet.C:
+			return false
+		case <-timeout.C:
+			return false
+		case <-v.doneChan:
+			return true
+		}
+	}
+}
+
+//catchUp passes every change after cursor to add, returning the new cursor.
+func (v *versionNotifier) catchUp(cursor int64, add func(change *changes.Change)) (int64, error) {
+
+	for {
+
+		result, err := v.server.storage.ChangesSince(cursor)
+
+		if err != nil {
+			return cursor, err
+		}
+
+		for _, change := range result.Changes {
+			add(change)
+		}
+
+		if result.Cursor > cursor {
+			cursor = result.Cursor
+		}
+
+		if len(result.Changes) < changes.MaxChanges {
+			return cursor, nil
+		}
 	}
 }
 
 func (v *versionNotifier) done() {
+	v.watcher.Close()
 	close(v.doneChan)
 }
 
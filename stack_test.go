@@ -143,7 +143,7 @@ func TestChangedSizeStackRoundTrip(t *testing.T) {
 
 	rec := cState.StorageRecord()
 
-	refriedState, err := game.Manager().stateFromRecord(rec)
+	refriedState, err := game.Manager().stateFromRecord(rec, nil)
 
 	assert.For(t).ThatActual(err).IsNil()
 
@@ -168,6 +168,74 @@ func (p PlayerIndex) Previous(state State) PlayerIndex {
 	return p
 }
 
+//Eliminated returns true if the PlayerIndex denotes one of the normal players
+//and that player's PlayerState implements PlayerEliminator and reports that
+//they have been eliminated.
+func (p PlayerIndex) Eliminated(state State) bool {
+	if p < 0 || !p.Valid(state) {
+		return false
+	}
+	eliminator, ok := state.PlayerStates()[p].(PlayerEliminator)
+	if !ok {
+		return false
+	}
+	return eliminator.PlayerEliminated()
+}
+
+//NextActive is like Next, but skips over players who have been Eliminated.
+//If every other player has been eliminated, returns p.
+func (p PlayerIndex) NextActive(state State) PlayerIndex {
+	result := p
+	for i := 0; i < len(state.PlayerStates()); i++ {
+		result = result.Next(state)
+		if !result.Eliminated(state) {
+			return result
+		}
+	}
+	return p
+}
+
+//PreviousActive is like Previous, but skips over players who have been
+//Eliminated. If every other player has been eliminated, returns p.
+func (p PlayerIndex) PreviousActive(state State) PlayerIndex {
+	result := p
+	for i := 0; i < len(state.PlayerStates()); i++ {
+		result = result.Previous(state)
+		if !result.Eliminated(state) {
+			return result
+		}
+	}
+	return p
+}
+
+//ActivePlayers returns the PlayerIndexes of all of the players in state who
+//have not been Eliminated, in order.
+func ActivePlayers(state State) []PlayerIndex {
+	var result []PlayerIndex
+	for i := range state.PlayerStates() {
+		if !PlayerIndex(i).Eliminated(state) {
+			result = append(result, PlayerIndex(i))
+		}
+	}
+	return result
+}
+
+//LastPlayerStanding is a helper for CheckGameFinished in games where players
+//are eliminated. It returns finished true once at most one player in a
+//multi-player game has not been eliminated, with the remaining player (if
+//any) as the winner. DefaultGameDelegate's CheckGameFinished calls it
+//automatically.
+func LastPlayerStanding(state State) (finished bool, winners []PlayerIndex) {
+	if len(state.PlayerStates()) < 2 {
+		return false, nil
+	}
+	active := ActivePlayers(state)
+	if len(active) > 1 {
+		return false, nil
+	}
+	return true, active
+}
+
 //Equivalent checks whether the two playerIndexes are equivalent. For most
 //indexes it checks if both are the same. ObserverPlayerIndex returns false
 //when compared to any other PlayerIndex. AdminPlayerIndex returns true when
@@ -672,6 +740,34 @@ type PlayerState interface {
 	SubState
 }
 
+//PlayerEliminator is an optional interface that PlayerStates may implement to
+//signal that the player has been eliminated from (or has otherwise finished)
+//the game, even though the game itself continues. Eliminated players are
+//skipped by PlayerIndex.NextActive, DefaultGameDelegate.CurrentPlayerIndex,
+//the round robin and FinishTurn moves in the moves package, and agents, and
+//can't make CurrentPlayer moves. Generally you simply embed
+//EliminatablePlayerState to implement it.
+type PlayerEliminator interface {
+	PlayerEliminated() bool
+}
+
+//EliminatablePlayerState is designed to be embedded anonymously in your
+//PlayerState, alongside BaseSubState, to implement PlayerEliminator with a
+//standard Eliminated property.
+type EliminatablePlayerState struct {
+	Eliminated bool
+}
+
+//PlayerEliminated returns the Eliminated property.
+func (e *EliminatablePlayerState) PlayerEliminated() bool {
+	return e.Eliminated
+}
+
+//SetPlayerEliminated sets the Eliminated property.
+func (e *EliminatablePlayerState) SetPlayerEliminated(eliminated bool) {
+	e.Eliminated = eliminated
+}
+
 //A MutablePlayerState is a PlayerState that is allowed to be mutated.
 type MutablePlayerState interface {
 	PlayerIndexer
@@ -53,6 +53,70 @@ func TestPlayerIndexNextPrevious(t *testing.T) {
 	}
 }
 
+type testEliminatablePlayerState struct {
+	*testPlayerState
+	EliminatablePlayerState
+}
+
+func TestPlayerIndexActive(t *testing.T) {
+
+	playerStates := make([]ConfigurablePlayerState, 4)
+
+	for i := range playerStates {
+		playerStates[i] = &testEliminatablePlayerState{
+			testPlayerState: &testPlayerState{playerIndex: PlayerIndex(i)},
+		}
+	}
+
+	gameState := &testGameState{
+		CurrentPlayer: 1,
+	}
+
+	state := &state{
+		gameState:    gameState,
+		playerStates: playerStates,
+	}
+
+	delegate := &DefaultGameDelegate{}
+
+	assert.For(t).ThatActual(delegate.CurrentPlayerIndex(state)).Equals(PlayerIndex(1))
+
+	eliminate := func(player PlayerIndex) {
+		state.playerStates[player].(*testEliminatablePlayerState).SetPlayerEliminated(true)
+	}
+
+	eliminate(1)
+
+	assert.For(t).ThatActual(PlayerIndex(1).Eliminated(state)).IsTrue()
+	assert.For(t).ThatActual(PlayerIndex(0).Eliminated(state)).IsFalse()
+	assert.For(t).ThatActual(ObserverPlayerIndex.Eliminated(state)).IsFalse()
+
+	assert.For(t).ThatActual(PlayerIndex(0).NextActive(state)).Equals(PlayerIndex(2))
+	assert.For(t).ThatActual(PlayerIndex(2).PreviousActive(state)).Equals(PlayerIndex(0))
+	assert.For(t).ThatActual(ActivePlayers(state)).Equals([]PlayerIndex{0, 2, 3})
+
+	//The default CurrentPlayerIndex skips over eliminated players.
+	assert.For(t).ThatActual(delegate.CurrentPlayerIndex(state)).Equals(PlayerIndex(2))
+
+	finished, _ := LastPlayerStanding(state)
+	assert.For(t).ThatActual(finished).IsFalse()
+
+	eliminate(0)
+	eliminate(3)
+
+	assert.For(t).ThatActual(PlayerIndex(2).NextActive(state)).Equals(PlayerIndex(2))
+	assert.For(t).ThatActual(PlayerIndex(2).PreviousActive(state)).Equals(PlayerIndex(2))
+
+	finished, winners := LastPlayerStanding(state)
+	assert.For(t).ThatActual(finished).IsTrue()
+	assert.For(t).ThatActual(winners).Equals([]PlayerIndex{2})
+
+	eliminate(2)
+
+	assert.For(t).ThatActual(delegate.CurrentPlayerIndex(state)).Equals(ObserverPlayerIndex)
+
+}
+
 func TestPlayerIndexValid(t *testing.T) {
 
 	gameTwoPlayers := testGame(t)
@@ -257,7 +321,7 @@ func TestState(t *testing.T) {
 		t.Error("Unexpected error", err)
 	}
 
-	state, err := game.Manager().stateFromRecord(record)
+	state, err := game.Manager().stateFromRecord(record, nil)
 	state.game = game
 
 	if err != nil {
@@ -337,7 +401,7 @@ func TestStateSerialization(t *testing.T) {
 		t.Fatal("Couldn't serialize state:", err)
 	}
 
-	reconstitutedState, err := game.Manager().stateFromRecord(blob)
+	reconstitutedState, err := game.Manager().stateFromRecord(blob, nil)
 
 	if err != nil {
 		t.Error("StateFromBlob returned unexpected err", err)
@@ -36,13 +36,21 @@ type GameStorageRecord struct {
 	SecretSalt string `json:",omitempty"`
 	Version    int
 	Winners    []PlayerIndex
-	Finished   bool
-	Created    time.Time
+	//Result is the full outcome of the game, set once it is finished.
+	Result   *GameResult `json:",omitempty"`
+	Finished bool
+	Created  time.Time
 	//NumPlayers is the reported number of players when it was created.
 	//Primarily for convenience to storage layer so they know how many players
 	//are in the game.
 	NumPlayers int
 	Agents     []string
+	//Components is the subset of the chest that takes part in this game. nil
+	//means the entire chest. It's not omitted when empty, so that an empty
+	//selection is still saved as one.
+	Components ComponentSelection
+	//Match is the id of the Match this game is part of, or "".
+	Match string `json:",omitempty"`
 }
 
 //StorageManager is an interface that anything can implement to handle the
@@ -81,4 +89,12 @@ type StorageManager interface {
 	//anything here; it's primarily useful for signaling that a run of moves
 	//has been applied, e.g. in the server.
 	PlayerMoveApplied(game *GameStorageRecord) error
+
+	//Match returns the match with the given id, or ErrMatchNotFound if there
+	//isn't one.
+	Match(id string) (*MatchStorageRecord, error)
+
+	//SaveMatch stores the match, replacing any previously stored record with
+	//the same Id.
+	SaveMatch(match *MatchStorageRecord) error
 }
@@ -0,0 +1,722 @@
+/*
+
+archive moves whole stores between storage managers, for example from bolt
+to mysql, via a portable archive format.
+
+An archive is newline-delimited JSON. The first line is a header, and every
+line after it is one Record: a user, a cookie, a game or a match. A game's
+record includes everything stored for it: its game and extended game
+records, its players, every state and move, and its agent states. Records
+are written in a stable order (users, then cookies, then games, then
+matches, each sorted by id), so exporting the same data from two different
+storage managers produces identical archives.
+
+	src := bolt.NewStorageManager(".database")
+	f, _ := os.Create("games.archive")
+	exported, err := archive.Export(src, f)
+
+	dst := mysql.NewStorageManager(false)
+	dst.Connect(dsn)
+	imported, err := archive.Import(dst, archiveReader)
+	err = archive.Verify(dst, imported)
+
+Export and Import both return a Summary of the records they processed,
+including a hash of their contents. Verify re-exports the destination and
+checks that it matches. SummarizeArchive reads an archive without importing
+it, so a store can also be verified against an archive file later.
+
+The boardgame-archive command, in cmd/boardgame-archive at the root of this
+repo, wraps all of this for the storage managers configured in
+config.SECRET.json.
+
+Storage managers can't list all of their users or cookies via the server's
+StorageManager interface, so storage managers that implement UserLister and
+CookieLister have all of their users and cookies exported. For others, only
+the users who are players in a game are exported, and no cookies are.
+Cookies whose user no longer exists are never exported.
+
+Games compacted by storage/retention are missing some states, but keep all
+of their moves, so those versions are exported with only their move.
+Storage managers can only save a move along with a state, so Import saves
+each of them with the state before it and then deletes that copy again,
+which requires the destination to implement StateDeleter. Import into a
+storage manager that is empty; records that already exist may conflict.
+
+*/
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/extendedgame"
+	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/server/api/users"
+	"github.com/jkomoros/boardgame/storage"
+	"hash"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//FormatName is the Format of every archive's Header.
+const FormatName = "boardgame-archive"
+
+//FormatVersion is the version of the archive format that Export writes.
+//Import rejects archives from newer versions. Version 2 added versions with
+//a move but no state, for games compacted by storage/retention.
+const FormatVersion = 2
+
+//Storage is the interface of the storage managers that can be exported and
+//imported.
+type Storage interface {
+	storage.Manager
+}
+
+//UserLister is implemented by storage managers that can list every user
+//they store.
+type UserLister interface {
+	ListUsers() ([]*users.StorageRecord, error)
+}
+
+//CookieLister is implemented by storage managers that can list every
+//cookie they store. The result maps each cookie to its user's id.
+type CookieLister interface {
+	ListCookies() (map[string]string, error)
+}
+
+//StateDeleter is implemented by storage managers that can delete states.
+//Importing games compacted by storage/retention requires it.
+type StateDeleter interface {
+	DeleteStates(gameId string, versions []int) error
+}
+
+//Header is the first line of every archive.
+type Header struct {
+	Format  string
+	Version int
+	//Source is the Name of the storage manager that was exported.
+	Source  string
+	Created time.Time
+}
+
+//Record is one line of an archive after the header. Exactly one of its
+//fields is set.
+type Record struct {
+	User   *users.StorageRecord          `json:",omitempty"`
+	Cookie *Cookie                       `json:",omitempty"`
+	Game   *Game                         `json:",omitempty"`
+	Match  *boardgame.MatchStorageRecord `json:",omitempty"`
+}
+
+//Cookie connects a cookie to a user.
+type Cookie struct {
+	Cookie string
+	UserId string
+}
+
+//Game is everything stored for one game.
+type Game struct {
+	Game     *boardgame.GameStorageRecord
+	Extended *extendedgame.StorageRecord
+	//Players are the user ids in each player slot, as returned by
+	//UserIdsForGame.
+	Players     []string
+	Versions    []*Version
+	AgentStates []*AgentState `json:",omitempty"`
+}
+
+//Version is the state, and the move that led to it, for one version of a
+//game. State is omitted for versions whose state was compacted away by
+//storage/retention.
+type Version struct {
+	Version int
+	State   json.RawMessage              `json:",omitempty"`
+	Move    *boardgame.MoveStorageRecord `json:",omitempty"`
+}
+
+//AgentState is the state an agent saved for one player of a game.
+type AgentState struct {
+	Player boardgame.PlayerIndex
+	State  []byte
+}
+
+//Summary counts the records in an archive, and has a hash of all of them.
+//Two stores with the same contents have the same Summary.
+type Summary struct {
+	Users       int
+	Cookies     int
+	Games       int
+	States      int
+	Moves       int
+	AgentStates int
+	Matches     int
+	Hash        string
+
+	hash hash.Hash
+}
+
+func newSummary() *Summary {
+	return &Summary{
+		hash: sha256.New(),
+	}
+}
+
+//add counts record, whose encoding is blob, in the summary.
+func (s *Summary) add(record *Record, blob []byte) {
+	switch {
+	case record.User != nil:
+		s.Users++
+	case record.Cookie != nil:
+		s.Cookies++
+	case record.Game != nil:
+		s.Games++
+		for _, version := range record.Game.Versions {
+			if len(version.State) > 0 {
+				s.States++
+			}
+			if version.Move != nil {
+				s.Moves++
+			}
+		}
+		s.AgentStates += len(record.Game.AgentStates)
+	case record.Match != nil:
+		s.Matches++
+	}
+	s.hash.Write(blob)
+	s.hash.Write([]byte("\n"))
+}
+
+func (s *Summary) finish() {
+	s.Hash = hex.EncodeToString(s.hash.Sum(nil))
+}
+
+//Compare returns a description of each way that other differs from s, or
+//nil if they're the same.
+func (s *Summary) Compare(other *Summary) []string {
+
+	var result []string
+
+	counts := []struct {
+		name     string
+		expected int
+		actual   int
+	}{
+		{"users", s.Users, other.Users},
+		{"cookies", s.Cookies, other.Cookies},
+		{"games", s.Games, other.Games},
+		{"states", s.States, other.States},
+		{"moves", s.Moves, other.Moves},
+		{"agent states", s.AgentStates, other.AgentStates},
+		{"matches", s.Matches, other.Matches},
+	}
+
+	for _, count := range counts {
+		if count.expected != count.actual {
+			result = append(result, "Expected "+strconv.Itoa(count.expected)+" "+count.name+", got "+strconv.Itoa(count.actual))
+		}
+	}
+
+	if s.Hash != other.Hash {
+		result = append(result, "Expected hash "+s.Hash+", got "+other.Hash)
+	}
+
+	return result
+}
+
+//String returns a human readable description of the counts in the summary.
+func (s *Summary) String() string {
+	return strconv.Itoa(s.Users) + " users, " +
+		strconv.Itoa(s.Cookies) + " cookies, " +
+		strconv.Itoa(s.Games) + " games (" +
+		strconv.Itoa(s.States) + " states, " +
+		strconv.Itoa(s.Moves) + " moves, " +
+		strconv.Itoa(s.AgentStates) + " agent states), " +
+		strconv.Itoa(s.Matches) + " matches. Hash " + s.Hash
+}
+
+//normalizeRecord puts record in canonical form, so that the same data read
+//from different storage managers encodes identically.
+func normalizeRecord(record *Record) error {
+
+	if game := record.Game; game != nil {
+		game.Game.Created = game.Game.Created.UTC()
+		for _, version := range game.Versions {
+			if len(version.State) > 0 {
+				var compacted bytes.Buffer
+				if err := json.Compact(&compacted, version.State); err != nil {
+					return errors.New("State " + strconv.Itoa(version.Version) + " of game " + game.Game.Id + " isn't valid JSON: " + err.Error())
+				}
+				version.State = compacted.Bytes()
+			}
+			if version.Move != nil {
+				version.Move.Timestamp = version.Move.Timestamp.UTC()
+				//Move blobs are JSON too, but some storage managers
+				//reformat them.
+				var compactedMove bytes.Buffer
+				if err := json.Compact(&compactedMove, version.Move.Blob); err == nil {
+					version.Move.Blob = compactedMove.Bytes()
+				}
+			}
+		}
+	}
+
+	if record.Match != nil {
+		record.Match.Created = record.Match.Created.UTC()
+	}
+
+	return nil
+}
+
+//writer writes records to an archive, and summarizes them.
+type writer struct {
+	w       io.Writer
+	summary *Summary
+}
+
+func (w *writer) write(record *Record) error {
+
+	if err := normalizeRecord(record); err != nil {
+		return err
+	}
+
+	blob, err := json.Marshal(record)
+
+	if err != nil {
+		return errors.New("Couldn't encode record: " + err.Error())
+	}
+
+	w.summary.add(record, blob)
+
+	if _, err := w.w.Write(append(blob, '\n')); err != nil {
+		return errors.New("Couldn't write record: " + err.Error())
+	}
+
+	return nil
+}
+
+//Export writes everything in storage to w as an archive, and returns a
+//Summary of what it wrote.
+func Export(storage Storage, w io.Writer) (*Summary, error) {
+
+	headerBlob, err := json.Marshal(&Header{
+		Format:  FormatName,
+		Version: FormatVersion,
+		Source:  storage.Name(),
+		Created: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return nil, errors.New("Couldn't encode header: " + err.Error())
+	}
+
+	if _, err := w.Write(append(headerBlob, '\n')); err != nil {
+		return nil, errors.New("Couldn't write header: " + err.Error())
+	}
+
+	out := &writer{w, newSummary()}
+
+	gameIds := make([]string, 0)
+
+	for _, game := range storage.ListGames(math.MaxInt32, listing.All, "", "") {
+		gameIds = append(gameIds, game.Id)
+	}
+
+	sort.Strings(gameIds)
+
+	allUsers, err := exportUsers(storage, gameIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range allUsers {
+		if err := out.write(&Record{User: user}); err != nil {
+			return nil, err
+		}
+	}
+
+	if lister, ok := storage.(CookieLister); ok {
+		cookies, err := lister.ListCookies()
+		if err != nil {
+			return nil, errors.New("Couldn't list cookies: " + err.Error())
+		}
+		sortedCookies := make([]string, 0, len(cookies))
+		for cookie := range cookies {
+			sortedCookies = append(sortedCookies, cookie)
+		}
+		sort.Strings(sortedCookies)
+		for _, cookie := range sortedCookies {
+			if storage.GetUserById(cookies[cookie]) == nil {
+				continue
+			}
+			if err := out.write(&Record{Cookie: &Cookie{cookie, cookies[cookie]}}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	matchIds := make(map[string]bool)
+
+	for _, id := range gameIds {
+		game, err := exportGame(storage, id)
+		if err != nil {
+			return nil, err
+		}
+		if game.Game.Match != "" {
+			matchIds[game.Game.Match] = true
+		}
+		if err := out.write(&Record{Game: game}); err != nil {
+			return nil, err
+		}
+	}
+
+	sortedMatchIds := make([]string, 0, len(matchIds))
+
+	for id := range matchIds {
+		sortedMatchIds = append(sortedMatchIds, id)
+	}
+
+	sort.Strings(sortedMatchIds)
+
+	for _, id := range sortedMatchIds {
+		match, err := storage.Match(id)
+		if err != nil {
+			return nil, errors.New("Couldn't fetch match " + id + ": " + err.Error())
+		}
+		if err := out.write(&Record{Match: match}); err != nil {
+			return nil, err
+		}
+	}
+
+	out.summary.finish()
+
+	return out.summary, nil
+}
+
+//exportUsers returns every user in storage, sorted by id.
+func exportUsers(storage Storage, gameIds []string) ([]*users.StorageRecord, error) {
+
+	var result []*users.StorageRecord
+
+	if lister, ok := storage.(UserLister); ok {
+		var err error
+		result, err = lister.ListUsers()
+		if err != nil {
+			return nil, errors.New("Couldn't list users: " + err.Error())
+		}
+	} else {
+		seen := make(map[string]bool)
+		for _, id := range gameIds {
+			for _, userId := range storage.UserIdsForGame(id) {
+				if userId == "" || seen[userId] {
+					continue
+				}
+				seen[userId] = true
+				if user := storage.GetUserById(userId); user != nil {
+					result = append(result, user)
+				}
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+
+	return result, nil
+}
+
+//exportGame returns everything stored for the game with the given id.
+func exportGame(storage Storage, id string) (*Game, error) {
+
+	record, err := storage.Game(id)
+
+	if err != nil {
+		return nil, errors.New("Couldn't fetch game " + id + ": " + err.Error())
+	}
+
+	eGame, err := storage.ExtendedGame(id)
+
+	if err != nil {
+		return nil, errors.New("Couldn't fetch extended game " + id + ": " + err.Error())
+	}
+
+	result := &Game{
+		Game:     record,
+		Extended: eGame,
+		Players:  storage.UserIdsForGame(id),
+	}
+
+	moves, err := storage.Moves(id, 0, record.Version)
+
+	if err != nil {
+		return nil, errors.New("Couldn't fetch moves for game " + id + ": " + err.Error())
+	}
+
+	movesByVersion := make(map[int]*boardgame.MoveStorageRecord, len(moves))
+
+	for _, move := range moves {
+		movesByVersion[move.Version] = move
+	}
+
+	for version := 0; version <= record.Version; version++ {
+		state, err := storage.State(id, version)
+		if errors.Is(err, boardgame.ErrVersionNotFound) {
+			//Compacted away, but its move is still there.
+			state = nil
+		} else if err != nil {
+			return nil, errors.New("Couldn't fetch version " + strconv.Itoa(version) + " of game " + id + ": " + err.Error())
+		}
+		move := movesByVersion[version]
+		if state == nil && move == nil {
+			continue
+		}
+		result.Versions = append(result.Versions, &Version{
+			Version: version,
+			State:   json.RawMessage(state),
+			Move:    move,
+		})
+	}
+
+	for player := 0; player < record.NumPlayers; player++ {
+		state, err := storage.AgentState(id, boardgame.PlayerIndex(player))
+		if err != nil {
+			return nil, errors.New("Couldn't fetch agent state for game " + id + ": " + err.Error())
+		}
+		if state == nil {
+			continue
+		}
+		result.AgentStates = append(result.AgentStates, &AgentState{
+			Player: boardgame.PlayerIndex(player),
+			State:  state,
+		})
+	}
+
+	return result, nil
+}
+
+//Import saves every record in the archive read from r to storage, which
+//should be empty, and returns a Summary of what it read.
+func Import(storage Storage, r io.Reader) (*Summary, error) {
+	return readArchive(r, func(record *Record) error {
+		return importRecord(storage, record)
+	})
+}
+
+//SummarizeArchive returns the Summary of the archive read from r, without
+//importing it anywhere.
+func SummarizeArchive(r io.Reader) (*Summary, error) {
+	return readArchive(r, nil)
+}
+
+//readArchive calls handle, if it's not nil, with every record in the archive
+//read from r, and returns their Summary.
+func readArchive(r io.Reader, handle func(record *Record) error) (*Summary, error) {
+
+	reader := bufio.NewReader(r)
+
+	line, err := reader.ReadBytes('\n')
+
+	if err != nil && err != io.EOF {
+		return nil, errors.New("Couldn't read header: " + err.Error())
+	}
+
+	var header Header
+
+	if err := json.Unmarshal(line, &header); err != nil {
+		return nil, errors.New("Couldn't decode header: " + err.Error())
+	}
+
+	if header.Format != FormatName {
+		return nil, errors.New("Not a " + FormatName + " archive")
+	}
+
+	if header.Version > FormatVersion {
+		return nil, errors.New("Archive is format version " + strconv.Itoa(header.Version) + ", which is newer than this tool supports")
+	}
+
+	summary := newSummary()
+
+	for lineNumber := 2; ; lineNumber++ {
+
+		line, err := reader.ReadBytes('\n')
+
+		if err != nil && err != io.EOF {
+			return summary, errors.New("Couldn't read line " + strconv.Itoa(lineNumber) + ": " + err.Error())
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var record Record
+
+			if err := json.Unmarshal(line, &record); err != nil {
+				return summary, errors.New("Couldn't decode line " + strconv.Itoa(lineNumber) + ": " + err.Error())
+			}
+
+			if err := normalizeRecord(&record); err != nil {
+				return summary, err
+			}
+
+			blob, err := json.Marshal(&record)
+
+			if err != nil {
+				return summary, errors.New("Couldn't encode record: " + err.Error())
+			}
+
+			if handle != nil {
+				if err := handle(&record); err != nil {
+					return summary, errors.New("Line " + strconv.Itoa(lineNumber) + ": " + err.Error())
+				}
+			}
+
+			summary.add(&record, blob)
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	summary.finish()
+
+	return summary, nil
+}
+
+func importRecord(storage Storage, record *Record) error {
+
+	switch {
+	case record.User != nil:
+		if err := storage.UpdateUser(record.User); err != nil {
+			return errors.New("Couldn't save user " + record.User.Id + ": " + err.Error())
+		}
+	case record.Cookie != nil:
+		user := storage.GetUserById(record.Cookie.UserId)
+		if user == nil {
+			return errors.New("Cookie's user " + record.Cookie.UserId + " doesn't exist")
+		}
+		if err := storage.ConnectCookieToUser(record.Cookie.Cookie, user); err != nil {
+			return errors.New("Couldn't save cookie: " + err.Error())
+		}
+	case record.Game != nil:
+		return importGame(storage, record.Game)
+	case record.Match != nil:
+		if err := storage.SaveMatch(record.Match); err != nil {
+			return errors.New("Couldn't save match " + record.Match.Id + ": " + err.Error())
+		}
+	default:
+		return errors.New("Empty record")
+	}
+
+	return nil
+}
+
+func importGame(storage Storage, game *Game) error {
+
+	if game.Game == nil || game.Extended == nil {
+		return errors.New("Incomplete game record")
+	}
+
+	id := game.Game.Id
+
+	if len(game.Versions) == 0 || len(game.Versions[0].State) == 0 {
+		return errors.New("Game " + id + " has no state for its first version")
+	}
+
+	var compacted []int
+
+	for _, version := range game.Versions {
+		if len(version.State) == 0 {
+			compacted = append(compacted, version.Version)
+		}
+	}
+
+	deleter, canDelete := storage.(StateDeleter)
+
+	if len(compacted) > 0 && !canDelete {
+		return errors.New("Game " + id + " has compacted states, but the storage manager can't delete states")
+	}
+
+	var lastState json.RawMessage
+
+	for _, version := range game.Versions {
+		state := version.State
+		if len(state) == 0 {
+			//Saved with a copy of the state before it, which is deleted
+			//once every version is saved.
+			state = lastState
+		}
+		lastState = state
+		record := game.Game
+		if version.Version != game.Game.Version {
+			//A game can't change once it's finished, so it wasn't finished
+			//at any of its earlier versions.
+			versionRecord := *game.Game
+			versionRecord.Version = version.Version
+			versionRecord.Finished = false
+			versionRecord.Winners = nil
+			versionRecord.Result = nil
+			record = &versionRecord
+		}
+		if err := storage.SaveGameAndCurrentState(record, boardgame.StateStorageRecord(state), version.Move); err != nil {
+			return errors.New("Couldn't save version " + strconv.Itoa(version.Version) + " of game " + id + ": " + err.Error())
+		}
+	}
+
+	if len(compacted) > 0 {
+		if err := deleter.DeleteStates(id, compacted); err != nil {
+			return errors.New("Couldn't delete compacted states of game " + id + ": " + err.Error())
+		}
+	}
+
+	if err := storage.UpdateExtendedGame(id, game.Extended); err != nil {
+		return errors.New("Couldn't save extended game " + id + ": " + err.Error())
+	}
+
+	for i, userId := range game.Players {
+		if userId == "" {
+			continue
+		}
+		if err := storage.SetPlayerForGame(id, boardgame.PlayerIndex(i), userId); err != nil {
+			return errors.New("Couldn't set player " + strconv.Itoa(i) + " of game " + id + ": " + err.Error())
+		}
+	}
+
+	for _, agentState := range game.AgentStates {
+		if err := storage.SaveAgentState(id, agentState.Player, agentState.State); err != nil {
+			return errors.New("Couldn't save agent state for game " + id + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+//Summarize returns the Summary that exporting storage would return, without
+//writing the archive anywhere.
+func Summarize(storage Storage) (*Summary, error) {
+	return Export(storage, ioutil.Discard)
+}
+
+//Verify checks that storage holds exactly the records described by
+//expected, typically the Summary returned by Import.
+func Verify(storage Storage, expected *Summary) error {
+
+	actual, err := Summarize(storage)
+
+	if err != nil {
+		return errors.New("Couldn't summarize storage: " + err.Error())
+	}
+
+	if problems := expected.Compare(actual); len(problems) > 0 {
+		return errors.New("Storage doesn't match: " + strings.Join(problems, "; "))
+	}
+
+	return nil
+}
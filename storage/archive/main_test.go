@@ -0,0 +1,294 @@
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/examples/tictactoe"
+	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/server/api/users"
+	"github.com/jkomoros/boardgame/storage/filesystem"
+	"github.com/jkomoros/boardgame/storage/memory"
+	"github.com/workfit/tester/assert"
+	"strings"
+	"testing"
+)
+
+const testDir = ".testarchive"
+
+//populate fills storage with a little of everything an archive holds.
+func populate(t *testing.T, storage Storage) {
+
+	manager, err := tictactoe.NewManager(storage)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	user := &users.StorageRecord{Id: "USERONE", DisplayName: "One"}
+
+	assert.For(t).ThatActual(storage.UpdateUser(user)).IsNil()
+	assert.For(t).ThatActual(storage.UpdateUser(&users.StorageRecord{Id: "USERTWO"})).IsNil()
+	assert.For(t).ThatActual(storage.ConnectCookieToUser("COOKIE", user)).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	for i := 0; i < 3; i++ {
+		move := game.PlayerMoveByName("Place Token")
+		assert.For(t, i).ThatActual(<-game.ProposeMove(move, boardgame.AdminPlayerIndex)).IsNil()
+	}
+
+	assert.For(t).ThatActual(storage.SetPlayerForGame(game.Id(), 0, user.Id)).IsNil()
+	assert.For(t).ThatActual(storage.SaveAgentState(game.Id(), 1, []byte("AGENTSTATE"))).IsNil()
+
+	eGame, err := storage.ExtendedGame(game.Id())
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	eGame.Owner = user.Id
+	eGame.Visible = false
+
+	assert.For(t).ThatActual(storage.UpdateExtendedGame(game.Id(), eGame)).IsNil()
+
+	_, err = manager.NewMatch(2, nil, nil, boardgame.MatchPolicy{BestOf: 3})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+}
+
+func TestRoundTrip(t *testing.T) {
+
+	src := memory.NewStorageManager()
+
+	populate(t, src)
+
+	var buf bytes.Buffer
+
+	exported, err := Export(src, &buf)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	assert.For(t).ThatActual(exported.Users).Equals(2)
+	assert.For(t).ThatActual(exported.Cookies).Equals(1)
+	assert.For(t).ThatActual(exported.Games).Equals(2)
+	//Every version but the first has a move.
+	assert.For(t).ThatActual(exported.States).Equals(exported.Moves + 2)
+	assert.For(t).ThatActual(exported.AgentStates).Equals(1)
+	assert.For(t).ThatActual(exported.Matches).Equals(1)
+
+	archiveBlob := buf.String()
+
+	assert.For(t).ThatActual(strings.HasPrefix(archiveBlob, `{"Format":"boardgame-archive","Version":2,"Source":"memory"`)).IsTrue()
+
+	//Importing into a different kind of storage manager holds the same data.
+	dst, err := filesystem.NewStorageManager(testDir)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	defer dst.CleanUp()
+
+	imported, err := Import(dst, strings.NewReader(archiveBlob))
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(imported.Compare(exported)).Equals([]string(nil))
+	assert.For(t).ThatActual(Verify(dst, imported)).IsNil()
+
+	manager, _ := tictactoe.NewManager(dst)
+
+	for _, game := range src.ListGames(10, listing.All, "", "") {
+		refried := manager.Game(game.Id)
+		assert.For(t, game.Id).ThatActual(refried).IsNotNil()
+		assert.For(t, game.Id).ThatActual(refried.Version()).Equals(game.Version)
+		assert.For(t, game.Id).ThatActual(dst.UserIdsForGame(game.Id)).Equals(src.UserIdsForGame(game.Id))
+	}
+
+	assert.For(t).ThatActual(dst.GetUserByCookie("COOKIE").Id).Equals("USERONE")
+
+	//Verification catches changes.
+	assert.For(t).ThatActual(dst.UpdateUser(&users.StorageRecord{Id: "USERTHREE"})).IsNil()
+	assert.For(t).ThatActual(Verify(dst, imported)).IsNotNil()
+
+}
+
+func TestCompactedGame(t *testing.T) {
+
+	src := memory.NewStorageManager()
+
+	populate(t, src)
+
+	before, err := Summarize(src)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	var gameId string
+
+	for _, game := range src.ListGames(10, listing.All, "", "") {
+		if game.Version >= 3 {
+			gameId = game.Id
+		}
+	}
+
+	assert.For(t).ThatActual(gameId).DoesNotEqual("")
+
+	//Compact the states the way storage/retention does, keeping the moves.
+	assert.For(t).ThatActual(src.DeleteStates(gameId, []int{1, 2})).IsNil()
+
+	var buf bytes.Buffer
+
+	exported, err := Export(src, &buf)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(exported.States).Equals(before.States - 2)
+	assert.For(t).ThatActual(exported.Moves).Equals(before.Moves)
+
+	dst := memory.NewStorageManager()
+
+	imported, err := Import(dst, strings.NewReader(buf.String()))
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(Verify(dst, imported)).IsNil()
+
+	for version := 1; version <= 2; version++ {
+		_, err := dst.State(gameId, version)
+		assert.For(t, version).ThatActual(errors.Is(err, boardgame.ErrVersionNotFound)).IsTrue()
+
+		move, err := dst.Move(gameId, version)
+		assert.For(t, version).ThatActual(err).IsNil()
+		expected, _ := src.Move(gameId, version)
+		assert.For(t, version).ThatActual(move.Name).Equals(expected.Name)
+	}
+
+	//Storage managers that can't delete states can't hold a compacted game.
+	_, err = Import(struct{ Storage }{memory.NewStorageManager()}, strings.NewReader(buf.String()))
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+	//Verification catches a lost move.
+	assert.For(t).ThatActual(Verify(&movelessStorage{dst, gameId}, imported)).IsNotNil()
+
+}
+
+//movelessStorage loses the moves of one game.
+type movelessStorage struct {
+	Storage
+	gameId string
+}
+
+func (m *movelessStorage) Moves(gameId string, fromVersion, toVersion int) ([]*boardgame.MoveStorageRecord, error) {
+	if gameId == m.gameId {
+		return nil, nil
+	}
+	return m.Storage.Moves(gameId, fromVersion, toVersion)
+}
+
+func TestExportStateError(t *testing.T) {
+
+	src := memory.NewStorageManager()
+
+	populate(t, src)
+
+	_, err := Summarize(&brokenStateStorage{src})
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+}
+
+//brokenStateStorage fails to read any state other than version 0.
+type brokenStateStorage struct {
+	Storage
+}
+
+func (b *brokenStateStorage) State(gameId string, version int) (boardgame.StateStorageRecord, error) {
+	if version > 0 {
+		return nil, errors.New("Disk on fire")
+	}
+	return b.Storage.State(gameId, version)
+}
+
+func TestUserFallback(t *testing.T) {
+
+	src := memory.NewStorageManager()
+
+	populate(t, src)
+
+	//Without UserLister and CookieLister, only players are exported.
+	summary, err := Summarize(struct{ Storage }{src})
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(summary.Users).Equals(1)
+	assert.For(t).ThatActual(summary.Cookies).Equals(0)
+
+}
+
+func TestBadArchive(t *testing.T) {
+
+	_, err := Import(memory.NewStorageManager(), strings.NewReader(`{"Format":"something-else","Version":1}`+"\n"))
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+	_, err = Import(memory.NewStorageManager(), strings.NewReader(`{"Format":"boardgame-archive","Version":100}`+"\n"))
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+	_, err = Import(memory.NewStorageManager(), strings.NewReader(`{"Format":"boardgame-archive","Version":1}`+"\n{}\n"))
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+	summary, err := Import(memory.NewStorageManager(), strings.NewReader(`{"Format":"boardgame-archive","Version":1}`))
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(summary.Games).Equals(0)
+
+}
+
+//savedGamesStorage records every game record saved to it.
+type savedGamesStorage struct {
+	Storage
+	saved []boardgame.GameStorageRecord
+}
+
+func (s *savedGamesStorage) SaveGameAndCurrentState(game *boardgame.GameStorageRecord, state boardgame.StateStorageRecord, move *boardgame.MoveStorageRecord) error {
+	s.saved = append(s.saved, *game)
+	return s.Storage.SaveGameAndCurrentState(game, state, move)
+}
+
+func TestImportFinishedGame(t *testing.T) {
+
+	src := memory.NewStorageManager()
+
+	manager, err := tictactoe.NewManager(src)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	for i := 0; i < 20 && !game.Finished(); i++ {
+		move := game.PlayerMoveByName("Place Token")
+		assert.For(t, i).ThatActual(<-game.ProposeMove(move, boardgame.AdminPlayerIndex)).IsNil()
+	}
+
+	assert.For(t).ThatActual(game.Finished()).IsTrue()
+
+	var buf bytes.Buffer
+
+	_, err = Export(src, &buf)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	dst := &savedGamesStorage{Storage: memory.NewStorageManager()}
+
+	_, err = Import(dst, &buf)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(len(dst.saved)).Equals(game.Version() + 1)
+
+	//Only the last version is finished.
+	for _, record := range dst.saved {
+		finalVersion := record.Version == game.Version()
+		assert.For(t, record.Version).ThatActual(record.Finished).Equals(finalVersion)
+		assert.For(t, record.Version).ThatActual(record.Result != nil).Equals(finalVersion)
+	}
+
+}
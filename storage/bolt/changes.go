@@ -0,0 +1,139 @@
+package bolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"github.com/boltdb/bolt"
+	"github.com/jkomoros/boardgame/server/api/changes"
+)
+
+//changesBucket maps each change's cursor, from the bucket's sequence, to a
+//changeRecord.
+var changesBucket = []byte("Changes")
+
+type changeRecord struct {
+	GameId  string
+	Version int
+}
+
+//keyForChange returns a key that sorts, bytewise, in the order of cursor.
+func keyForChange(cursor uint64) []byte {
+	result := make([]byte, 8)
+	binary.BigEndian.PutUint64(result, cursor)
+	return result
+}
+
+//recordChange adds a change for the given version of the game to the feed,
+//within the transaction that saves it, and prunes all but the most recent
+//changes.MaxRetained changes.
+func recordChange(tx *bolt.Tx, gameId string, version int) error {
+
+	bucket := tx.Bucket(changesBucket)
+
+	if bucket == nil {
+		return errors.New("Couldn't open changes bucket")
+	}
+
+	cursor, err := bucket.NextSequence()
+
+	if err != nil {
+		return err
+	}
+
+	blob, err := json.Marshal(&changeRecord{
+		GameId:  gameId,
+		Version: version,
+	})
+
+	if err != nil {
+		return errors.New("Couldn't serialize change: " + err.Error())
+	}
+
+	if err := bucket.Put(keyForChange(cursor), blob); err != nil {
+		return err
+	}
+
+	if cursor <= changes.MaxRetained {
+		return nil
+	}
+
+	oldest := keyForChange(cursor - changes.MaxRetained)
+
+	//Normally there's only the one change to prune, but collect the keys
+	//first, since deleting while iterating skips keys.
+	var pruned [][]byte
+
+	c := bucket.Cursor()
+
+	for k, _ := c.First(); k != nil && bytes.Compare(k, oldest) <= 0; k, _ = c.Next() {
+		pruned = append(pruned, k)
+	}
+
+	for _, k := range pruned {
+		if err := bucket.Delete(k); err != nil {
+			return errors.New("Couldn't prune change: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (s *StorageManager) ChangesSince(cursor int64) (*changes.Result, error) {
+
+	result := &changes.Result{
+		Cursor: cursor,
+	}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+
+		bucket := tx.Bucket(changesBucket)
+
+		if bucket == nil {
+			return errors.New("Couldn't open changes bucket")
+		}
+
+		if cursor == changes.Latest {
+			result.Cursor = int64(bucket.Sequence())
+			return nil
+		}
+
+		if cursor < 0 {
+			cursor = 0
+		}
+
+		c := bucket.Cursor()
+
+		for k, v := c.Seek(keyForChange(uint64(cursor) + 1)); k != nil && len(result.Changes) < changes.MaxChanges; k, v = c.Next() {
+
+			var record changeRecord
+
+			if err := json.Unmarshal(v, &record); err != nil {
+				return errors.New("Couldn't deserialize change: " + err.Error())
+			}
+
+			change := &changes.Change{
+				Cursor:  int64(binary.BigEndian.Uint64(k)),
+				GameId:  record.GameId,
+				Version: record.Version,
+			}
+
+			result.Changes = append(result.Changes, change)
+			result.Cursor = change.Cursor
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+//Watch polls the changes bucket for new changes.
+func (s *StorageManager) Watch(gameId string) *changes.Watcher {
+	return s.poller.Watch(gameId)
+}
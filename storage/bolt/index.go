@@ -0,0 +1,290 @@
+package bolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"github.com/boltdb/bolt"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/extendedgame"
+	"github.com/jkomoros/boardgame/server/api/query"
+	"time"
+)
+
+//The index buckets map keys to nothing; all of the information is in the
+//keys, so that bolt's cursors can walk them in order. The games by activity
+//and created buckets are keyed by the time followed by the game's key, and
+//the user games bucket is keyed by the user's id, a 0 byte, and the game's
+//key.
+var (
+	activityIndexBucket = []byte("GamesByActivity")
+	createdIndexBucket  = []byte("GamesByCreated")
+	userGamesBucket     = []byte("UserGames")
+)
+
+//sortableKeyLength is the length of the time at the start of keys in the
+//activity and created indexes.
+const sortableKeyLength = 8
+
+//sortableKey returns a key for the given time in nanoseconds and game that
+//sorts, bytewise, in the order of the time and then the game's key.
+func sortableKey(nanos int64, gameId string) []byte {
+	result := make([]byte, sortableKeyLength, sortableKeyLength+len(gameId))
+	//Flipping the sign bit makes negative numbers sort before positive
+	//ones.
+	binary.BigEndian.PutUint64(result, uint64(nanos)^(1<<63))
+	return append(result, keyForGame(gameId)...)
+}
+
+func activityIndexKey(lastActivity int64, gameId string) []byte {
+	return sortableKey(lastActivity, gameId)
+}
+
+func createdIndexKey(created time.Time, gameId string) []byte {
+	return sortableKey(created.UnixNano(), gameId)
+}
+
+func userGamePrefix(userId string) []byte {
+	return append([]byte(userId), 0)
+}
+
+func userGameKey(userId string, gameId string) []byte {
+	return append(userGamePrefix(userId), keyForGame(gameId)...)
+}
+
+//indexBuckets returns the activity, created and user games buckets.
+func indexBuckets(tx *bolt.Tx) (activity, created, userGames *bolt.Bucket, err error) {
+	activity = tx.Bucket(activityIndexBucket)
+	created = tx.Bucket(createdIndexBucket)
+	userGames = tx.Bucket(userGamesBucket)
+	if activity == nil || created == nil || userGames == nil {
+		return nil, nil, nil, errors.New("Couldn't open index buckets")
+	}
+	return activity, created, userGames, nil
+}
+
+//buildIndexes adds every game that's already stored to the indexes, for
+//databases created before the indexes existed.
+func buildIndexes(tx *bolt.Tx) error {
+
+	activity, created, userGames, err := indexBuckets(tx)
+
+	if err != nil {
+		return err
+	}
+
+	eBucket := tx.Bucket(extendedGamesBucket)
+	gUBucket := tx.Bucket(gameUsersBucket)
+
+	return tx.Bucket(gamesBucket).ForEach(func(k, v []byte) error {
+
+		var game boardgame.GameStorageRecord
+
+		if err := json.Unmarshal(v, &game); err != nil {
+			return errors.New("Couldn't deserialize a game: " + err.Error())
+		}
+
+		if err := created.Put(createdIndexKey(game.Created, game.Id), nil); err != nil {
+			return err
+		}
+
+		if rawEGame := eBucket.Get(k); rawEGame != nil {
+			var eGame extendedgame.StorageRecord
+			if err := json.Unmarshal(rawEGame, &eGame); err != nil {
+				return errors.New("Couldn't deserialize an extended game: " + err.Error())
+			}
+			if err := activity.Put(activityIndexKey(eGame.LastActivity, game.Id), nil); err != nil {
+				return err
+			}
+		}
+
+		var ids []string
+
+		if rawIds := gUBucket.Get(k); rawIds != nil {
+			if err := json.Unmarshal(rawIds, &ids); err != nil {
+				return errors.New("Couldn't deserialize game users: " + err.Error())
+			}
+		}
+
+		for _, id := range ids {
+			if id == "" {
+				continue
+			}
+			if err := userGames.Put(userGameKey(id, game.Id), nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+//combinedGameInTx is like CombinedGame, but reads the game within an
+//existing transaction.
+func combinedGameInTx(tx *bolt.Tx, id string) (*extendedgame.CombinedStorageRecord, error) {
+
+	rawGame := tx.Bucket(gamesBucket).Get(keyForGame(id))
+	rawEGame := tx.Bucket(extendedGamesBucket).Get(keyForGame(id))
+
+	if rawGame == nil || rawEGame == nil {
+		return nil, boardgame.ErrGameNotFound
+	}
+
+	var result extendedgame.CombinedStorageRecord
+
+	if err := json.Unmarshal(rawGame, &result.GameStorageRecord); err != nil {
+		return nil, errors.New("Unmarshal error " + err.Error())
+	}
+
+	if err := json.Unmarshal(rawEGame, &result.StorageRecord); err != nil {
+		return nil, errors.New("Unmarshal error " + err.Error())
+	}
+
+	return &result, nil
+}
+
+//gameIdsForUser returns the keys of every game the user is a player in.
+func (s *StorageManager) gameIdsForUser(userId string) ([]string, error) {
+
+	var result []string
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		userGames := tx.Bucket(userGamesBucket)
+
+		if userGames == nil {
+			return errors.New("Couldn't open user games bucket")
+		}
+
+		prefix := userGamePrefix(userId)
+
+		c := userGames.Cursor()
+
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			result = append(result, string(k[len(prefix):]))
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+//QueryGames walks the index for the query's sort, starting at its cursor,
+//until it has found a page of matching games. Queries for a user's games
+//use the user games index instead, and sort the user's games in memory.
+func (s *StorageManager) QueryGames(q *query.Query) (*query.Result, error) {
+
+	start, err := q.Start()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if q.UserId != "" {
+		ids, err := s.gameIdsForUser(q.UserId)
+
+		if err != nil {
+			return nil, errors.New("Couldn't list user's games: " + err.Error())
+		}
+
+		var games []*extendedgame.CombinedStorageRecord
+
+		err = s.db.View(func(tx *bolt.Tx) error {
+			for _, id := range ids {
+				game, err := combinedGameInTx(tx, id)
+				if err != nil {
+					continue
+				}
+				games = append(games, game)
+			}
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return query.Apply(q, games, s.UserIdsForGame)
+	}
+
+	indexBucket := activityIndexBucket
+
+	if q.Sort == query.Created {
+		indexBucket = createdIndexBucket
+	}
+
+	pageSize := q.PageSize()
+
+	var games []*extendedgame.CombinedStorageRecord
+
+	err = s.db.View(func(tx *bolt.Tx) error {
+
+		index := tx.Bucket(indexBucket)
+
+		if index == nil {
+			return errors.New("Couldn't open index bucket")
+		}
+
+		c := index.Cursor()
+
+		next := c.Prev
+
+		if q.Ascending {
+			next = c.Next
+		}
+
+		var k []byte
+
+		switch {
+		case start == nil && q.Ascending:
+			k, _ = c.First()
+		case start == nil:
+			k, _ = c.Last()
+		default:
+			startKey := sortableKey(start.Key, start.Id)
+			k, _ = c.Seek(startKey)
+			if q.Ascending {
+				if bytes.Equal(k, startKey) {
+					k, _ = c.Next()
+				}
+			} else if k == nil {
+				k, _ = c.Last()
+			} else {
+				k, _ = c.Prev()
+			}
+		}
+
+		//Fetch one more game than fits in the page, to know whether there's
+		//another page.
+		for ; k != nil && len(games) <= pageSize; k, _ = next() {
+
+			game, err := combinedGameInTx(tx, string(k[sortableKeyLength:]))
+
+			if err != nil {
+				continue
+			}
+
+			if q.Matches(game, nil) {
+				games = append(games, game)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, errors.New("Couldn't query games: " + err.Error())
+	}
+
+	result := &query.Result{
+		Games: games,
+	}
+
+	if len(games) > pageSize {
+		result.Games = games[:pageSize]
+		result.NextCursor = q.CursorFor(result.Games[pageSize-1])
+	}
+
+	return result, nil
+}
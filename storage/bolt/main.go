@@ -7,10 +7,12 @@ boardgame.StorageManager and boardgame/server.StorageManager.
 package bolt
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"github.com/boltdb/bolt"
 	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/changes"
 	"github.com/jkomoros/boardgame/server/api/extendedgame"
 	"github.com/jkomoros/boardgame/server/api/listing"
 	"github.com/jkomoros/boardgame/server/api/users"
@@ -27,6 +29,7 @@ import (
 type StorageManager struct {
 	db       *bolt.DB
 	filename string
+	poller   *changes.Poller
 }
 
 var (
@@ -38,6 +41,7 @@ var (
 	cookiesBucket       = []byte("Cookies")
 	gameUsersBucket     = []byte("GameUsers")
 	agentStatesBucket   = []byte("AgentStates")
+	matchesBucket       = []byte("Matches")
 )
 
 func NewStorageManager(fileName string) *StorageManager {
@@ -72,6 +76,21 @@ func NewStorageManager(fileName string) *StorageManager {
 		if _, err := tx.CreateBucketIfNotExists(agentStatesBucket); err != nil {
 			return errors.New("Cannot create agent states bucket" + err.Error())
 		}
+		if _, err := tx.CreateBucketIfNotExists(matchesBucket); err != nil {
+			return errors.New("Cannot create matches bucket" + err.Error())
+		}
+		if _, err := tx.CreateBucketIfNotExists(changesBucket); err != nil {
+			return errors.New("Cannot create changes bucket" + err.Error())
+		}
+		needsIndexes := tx.Bucket(activityIndexBucket) == nil
+		for _, name := range [][]byte{activityIndexBucket, createdIndexBucket, userGamesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return errors.New("Cannot create " + string(name) + " bucket" + err.Error())
+			}
+		}
+		if needsIndexes {
+			return buildIndexes(tx)
+		}
 		return nil
 	})
 
@@ -80,10 +99,12 @@ func NewStorageManager(fileName string) *StorageManager {
 		return nil
 	}
 	//We don't defer DB close; our users need to.
-	return &StorageManager{
+	result := &StorageManager{
 		db:       db,
 		filename: fileName,
 	}
+	result.poller = changes.NewPoller(result.ChangesSince, 0)
+	return result
 
 }
 
@@ -107,6 +128,10 @@ func keyForCookie(cookie string) []byte {
 	return []byte(cookie)
 }
 
+func keyForMatch(id string) []byte {
+	return []byte(strings.ToUpper(id))
+}
+
 func keyForAgentState(gameId string, player boardgame.PlayerIndex) []byte {
 	return []byte(gameId + "-" + player.String())
 }
@@ -142,7 +167,7 @@ func (s *StorageManager) State(gameId string, version int) (boardgame.StateStora
 	}
 
 	if record == nil {
-		return nil, errors.New("No such version for game")
+		return nil, boardgame.ErrVersionNotFound
 	}
 
 	return record, nil
@@ -150,23 +175,47 @@ func (s *StorageManager) State(gameId string, version int) (boardgame.StateStora
 
 func (s *StorageManager) Moves(gameId string, fromVersion, toVersion int) ([]*boardgame.MoveStorageRecord, error) {
 
-	//There's no efficiency boost for fetching multiple moves at once so just wrap around Move()
-
 	if fromVersion == toVersion {
 		fromVersion = fromVersion - 1
 	}
 
-	result := make([]*boardgame.MoveStorageRecord, toVersion-fromVersion)
+	if gameId == "" {
+		return nil, errors.New("No game provided")
+	}
 
-	index := 0
-	for i := fromVersion + 1; i <= toVersion; i++ {
-		move, err := s.Move(gameId, i)
-		if err != nil {
-			return nil, err
+	var result []*boardgame.MoveStorageRecord
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(movesBucket)
+
+		if b == nil {
+			return errors.New("Couldn't get bucket")
 		}
-		result[index] = move
-		index++
+
+		for i := fromVersion + 1; i <= toVersion; i++ {
+			record := b.Get(keyForMove(gameId, i))
+
+			if record == nil {
+				//Skip versions with no move.
+				continue
+			}
+
+			var move boardgame.MoveStorageRecord
+
+			if err := json.Unmarshal(record, &move); err != nil {
+				return errors.New("Couldn't unmarshal internal blob: " + err.Error())
+			}
+
+			result = append(result, &move)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
+
 	return result, nil
 }
 
@@ -197,7 +246,7 @@ func (s *StorageManager) Move(gameId string, version int) (*boardgame.MoveStorag
 	}
 
 	if record == nil {
-		return nil, errors.New("No such version (" + strconv.Itoa(version) + ") for game " + gameId)
+		return nil, boardgame.ErrVersionNotFound.WithError("No such version (" + strconv.Itoa(version) + ") for game " + gameId)
 	}
 
 	var result boardgame.MoveStorageRecord
@@ -227,7 +276,7 @@ func (s *StorageManager) Game(id string) (*boardgame.GameStorageRecord, error) {
 	}
 
 	if rawRecord == nil {
-		return nil, errors.New("No such game found")
+		return nil, boardgame.ErrGameNotFound
 	}
 
 	var record boardgame.GameStorageRecord
@@ -250,25 +299,6 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 		return errors.New("Couldn't serialize the internal game record: " + err.Error())
 	}
 
-	previousGame, _ := s.Game(game.Id)
-
-	eGame := extendedgame.DefaultStorageRecord()
-
-	if previousGame != nil {
-		//This is not a new game!
-		eGame, err = s.ExtendedGame(game.Id)
-		if err != nil {
-			return errors.New("Couldnt' find extended game for an already created game: " + err.Error())
-		}
-		eGame.LastActivity = time.Now().UnixNano()
-	}
-
-	serializedExtendedGameRecord, err := json.Marshal(eGame)
-
-	if err != nil {
-		return errors.New("Couldn't serialize the internal extended game record: " + err.Error())
-	}
-
 	var serializedMoveRecord []byte
 
 	if move != nil {
@@ -279,7 +309,7 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 		return errors.New("Couldn't serialize the internal move record: " + err.Error())
 	}
 
-	return s.db.Update(func(tx *bolt.Tx) error {
+	err = s.db.Update(func(tx *bolt.Tx) error {
 		gBucket := tx.Bucket(gamesBucket)
 
 		if gBucket == nil {
@@ -304,6 +334,44 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 			return errors.New("Couldn't open extended games bucket")
 		}
 
+		activity, created, _, err := indexBuckets(tx)
+
+		if err != nil {
+			return err
+		}
+
+		eGame := extendedgame.DefaultStorageRecord()
+
+		//The previous records are read in this transaction, so that the
+		//index keys removed are the ones actually stored.
+		if rawPreviousGame := gBucket.Get(keyForGame(game.Id)); rawPreviousGame != nil {
+			//This is not a new game!
+			var previousGame boardgame.GameStorageRecord
+			if err := json.Unmarshal(rawPreviousGame, &previousGame); err != nil {
+				return errors.New("Couldn't unmarshal previous game record: " + err.Error())
+			}
+			rawExtendedGame := eBucket.Get(keyForGame(game.Id))
+			if rawExtendedGame == nil {
+				return errors.New("Couldn't find extended game for an already created game")
+			}
+			if err := json.Unmarshal(rawExtendedGame, &eGame); err != nil {
+				return errors.New("Couldn't unmarshal extended game record: " + err.Error())
+			}
+			if err := activity.Delete(activityIndexKey(eGame.LastActivity, game.Id)); err != nil {
+				return err
+			}
+			if err := created.Delete(createdIndexKey(previousGame.Created, game.Id)); err != nil {
+				return err
+			}
+			eGame.LastActivity = time.Now().UnixNano()
+		}
+
+		serializedExtendedGameRecord, err := json.Marshal(eGame)
+
+		if err != nil {
+			return errors.New("Couldn't serialize the internal extended game record: " + err.Error())
+		}
+
 		if err := gBucket.Put(keyForGame(game.Id), serializedGameRecord); err != nil {
 			return err
 		}
@@ -316,6 +384,14 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 			return err
 		}
 
+		if err := activity.Put(activityIndexKey(eGame.LastActivity, game.Id), nil); err != nil {
+			return err
+		}
+
+		if err := created.Put(createdIndexKey(game.Created, game.Id), nil); err != nil {
+			return err
+		}
+
 		if serializedMoveRecord != nil {
 			if err := mBucket.Put(keyForMove(game.Id, version), serializedMoveRecord); err != nil {
 				return err
@@ -323,10 +399,81 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 
 		}
 
-		return nil
+		return recordChange(tx, game.Id, version)
 
 	})
 
+	if err != nil {
+		return err
+	}
+
+	s.poller.Notify()
+
+	return nil
+
+}
+
+//ReplaceGame overwrites the game record that's already saved, without saving
+//a new version, for example so storage/encrypted can re-encrypt it.
+func (s *StorageManager) ReplaceGame(game *boardgame.GameStorageRecord) error {
+
+	previousGame, err := s.Game(game.Id)
+
+	if err != nil {
+		return err
+	}
+
+	serializedGameRecord, err := json.Marshal(game)
+
+	if err != nil {
+		return errors.New("Couldn't serialize the internal game record: " + err.Error())
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		gBucket := tx.Bucket(gamesBucket)
+
+		if gBucket == nil {
+			return errors.New("Couldn't open games bucket")
+		}
+
+		if err := gBucket.Put(keyForGame(game.Id), serializedGameRecord); err != nil {
+			return err
+		}
+
+		if previousGame.Created.Equal(game.Created) {
+			return nil
+		}
+
+		_, created, _, err := indexBuckets(tx)
+
+		if err != nil {
+			return err
+		}
+
+		if err := created.Delete(createdIndexKey(previousGame.Created, game.Id)); err != nil {
+			return err
+		}
+
+		return created.Put(createdIndexKey(game.Created, game.Id), nil)
+	})
+}
+
+//ReplaceState overwrites the state already saved for the given version of
+//the game, for example so storage/delta can migrate the game.
+func (s *StorageManager) ReplaceState(gameId string, version int, state boardgame.StateStorageRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		sBucket := tx.Bucket(statesBucket)
+
+		if sBucket == nil {
+			return errors.New("Couldn't open states bucket")
+		}
+
+		if sBucket.Get(keyForState(gameId, version)) == nil {
+			return boardgame.ErrVersionNotFound
+		}
+
+		return sBucket.Put(keyForState(gameId, version), state)
+	})
 }
 
 func (s *StorageManager) AgentState(gameId string, player boardgame.PlayerIndex) ([]byte, error) {
@@ -371,6 +518,55 @@ func (s *StorageManager) SaveAgentState(gameId string, player boardgame.PlayerIn
 
 }
 
+func (s *StorageManager) Match(id string) (*boardgame.MatchStorageRecord, error) {
+
+	var rawRecord []byte
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(matchesBucket)
+		if b == nil {
+			return errors.New("Couldn't open bucket")
+		}
+		rawRecord = b.Get(keyForMatch(id))
+		return nil
+	})
+
+	if err != nil {
+		return nil, errors.New("Transacation error " + err.Error())
+	}
+
+	if rawRecord == nil {
+		return nil, boardgame.ErrMatchNotFound
+	}
+
+	var record boardgame.MatchStorageRecord
+
+	if err := json.Unmarshal(rawRecord, &record); err != nil {
+		return nil, errors.New("Unmarshal error " + err.Error())
+	}
+
+	return &record, nil
+}
+
+func (s *StorageManager) SaveMatch(match *boardgame.MatchStorageRecord) error {
+
+	serializedMatchRecord, err := json.Marshal(match)
+
+	if err != nil {
+		return errors.New("Couldn't serialize the match record: " + err.Error())
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		mBucket := tx.Bucket(matchesBucket)
+
+		if mBucket == nil {
+			return errors.New("Couldn't open matches bucket")
+		}
+
+		return mBucket.Put(keyForMatch(match.Id), serializedMatchRecord)
+	})
+}
+
 func (s *StorageManager) ListGames(max int, list listing.Type, userId string, gameType string) []*extendedgame.CombinedStorageRecord {
 
 	if (list == listing.ParticipatingActive || list == listing.ParticipatingFinished) && userId == "" {
@@ -381,23 +577,18 @@ func (s *StorageManager) ListGames(max int, list listing.Type, userId string, ga
 
 	var resultIds []string
 
+	//Walk the activity index from the most recently active game, so we can
+	//stop as soon as we have max games.
 	err := s.db.View(func(tx *bolt.Tx) error {
 
-		gBucket := tx.Bucket(gamesBucket)
+		activity := tx.Bucket(activityIndexBucket)
 
-		if gBucket == nil {
-			return errors.New("couldn't open games bucket")
+		if activity == nil {
+			return errors.New("couldn't open activity index bucket")
 		}
-		c := gBucket.Cursor()
-		for k, v := c.First(); k != nil; k, v = c.Next() {
-
-			var record boardgame.GameStorageRecord
-
-			if err := json.Unmarshal(v, &record); err != nil {
-				return errors.New("Couldn't deserialize a game: " + err.Error())
-			}
-
-			resultIds = append(resultIds, record.Id)
+		c := activity.Cursor()
+		for k, _ := c.Last(); k != nil; k, _ = c.Prev() {
+			resultIds = append(resultIds, string(k[sortableKeyLength:]))
 		}
 
 		return nil
@@ -476,10 +667,6 @@ func (s *StorageManager) ListGames(max int, list listing.Type, userId string, ga
 		result = append(result, game)
 	}
 
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].LastActivity > result[j].LastActivity
-	})
-
 	return result
 
 }
@@ -575,6 +762,26 @@ func (s *StorageManager) UpdateExtendedGame(id string, eGame *extendedgame.Stora
 			return errors.New("Couldn't open extended games bucket")
 		}
 
+		activity, _, _, err := indexBuckets(tx)
+
+		if err != nil {
+			return err
+		}
+
+		//Only games that have been saved are indexed.
+		if rawPrevious := eBucket.Get(keyForGame(id)); rawPrevious != nil {
+			var previous extendedgame.StorageRecord
+			if err := json.Unmarshal(rawPrevious, &previous); err != nil {
+				return errors.New("Couldn't unmarshal previous record: " + err.Error())
+			}
+			if err := activity.Delete(activityIndexKey(previous.LastActivity, id)); err != nil {
+				return err
+			}
+			if err := activity.Put(activityIndexKey(eGame.LastActivity, id), nil); err != nil {
+				return err
+			}
+		}
+
 		return eBucket.Put(keyForGame(id), serializedExtendedGameRecord)
 	})
 
@@ -588,41 +795,62 @@ func (s *StorageManager) UpdateExtendedGame(id string, eGame *extendedgame.Stora
 
 func (s *StorageManager) SetPlayerForGame(gameId string, playerIndex boardgame.PlayerIndex, userId string) error {
 
-	ids := s.UserIdsForGame(gameId)
-
-	if ids == nil {
-		return errors.New("Couldn't fetch original player indexes for that game")
-	}
-
-	if int(playerIndex) < 0 || int(playerIndex) >= len(ids) {
-		return errors.New("PlayerIndex " + playerIndex.String() + " is not valid for this game")
-	}
-
-	if ids[playerIndex] != "" {
-		return errors.New("PlayerIndex " + playerIndex.String() + " is already taken")
-	}
-
 	user := s.GetUserById(userId)
 
 	if user == nil {
 		return errors.New("That userId does not describe an existing user")
 	}
 
-	ids[playerIndex] = userId
+	gameRecord, err := s.Game(gameId)
 
-	err := s.db.Update(func(tx *bolt.Tx) error {
+	if err != nil {
+		return errors.New("Couldn't fetch original player indexes for that game")
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
 		gUBucket := tx.Bucket(gameUsersBucket)
 
 		if gUBucket == nil {
 			return errors.New("Couldn't open game useres bucket")
 		}
 
+		//The ids are read in this transaction so that two users can't both
+		//take the same seat, which would leave the first one's index entry
+		//behind.
+		ids := make([]string, gameRecord.NumPlayers)
+
+		if rawIds := gUBucket.Get(keyForGame(gameId)); rawIds != nil {
+			if err := json.Unmarshal(rawIds, &ids); err != nil {
+				return errors.New("Unable to unmarshal ids blob: " + err.Error())
+			}
+		}
+
+		if int(playerIndex) < 0 || int(playerIndex) >= len(ids) {
+			return errors.New("PlayerIndex " + playerIndex.String() + " is not valid for this game")
+		}
+
+		if ids[playerIndex] != "" {
+			return errors.New("PlayerIndex " + playerIndex.String() + " is already taken")
+		}
+
+		ids[playerIndex] = userId
+
 		blob, err := json.Marshal(ids)
 
 		if err != nil {
 			return errors.New("Unable to marshal ids blob: " + err.Error())
 		}
 
+		_, _, userGames, err := indexBuckets(tx)
+
+		if err != nil {
+			return err
+		}
+
+		if err := userGames.Put(userGameKey(userId, gameId), nil); err != nil {
+			return err
+		}
+
 		return gUBucket.Put(keyForGame(gameId), blob)
 	})
 
@@ -805,6 +1033,7 @@ func (s *StorageManager) Connect(config string) error {
 }
 
 func (s *StorageManager) Close() {
+	s.poller.Close()
 	s.db.Close()
 }
 
@@ -816,3 +1045,327 @@ func (s *StorageManager) PlayerMoveApplied(game *boardgame.GameStorageRecord) er
 	//Don't need to do anything
 	return nil
 }
+
+//StateVersions returns the versions of the game that have a state stored, in
+//ascending order.
+func (s *StorageManager) StateVersions(gameId string) ([]int, error) {
+
+	var result []int
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+
+		sBucket := tx.Bucket(statesBucket)
+
+		if sBucket == nil {
+			return errors.New("Couldn't open states bucket")
+		}
+
+		prefix := []byte(gameId + "_")
+
+		c := sBucket.Cursor()
+
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			version, err := strconv.Atoi(string(k[len(prefix):]))
+			if err != nil {
+				continue
+			}
+			result = append(result, version)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Ints(result)
+
+	return result, nil
+}
+
+//DeleteStates deletes the states stored for the given versions of the game.
+func (s *StorageManager) DeleteStates(gameId string, versions []int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+
+		sBucket := tx.Bucket(statesBucket)
+
+		if sBucket == nil {
+			return errors.New("Couldn't open states bucket")
+		}
+
+		for _, version := range versions {
+			if err := sBucket.Delete(keyForState(gameId, version)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+//deleteWithPrefix deletes every key in bucket that starts with prefix.
+func deleteWithPrefix(bucket *bolt.Bucket, prefix []byte) error {
+
+	var keys [][]byte
+
+	c := bucket.Cursor()
+
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+
+	for _, k := range keys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//DeleteGame deletes the game and everything stored for it.
+func (s *StorageManager) DeleteGame(gameId string) error {
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+
+		activity, created, userGames, err := indexBuckets(tx)
+
+		if err != nil {
+			return err
+		}
+
+		//Read everything the indexes are keyed on in this transaction, so a
+		//save that lands between reading and deleting can't leave stale
+		//index entries behind.
+		rawGame := tx.Bucket(gamesBucket).Get(keyForGame(gameId))
+
+		if rawGame == nil {
+			return boardgame.ErrGameNotFound
+		}
+
+		var game boardgame.GameStorageRecord
+
+		if err := json.Unmarshal(rawGame, &game); err != nil {
+			return errors.New("Couldn't deserialize game: " + err.Error())
+		}
+
+		var eGame *extendedgame.StorageRecord
+
+		if rawEGame := tx.Bucket(extendedGamesBucket).Get(keyForGame(gameId)); rawEGame != nil {
+			if err := json.Unmarshal(rawEGame, &eGame); err != nil {
+				return errors.New("Couldn't deserialize extended game: " + err.Error())
+			}
+		}
+
+		var userIds []string
+
+		if rawIds := tx.Bucket(gameUsersBucket).Get(keyForGame(gameId)); rawIds != nil {
+			if err := json.Unmarshal(rawIds, &userIds); err != nil {
+				return errors.New("Couldn't deserialize game users: " + err.Error())
+			}
+		}
+
+		if eGame != nil {
+			if err := activity.Delete(activityIndexKey(eGame.LastActivity, game.Id)); err != nil {
+				return err
+			}
+		}
+
+		if err := created.Delete(createdIndexKey(game.Created, game.Id)); err != nil {
+			return err
+		}
+
+		for _, userId := range userIds {
+			if userId == "" {
+				continue
+			}
+			if err := userGames.Delete(userGameKey(userId, game.Id)); err != nil {
+				return err
+			}
+		}
+
+		for _, name := range [][]byte{gamesBucket, extendedGamesBucket, gameUsersBucket} {
+			bucket := tx.Bucket(name)
+			if bucket == nil {
+				return errors.New("Couldn't open " + string(name) + " bucket")
+			}
+			if err := bucket.Delete(keyForGame(game.Id)); err != nil {
+				return err
+			}
+		}
+
+		//States, moves and agent states are keyed by the id as it was
+		//originally saved, not uppercased.
+		for _, bucketAndPrefix := range []struct {
+			name   []byte
+			prefix string
+		}{
+			{statesBucket, game.Id + "_"},
+			{movesBucket, game.Id + "_"},
+			{agentStatesBucket, game.Id + "-"},
+		} {
+			bucket := tx.Bucket(bucketAndPrefix.name)
+			if bucket == nil {
+				return errors.New("Couldn't open " + string(bucketAndPrefix.name) + " bucket")
+			}
+			if err := deleteWithPrefix(bucket, []byte(bucketAndPrefix.prefix)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+//OrphanedAgentStates returns the ids of the games that have agent states
+//stored but no longer exist.
+func (s *StorageManager) OrphanedAgentStates() ([]string, error) {
+
+	var result []string
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+
+		aBucket := tx.Bucket(agentStatesBucket)
+
+		if aBucket == nil {
+			return errors.New("Couldn't open agent states bucket")
+		}
+
+		gBucket := tx.Bucket(gamesBucket)
+
+		if gBucket == nil {
+			return errors.New("Couldn't open games bucket")
+		}
+
+		seen := make(map[string]bool)
+
+		c := aBucket.Cursor()
+
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			key := string(k)
+			gameId := key[:strings.LastIndex(key, "-")]
+			if seen[gameId] {
+				continue
+			}
+			seen[gameId] = true
+			if gBucket.Get(keyForGame(gameId)) == nil {
+				result = append(result, gameId)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+//DeleteAgentStates deletes every agent state stored for the game.
+func (s *StorageManager) DeleteAgentStates(gameId string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+
+		aBucket := tx.Bucket(agentStatesBucket)
+
+		if aBucket == nil {
+			return errors.New("Couldn't open agent states bucket")
+		}
+
+		return deleteWithPrefix(aBucket, []byte(gameId+"-"))
+	})
+}
+
+//OrphanedCookies returns the cookies connected to users that no longer
+//exist.
+func (s *StorageManager) OrphanedCookies() ([]string, error) {
+
+	var result []string
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+
+		cBucket := tx.Bucket(cookiesBucket)
+
+		if cBucket == nil {
+			return errors.New("Couldn't open cookies bucket")
+		}
+
+		uBucket := tx.Bucket(usersBucket)
+
+		if uBucket == nil {
+			return errors.New("Couldn't open users bucket")
+		}
+
+		return cBucket.ForEach(func(k, v []byte) error {
+			if uBucket.Get(v) == nil {
+				result = append(result, string(k))
+			}
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+//ListUsers returns every user, for example so storage/archive can export
+//them.
+func (s *StorageManager) ListUsers() ([]*users.StorageRecord, error) {
+
+	var result []*users.StorageRecord
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+
+		uBucket := tx.Bucket(usersBucket)
+
+		if uBucket == nil {
+			return errors.New("Couldn't open users bucket")
+		}
+
+		return uBucket.ForEach(func(k, v []byte) error {
+			var user users.StorageRecord
+			if err := json.Unmarshal(v, &user); err != nil {
+				return errors.New("Couldn't unmarshal user: " + err.Error())
+			}
+			result = append(result, &user)
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+//ListCookies returns every cookie, mapped to the id of its user.
+func (s *StorageManager) ListCookies() (map[string]string, error) {
+
+	result := make(map[string]string)
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+
+		cBucket := tx.Bucket(cookiesBucket)
+
+		if cBucket == nil {
+			return errors.New("Couldn't open cookies bucket")
+		}
+
+		return cBucket.ForEach(func(k, v []byte) error {
+			result[string(k)] = string(v)
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
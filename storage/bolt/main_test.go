@@ -1,14 +1,207 @@
 package bolt
 
 import (
-	"github.com/jkomoros/boardgame/storage/internal/test"
+	"github.com/boltdb/bolt"
+	"github.com/jkomoros/boardgame/examples/tictactoe"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"github.com/jkomoros/boardgame/server/api/query"
+	"github.com/jkomoros/boardgame/server/api/users"
+	"github.com/jkomoros/boardgame/storage/storagetest"
+	"github.com/workfit/tester/assert"
+	"sync"
 	"testing"
 )
 
 func TestStorageManager(t *testing.T) {
 
-	test.Test(func() test.StorageManager {
+	storagetest.Test(func() storagetest.StorageManager {
 		return NewStorageManager(".testdb")
 	}, "bolt", "", t)
 
 }
+
+func TestBuildIndexes(t *testing.T) {
+
+	storage := NewStorageManager(".testdb")
+
+	defer storage.CleanUp()
+
+	manager, err := tictactoe.NewManager(storage)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	user := &users.StorageRecord{Id: "USER"}
+
+	assert.For(t).ThatActual(storage.UpdateUser(user)).IsNil()
+
+	var ids []string
+
+	for i := 0; i < 3; i++ {
+		game := manager.NewGame()
+		assert.For(t, i).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+		ids = append([]string{game.Id()}, ids...)
+	}
+
+	assert.For(t).ThatActual(storage.SetPlayerForGame(ids[1], 0, user.Id)).IsNil()
+
+	//Simulate a database from before the indexes existed.
+	err = storage.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{activityIndexBucket, createdIndexBucket, userGamesBucket} {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	storage.Close()
+
+	storage = NewStorageManager(".testdb")
+
+	assert.For(t).ThatActual(storage).IsNotNil()
+
+	defer storage.Close()
+
+	result, err := storage.QueryGames(&query.Query{})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	var resultIds []string
+
+	for _, game := range result.Games {
+		resultIds = append(resultIds, game.Id)
+	}
+
+	assert.For(t).ThatActual(resultIds).Equals(ids)
+
+	result, err = storage.QueryGames(&query.Query{UserId: user.Id})
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(len(result.Games)).Equals(1)
+	assert.For(t).ThatActual(result.Games[0].Id).Equals(ids[1])
+
+}
+
+func TestIndexesUnderConcurrency(t *testing.T) {
+
+	storage := NewStorageManager(".testdb")
+
+	defer storage.Close()
+	defer storage.CleanUp()
+
+	manager, err := tictactoe.NewManager(storage)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	userIds := []string{"USERA", "USERB", "USERC", "USERD", "USERE", "USERF", "USERG", "USERH"}
+
+	for _, id := range userIds {
+		assert.For(t, id).ThatActual(storage.UpdateUser(&users.StorageRecord{Id: id})).IsNil()
+	}
+
+	var wg sync.WaitGroup
+
+	var lock sync.Mutex
+	var seated []string
+
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			record, _ := storage.Game(game.Id())
+			storage.SaveGameAndCurrentState(record, []byte("{}"), nil)
+		}()
+		go func() {
+			defer wg.Done()
+			eGame, _ := storage.ExtendedGame(game.Id())
+			eGame.Open = !eGame.Open
+			storage.UpdateExtendedGame(game.Id(), eGame)
+		}()
+	}
+
+	for _, id := range userIds {
+		wg.Add(1)
+		go func(userId string) {
+			defer wg.Done()
+			if storage.SetPlayerForGame(game.Id(), 0, userId) == nil {
+				lock.Lock()
+				seated = append(seated, userId)
+				lock.Unlock()
+			}
+		}(id)
+	}
+
+	wg.Wait()
+
+	assert.For(t).ThatActual(len(seated)).Equals(1)
+
+	var activityKeys int
+	var userGameKeys int
+
+	err = storage.db.View(func(tx *bolt.Tx) error {
+		activity, _, userGames, err := indexBuckets(tx)
+		if err != nil {
+			return err
+		}
+		activity.ForEach(func(k, v []byte) error {
+			activityKeys++
+			return nil
+		})
+		userGames.ForEach(func(k, v []byte) error {
+			userGameKeys++
+			return nil
+		})
+		return nil
+	})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	//Only the latest activity and seated user are indexed.
+	assert.For(t).ThatActual(activityKeys).Equals(1)
+	assert.For(t).ThatActual(userGameKeys).Equals(1)
+
+	result, err := storage.QueryGames(&query.Query{})
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(len(result.Games)).Equals(1)
+
+}
+
+func TestChangesPruned(t *testing.T) {
+
+	storage := NewStorageManager(".testdb")
+
+	defer storage.CleanUp()
+
+	total := changes.MaxRetained + 10
+
+	err := storage.db.Update(func(tx *bolt.Tx) error {
+		for i := 0; i < total; i++ {
+			if err := recordChange(tx, "PRUNE", i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	latest, err := storage.ChangesSince(changes.Latest)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(latest.Cursor).Equals(int64(total))
+
+	//A cursor from before the pruned changes starts at the oldest one left.
+	result, err := storage.ChangesSince(0)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(result.Changes[0].Version).Equals(10)
+	assert.For(t).ThatActual(result.Changes[0].Cursor).Equals(int64(11))
+
+}
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+)
+
+//Stats are the hit and miss counts for one of a StorageManager's caches.
+type Stats struct {
+	Hits   int64
+	Misses int64
+	//Entries is the number of items currently in the cache.
+	Entries int
+}
+
+//HitRate returns the fraction of lookups that were hits, or 0 if there
+//haven't been any.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+//lru is a fixed size, least recently used cache that is safe for concurrent
+//use.
+type lru struct {
+	maxEntries int
+	items      map[string]*list.Element
+	order      *list.List
+	hits       int64
+	misses     int64
+	lock       sync.Mutex
+}
+
+func newLRU(maxEntries int) *lru {
+	return &lru{
+		maxEntries: maxEntries,
+		items:      make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+//Get returns the value stored for key, marking it as recently used.
+func (l *lru) Get(key string) (interface{}, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	element, ok := l.items[key]
+
+	if !ok {
+		l.misses++
+		return nil, false
+	}
+
+	l.hits++
+	l.order.MoveToFront(element)
+
+	return element.Value.(*lruEntry).value, true
+}
+
+//Add stores value for key, evicting the least recently used item if the
+//cache is full.
+func (l *lru) Add(key string, value interface{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.add(key, value)
+}
+
+//add is Add for callers that already hold the lock.
+func (l *lru) add(key string, value interface{}) {
+
+	if element, ok := l.items[key]; ok {
+		element.Value.(*lruEntry).value = value
+		l.order.MoveToFront(element)
+		return
+	}
+
+	l.items[key] = l.order.PushFront(&lruEntry{key, value})
+
+	for l.order.Len() > l.maxEntries {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+//AddUnlessNewer is like Add, but if key is already cached it only replaces
+//the cached value if newer(cached) returns false. It's for values read from
+//the Backend on a miss, which a concurrent save might have cached a newer
+//version of in the meantime.
+func (l *lru) AddUnlessNewer(key string, value interface{}, newer func(cached interface{}) bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if element, ok := l.items[key]; ok && newer(element.Value.(*lruEntry).value) {
+		return
+	}
+
+	l.add(key, value)
+}
+
+//Remove drops key from the cache, if it's there.
+func (l *lru) Remove(key string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if element, ok := l.items[key]; ok {
+		l.order.Remove(element)
+		delete(l.items, key)
+	}
+}
+
+//RemovePrefix drops every key that starts with prefix from the cache.
+func (l *lru) RemovePrefix(prefix string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for key, element := range l.items {
+		if strings.HasPrefix(key, prefix) {
+			l.order.Remove(element)
+			delete(l.items, key)
+		}
+	}
+}
+
+func (l *lru) Stats() Stats {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return Stats{
+		Hits:    l.hits,
+		Misses:  l.misses,
+		Entries: l.order.Len(),
+	}
+}
@@ -0,0 +1,387 @@
+/*
+
+cache wraps another storage manager with read-through, least recently used
+caches of states, moves and game records. It's most useful in front of
+storage managers where every read is a round trip, like mysql.
+
+	storage := cache.NewStorageManager(mysql.NewStorageManager(false), 0)
+
+Game ids are treated case-insensitively, as the persistent storage managers
+do. Everything other than states, moves and game records, including
+extended games, is passed straight through to the wrapped storage manager.
+
+The methods that change states and game records after they're saved
+(ReplaceGame, ReplaceState, and the storage/retention methods DeleteStates
+and DeleteGame) update or invalidate the cache as they pass through, so
+storage/encrypted key rotation, storage/archive imports and storage/retention
+should be run against the StorageManager rather than the storage manager it
+wraps. The cache can't see writes that don't go through it, for example from
+another process sharing the same database, so it should only be used by the
+single process that writes to its storage, or that process must call
+Invalidate for each game that is changed elsewhere.
+
+*/
+package cache
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/storage"
+	"github.com/jkomoros/boardgame/storage/retention"
+	"strconv"
+	"strings"
+)
+
+//DefaultMaxEntries is the size of each cache if 0 is passed to
+//NewStorageManager. The caches are bounded only by their number of entries,
+//not by how many bytes those entries take up, so in games with large states
+//the states cache can use far more memory than the others; pick maxEntries
+//with the size of your states in mind.
+const DefaultMaxEntries = 1000
+
+//Backend is the interface of the storage managers that can be wrapped.
+type Backend interface {
+	storage.Manager
+}
+
+//StorageManager caches the states, moves and game records read from the
+//Backend it wraps, and passes everything else straight through.
+type StorageManager struct {
+	Backend
+	states *lru
+	moves  *lru
+	games  *lru
+}
+
+//CacheStats are the Stats for each of a StorageManager's caches.
+type CacheStats struct {
+	States Stats
+	Moves  Stats
+	Games  Stats
+}
+
+//NewStorageManager returns a StorageManager that keeps up to maxEntries
+//states, maxEntries moves and maxEntries game records from backend in
+//memory, however large they are. If maxEntries is 0, DefaultMaxEntries is
+//used.
+func NewStorageManager(backend Backend, maxEntries int) *StorageManager {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &StorageManager{
+		Backend: backend,
+		states:  newLRU(maxEntries),
+		moves:   newLRU(maxEntries),
+		games:   newLRU(maxEntries),
+	}
+}
+
+//Stats returns the hit and miss counts of each cache since the
+//StorageManager was created.
+func (s *StorageManager) Stats() CacheStats {
+	return CacheStats{
+		States: s.states.Stats(),
+		Moves:  s.moves.Stats(),
+		Games:  s.games.Stats(),
+	}
+}
+
+func keyForGame(gameId string) string {
+	return strings.ToUpper(gameId)
+}
+
+func keyForVersion(gameId string, version int) string {
+	return keyForGame(gameId) + "_" + strconv.Itoa(version)
+}
+
+//copyGame returns a copy of game that shares no slices, maps or pointers
+//with it, so that callers can't modify the cached record.
+func copyGame(game *boardgame.GameStorageRecord) *boardgame.GameStorageRecord {
+	result := *game
+	result.Winners = append([]boardgame.PlayerIndex(nil), game.Winners...)
+	result.Agents = append([]string(nil), game.Agents...)
+
+	if game.Result != nil {
+		gameResult := *game.Result
+		gameResult.Players = make([]*boardgame.PlayerResult, len(game.Result.Players))
+		for i, player := range game.Result.Players {
+			if player == nil {
+				continue
+			}
+			playerResult := *player
+			gameResult.Players[i] = &playerResult
+		}
+		result.Result = &gameResult
+	}
+
+	if game.Components != nil {
+		result.Components = make(boardgame.ComponentSelection, len(game.Components))
+		for deckName, indexes := range game.Components {
+			result.Components[deckName] = append([]int(nil), indexes...)
+		}
+	}
+
+	return &result
+}
+
+func copyMove(move *boardgame.MoveStorageRecord) *boardgame.MoveStorageRecord {
+	result := *move
+	result.Blob = append([]byte(nil), move.Blob...)
+	return &result
+}
+
+func copyState(state boardgame.StateStorageRecord) boardgame.StateStorageRecord {
+	return append(boardgame.StateStorageRecord(nil), state...)
+}
+
+func (s *StorageManager) State(gameId string, version int) (boardgame.StateStorageRecord, error) {
+
+	key := keyForVersion(gameId, version)
+
+	if cached, ok := s.states.Get(key); ok {
+		return copyState(cached.(boardgame.StateStorageRecord)), nil
+	}
+
+	record, err := s.Backend.State(gameId, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.states.Add(key, copyState(record))
+
+	return record, nil
+}
+
+func (s *StorageManager) Move(gameId string, version int) (*boardgame.MoveStorageRecord, error) {
+
+	key := keyForVersion(gameId, version)
+
+	if cached, ok := s.moves.Get(key); ok {
+		return copyMove(cached.(*boardgame.MoveStorageRecord)), nil
+	}
+
+	record, err := s.Backend.Move(gameId, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.moves.Add(key, copyMove(record))
+
+	return record, nil
+}
+
+//Moves returns the moves from the cache if they're all in it, and otherwise
+//fetches all of them from the Backend and caches them.
+func (s *StorageManager) Moves(gameId string, fromVersion, toVersion int) ([]*boardgame.MoveStorageRecord, error) {
+
+	first := fromVersion + 1
+
+	if fromVersion == toVersion {
+		first = toVersion
+	}
+
+	var result []*boardgame.MoveStorageRecord
+
+	for version := first; version <= toVersion; version++ {
+		cached, ok := s.moves.Get(keyForVersion(gameId, version))
+		if !ok {
+			result = nil
+			break
+		}
+		result = append(result, copyMove(cached.(*boardgame.MoveStorageRecord)))
+	}
+
+	if result != nil {
+		return result, nil
+	}
+
+	records, err := s.Backend.Moves(gameId, fromVersion, toVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		s.moves.Add(keyForVersion(gameId, record.Version), copyMove(record))
+	}
+
+	return records, nil
+}
+
+func (s *StorageManager) Game(id string) (*boardgame.GameStorageRecord, error) {
+
+	key := keyForGame(id)
+
+	if cached, ok := s.games.Get(key); ok {
+		return copyGame(cached.(*boardgame.GameStorageRecord)), nil
+	}
+
+	record, err := s.Backend.Game(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	//A save may have cached a newer record while this one was being read.
+	s.games.AddUnlessNewer(key, copyGame(record), func(cached interface{}) bool {
+		return cached.(*boardgame.GameStorageRecord).Version >= record.Version
+	})
+
+	return record, nil
+}
+
+//SaveGameAndCurrentState saves to the Backend, and then caches game, state
+//and move, since they're likely to be read again soon.
+func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageRecord, state boardgame.StateStorageRecord, move *boardgame.MoveStorageRecord) error {
+
+	if err := s.Backend.SaveGameAndCurrentState(game, state, move); err != nil {
+		if game != nil {
+			//We don't know how much of the game made it to the Backend.
+			s.games.Remove(keyForGame(game.Id))
+		}
+		return err
+	}
+
+	s.games.Add(keyForGame(game.Id), copyGame(game))
+	s.states.Add(keyForVersion(game.Id, game.Version), copyState(state))
+
+	if move != nil {
+		s.moves.Add(keyForVersion(game.Id, game.Version), copyMove(move))
+	}
+
+	return nil
+}
+
+//Invalidate drops everything cached for the game, so that it's read from
+//the Backend again. It's only necessary for changes that were made without
+//going through the StorageManager.
+func (s *StorageManager) Invalidate(gameId string) {
+	s.games.Remove(keyForGame(gameId))
+	s.states.RemovePrefix(keyForGame(gameId) + "_")
+	s.moves.RemovePrefix(keyForGame(gameId) + "_")
+}
+
+//ReplaceGame passes through to the Backend, which must be able to replace
+//games, and then caches the new record.
+func (s *StorageManager) ReplaceGame(game *boardgame.GameStorageRecord) error {
+
+	replacer, ok := s.Backend.(interface {
+		ReplaceGame(game *boardgame.GameStorageRecord) error
+	})
+
+	if !ok {
+		return errors.New("The wrapped storage manager doesn't support replacing games")
+	}
+
+	if err := replacer.ReplaceGame(game); err != nil {
+		s.games.Remove(keyForGame(game.Id))
+		return err
+	}
+
+	s.games.Add(keyForGame(game.Id), copyGame(game))
+
+	return nil
+}
+
+//ReplaceState passes through to the Backend, which must be able to replace
+//states, and then caches the new state.
+func (s *StorageManager) ReplaceState(gameId string, version int, state boardgame.StateStorageRecord) error {
+
+	replacer, ok := s.Backend.(interface {
+		ReplaceState(gameId string, version int, state boardgame.StateStorageRecord) error
+	})
+
+	if !ok {
+		return errors.New("The wrapped storage manager doesn't support replacing states")
+	}
+
+	key := keyForVersion(gameId, version)
+
+	if err := replacer.ReplaceState(gameId, version, state); err != nil {
+		s.states.Remove(key)
+		return err
+	}
+
+	s.states.Add(key, copyState(state))
+
+	return nil
+}
+
+//retentionStorage returns the Backend as a retention.Storage, or an error
+//if it doesn't support retention.
+func (s *StorageManager) retentionStorage() (retention.Storage, error) {
+	storage, ok := s.Backend.(retention.Storage)
+	if !ok {
+		return nil, errors.New("The wrapped storage manager doesn't support retention")
+	}
+	return storage, nil
+}
+
+func (s *StorageManager) StateVersions(gameId string) ([]int, error) {
+	storage, err := s.retentionStorage()
+	if err != nil {
+		return nil, err
+	}
+	return storage.StateVersions(gameId)
+}
+
+//DeleteStates passes through to the Backend and drops the deleted states
+//from the cache.
+func (s *StorageManager) DeleteStates(gameId string, versions []int) error {
+	storage, err := s.retentionStorage()
+	if err != nil {
+		return err
+	}
+	err = storage.DeleteStates(gameId, versions)
+	for _, version := range versions {
+		s.states.Remove(keyForVersion(gameId, version))
+	}
+	return err
+}
+
+//DeleteGame passes through to the Backend and drops everything cached for
+//the game.
+func (s *StorageManager) DeleteGame(gameId string) error {
+	storage, err := s.retentionStorage()
+	if err != nil {
+		return err
+	}
+	err = storage.DeleteGame(gameId)
+	s.Invalidate(gameId)
+	return err
+}
+
+func (s *StorageManager) OrphanedAgentStates() ([]string, error) {
+	storage, err := s.retentionStorage()
+	if err != nil {
+		return nil, err
+	}
+	return storage.OrphanedAgentStates()
+}
+
+func (s *StorageManager) DeleteAgentStates(gameId string) error {
+	storage, err := s.retentionStorage()
+	if err != nil {
+		return err
+	}
+	return storage.DeleteAgentStates(gameId)
+}
+
+func (s *StorageManager) OrphanedCookies() ([]string, error) {
+	storage, err := s.retentionStorage()
+	if err != nil {
+		return nil, err
+	}
+	return storage.OrphanedCookies()
+}
+
+//CleanUp passes through to the Backend, if it has a CleanUp method.
+func (s *StorageManager) CleanUp() {
+	if cleaner, ok := s.Backend.(interface {
+		CleanUp()
+	}); ok {
+		cleaner.CleanUp()
+	}
+}
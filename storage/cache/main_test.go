@@ -0,0 +1,292 @@
+package cache
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/examples/tictactoe"
+	"github.com/jkomoros/boardgame/storage/memory"
+	"github.com/jkomoros/boardgame/storage/storagetest"
+	"github.com/workfit/tester/assert"
+	"strings"
+	"testing"
+)
+
+func TestStorageManager(t *testing.T) {
+
+	storagetest.Test(func() storagetest.StorageManager {
+		return NewStorageManager(memory.NewStorageManager(), 0)
+	}, "memory", "", t)
+
+}
+
+func TestLRU(t *testing.T) {
+
+	cache := newLRU(2)
+
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+
+	_, ok := cache.Get("a")
+	assert.For(t).ThatActual(ok).IsTrue()
+
+	//b is now the least recently used, so it's evicted.
+	cache.Add("c", 3)
+
+	_, ok = cache.Get("b")
+	assert.For(t).ThatActual(ok).IsFalse()
+
+	val, ok := cache.Get("a")
+	assert.For(t).ThatActual(ok).IsTrue()
+	assert.For(t).ThatActual(val).Equals(1)
+
+	cache.Add("a", 4)
+
+	val, _ = cache.Get("a")
+	assert.For(t).ThatActual(val).Equals(4)
+
+	isNewer := func(cached interface{}) bool {
+		return cached.(int) >= 4
+	}
+
+	//a already has a value at least as new, so it's kept.
+	cache.AddUnlessNewer("a", 3, isNewer)
+
+	val, _ = cache.Get("a")
+	assert.For(t).ThatActual(val).Equals(4)
+
+	cache.AddUnlessNewer("a", 5, func(cached interface{}) bool {
+		return cached.(int) >= 5
+	})
+
+	val, _ = cache.Get("a")
+	assert.For(t).ThatActual(val).Equals(5)
+
+	cache.Remove("a")
+
+	_, ok = cache.Get("a")
+	assert.For(t).ThatActual(ok).IsFalse()
+
+	stats := cache.Stats()
+
+	assert.For(t).ThatActual(stats.Hits).Equals(int64(5))
+	assert.For(t).ThatActual(stats.Misses).Equals(int64(2))
+	assert.For(t).ThatActual(stats.Entries).Equals(1)
+	assert.For(t).ThatActual(stats.HitRate()).Equals(5.0 / 7.0)
+
+}
+
+func TestCaching(t *testing.T) {
+
+	backend := memory.NewStorageManager()
+
+	storage := NewStorageManager(backend, 0)
+
+	manager, err := tictactoe.NewManager(storage)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	//Saving the game primes the caches.
+	record, err := storage.Game(game.Id())
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(record.Version).Equals(game.Version())
+	assert.For(t).ThatActual(storage.Stats().Games.Hits).Equals(int64(1))
+
+	move := game.PlayerMoveByName("Place Token")
+
+	assert.For(t).ThatActual(<-game.ProposeMove(move, boardgame.AdminPlayerIndex)).IsNil()
+
+	//The cached game record was replaced when the move was saved.
+	record, err = storage.Game(strings.ToLower(game.Id()))
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(record.Version).Equals(game.Version())
+
+	//Modifying a returned record doesn't change the cached one.
+	record.Version = 100
+
+	record, _ = storage.Game(game.Id())
+
+	assert.For(t).ThatActual(record.Version).Equals(game.Version())
+
+	before := storage.Stats()
+
+	for i := 0; i < 2; i++ {
+		state, err := storage.State(game.Id(), 0)
+		assert.For(t, i).ThatActual(err).IsNil()
+		expected, _ := backend.State(game.Id(), 0)
+		assert.For(t, i).ThatActual(state).Equals(expected)
+	}
+
+	after := storage.Stats()
+
+	assert.For(t).ThatActual(after.States.Hits - before.States.Hits).Equals(int64(2))
+	assert.For(t).ThatActual(after.States.Misses).Equals(before.States.Misses)
+
+	//Modifying a returned state or move doesn't change the cached one.
+	state, _ := storage.State(game.Id(), 0)
+	state[0] = 'X'
+
+	state, _ = storage.State(game.Id(), 0)
+	expectedState, _ := backend.State(game.Id(), 0)
+	assert.For(t).ThatActual(state).Equals(expectedState)
+
+	cachedMove, err := storage.Move(game.Id(), 1)
+	assert.For(t).ThatActual(err).IsNil()
+	cachedMove.Blob[0] = 'X'
+
+	cachedMove, _ = storage.Move(game.Id(), 1)
+	expectedMove, _ := backend.Move(game.Id(), 1)
+	assert.For(t).ThatActual(cachedMove.Blob).Equals(expectedMove.Blob)
+
+	moves, err := storage.Moves(game.Id(), 0, game.Version())
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(len(moves)).Equals(game.Version())
+
+	expectedMoves, _ := backend.Moves(game.Id(), 0, game.Version())
+
+	assert.For(t).ThatActual(moves).Equals(expectedMoves)
+	assert.For(t).ThatActual(storage.Stats().Moves.Misses).Equals(int64(0))
+
+	//Misses aren't cached.
+	_, err = storage.State(game.Id(), 100)
+
+	assert.For(t).ThatActual(err).Equals(boardgame.ErrVersionNotFound)
+
+	_, err = storage.Game("missing")
+
+	assert.For(t).ThatActual(err).Equals(boardgame.ErrGameNotFound)
+	assert.For(t).ThatActual(storage.Stats().Games.Entries).Equals(1)
+
+}
+
+func TestInvalidation(t *testing.T) {
+
+	backend := memory.NewStorageManager()
+
+	storage := NewStorageManager(backend, 0)
+
+	manager, err := tictactoe.NewManager(storage)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	record, _ := storage.Game(game.Id())
+
+	record.Result = &boardgame.GameResult{
+		Players: []*boardgame.PlayerResult{{Player: 0, Rank: 1}},
+	}
+	record.Components = boardgame.ComponentSelection{"tokens": {0, 1}}
+
+	assert.For(t).ThatActual(storage.ReplaceGame(record)).IsNil()
+
+	//Modifying a returned record's result or components doesn't change the
+	//cached one.
+	record, _ = storage.Game(game.Id())
+
+	record.Result.Players[0].Rank = 2
+	record.Components["tokens"][0] = 5
+
+	record, _ = storage.Game(game.Id())
+
+	assert.For(t).ThatActual(record.Result.Players[0].Rank).Equals(1)
+	assert.For(t).ThatActual(record.Components["tokens"]).Equals([]int{0, 1})
+
+	replacement := boardgame.StateStorageRecord(`{"Replaced":true}`)
+
+	assert.For(t).ThatActual(storage.ReplaceState(game.Id(), 0, replacement)).IsNil()
+
+	state, err := storage.State(game.Id(), 0)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(state).Equals(replacement)
+
+	//Changes made behind the cache's back are only seen once the game is
+	//invalidated.
+	external := boardgame.StateStorageRecord(`{"External":true}`)
+
+	assert.For(t).ThatActual(backend.ReplaceState(game.Id(), 0, external)).IsNil()
+
+	state, _ = storage.State(game.Id(), 0)
+
+	assert.For(t).ThatActual(state).Equals(replacement)
+
+	storage.Invalidate(game.Id())
+
+	state, _ = storage.State(game.Id(), 0)
+
+	assert.For(t).ThatActual(state).Equals(external)
+
+	assert.For(t).ThatActual(storage.DeleteGame(game.Id())).IsNil()
+
+	_, err = storage.Game(game.Id())
+
+	assert.For(t).ThatActual(err).Equals(boardgame.ErrGameNotFound)
+
+	_, err = storage.State(game.Id(), 0)
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+}
+
+//racingBackend calls beforeReturn after reading a game record but before
+//returning it, to simulate a save that lands in the meantime.
+type racingBackend struct {
+	*memory.StorageManager
+	beforeReturn func()
+}
+
+func (r *racingBackend) Game(id string) (*boardgame.GameStorageRecord, error) {
+	record, err := r.StorageManager.Game(id)
+	if r.beforeReturn != nil {
+		r.beforeReturn()
+	}
+	return record, err
+}
+
+func TestStaleGameRead(t *testing.T) {
+
+	backend := &racingBackend{StorageManager: memory.NewStorageManager()}
+
+	manager, err := tictactoe.NewManager(backend)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	storage := NewStorageManager(backend, 0)
+
+	stale, err := backend.StorageManager.Game(game.Id())
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	newer := *stale
+	newer.Version++
+
+	//A save caches a newer record after the miss read the old one.
+	backend.beforeReturn = func() {
+		storage.games.Add(keyForGame(game.Id()), copyGame(&newer))
+	}
+
+	record, err := storage.Game(game.Id())
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(record.Version).Equals(stale.Version)
+
+	backend.beforeReturn = nil
+
+	record, err = storage.Game(game.Id())
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(record.Version).Equals(newer.Version)
+
+}
@@ -0,0 +1,341 @@
+/*
+
+delta wraps another storage manager to store most states as a JSON Patch
+against a recent full state, instead of as a complete state blob per version.
+States change very little from one version to the next, so for long games
+this cuts the space used by states dramatically.
+
+Every KeyframeInterval versions (and version 0) the full state is stored, as
+a keyframe. Every other version is stored as the patch from the most recent
+keyframe to it, so State never needs more than two reads from the wrapped
+storage manager to reconstruct a state. Callers of State get the full state
+back, so the encoding is invisible to the rest of the engine and to the
+server. A reconstructed state isn't byte for byte what was saved, though: it
+is compact JSON with its keys sorted. It decodes to exactly the same values,
+which is all the engine relies on. Keyframes, and states that weren't worth
+storing as a delta, are returned with their original bytes.
+
+Full states are valid keyframes, so wrapping a storage manager that already
+has games in it works without any conversion. To reclaim the space used by
+those games, call Migrate, or MigrateGame on each of them.
+
+	storage := delta.NewStorageManager(bolt.NewStorageManager(".database"), 0)
+
+*/
+package delta
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/storage"
+	"math"
+	"sort"
+	"strconv"
+)
+
+//DefaultKeyframeInterval is the KeyframeInterval used if 0 is passed to
+//NewStorageManager.
+const DefaultKeyframeInterval = 20
+
+//Backend is the interface of the storage managers that can be wrapped.
+type Backend interface {
+	storage.Manager
+}
+
+//StateReplacer is implemented by storage managers that can overwrite a state
+//that has already been saved. MigrateGame requires it.
+type StateReplacer interface {
+	ReplaceState(gameId string, version int, state boardgame.StateStorageRecord) error
+}
+
+//StorageManager delta-encodes the states it saves to the Backend it wraps,
+//and passes everything else straight through.
+type StorageManager struct {
+	Backend
+	keyframeInterval int
+}
+
+//deltaRecord is what a delta-encoded state looks like in storage. Its keys
+//don't overlap with any of a state's, so the two can't be confused.
+type deltaRecord struct {
+	DeltaBase  int
+	DeltaPatch []patchOp
+}
+
+//NewStorageManager returns a StorageManager that stores a keyframe every
+//keyframeInterval versions in backend. If keyframeInterval is 0,
+//DefaultKeyframeInterval is used; if it's 1, every state is stored whole.
+func NewStorageManager(backend Backend, keyframeInterval int) *StorageManager {
+	if keyframeInterval <= 0 {
+		keyframeInterval = DefaultKeyframeInterval
+	}
+	return &StorageManager{
+		Backend:          backend,
+		keyframeInterval: keyframeInterval,
+	}
+}
+
+//KeyframeInterval returns how often full states are stored.
+func (s *StorageManager) KeyframeInterval() int {
+	return s.keyframeInterval
+}
+
+//parseDelta returns the delta encoded in record, or false if record is a
+//full state.
+func parseDelta(record boardgame.StateStorageRecord) (*deltaRecord, bool) {
+	var probe map[string]json.RawMessage
+
+	if err := json.Unmarshal(record, &probe); err != nil {
+		return nil, false
+	}
+
+	if _, ok := probe["DeltaPatch"]; !ok {
+		return nil, false
+	}
+
+	//Decode numbers in the patch as json.Number, so that large ones don't
+	//lose precision as float64s.
+	decoder := json.NewDecoder(bytes.NewReader(record))
+	decoder.UseNumber()
+
+	var result deltaRecord
+
+	if err := decoder.Decode(&result); err != nil {
+		return nil, false
+	}
+
+	return &result, true
+}
+
+//State returns the full state at version, reconstructing it from its
+//keyframe if it was stored as a delta.
+func (s *StorageManager) State(gameId string, version int) (boardgame.StateStorageRecord, error) {
+
+	record, err := s.Backend.State(gameId, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	delta, ok := parseDelta(record)
+
+	if !ok {
+		return record, nil
+	}
+
+	base, err := s.Backend.State(gameId, delta.DeltaBase)
+
+	if err != nil {
+		return nil, errors.New("Couldn't fetch keyframe " + strconv.Itoa(delta.DeltaBase) + " for version " + strconv.Itoa(version) + ": " + err.Error())
+	}
+
+	if _, ok := parseDelta(base); ok {
+		return nil, errors.New("The keyframe for version " + strconv.Itoa(version) + " isn't a full state")
+	}
+
+	doc, err := decodeJSON(base)
+
+	if err != nil {
+		return nil, errors.New("Couldn't decode keyframe: " + err.Error())
+	}
+
+	doc, err = applyPatch(doc, delta.DeltaPatch)
+
+	if err != nil {
+		return nil, errors.New("Couldn't apply delta for version " + strconv.Itoa(version) + ": " + err.Error())
+	}
+
+	result, err := json.Marshal(doc)
+
+	if err != nil {
+		return nil, errors.New("Couldn't encode reconstructed state: " + err.Error())
+	}
+
+	return result, nil
+}
+
+//SaveGameAndCurrentState saves state as a delta from its keyframe unless
+//it's a keyframe itself, or the delta wouldn't be any smaller.
+func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageRecord, state boardgame.StateStorageRecord, move *boardgame.MoveStorageRecord) error {
+	if game == nil {
+		return errors.New("No game provided")
+	}
+
+	return s.Backend.SaveGameAndCurrentState(game, s.encode(game.Id, game.Version, state), move)
+}
+
+//encode returns how the state at version should be stored: either as is,
+//or as a delta from the keyframe before it.
+func (s *StorageManager) encode(gameId string, version int, state boardgame.StateStorageRecord) boardgame.StateStorageRecord {
+
+	if s.keyframeInterval <= 1 || version%s.keyframeInterval == 0 {
+		return state
+	}
+
+	keyframe := version - version%s.keyframeInterval
+
+	base, err := s.Backend.State(gameId, keyframe)
+
+	if err != nil {
+		return state
+	}
+
+	if delta, ok := parseDelta(base); ok {
+		//The game was saved with a different interval. Patch from that
+		//state's keyframe instead.
+		keyframe = delta.DeltaBase
+		base, err = s.Backend.State(gameId, keyframe)
+		if err != nil {
+			return state
+		}
+		if _, ok := parseDelta(base); ok {
+			return state
+		}
+	}
+
+	from, err := decodeJSON(base)
+
+	if err != nil {
+		return state
+	}
+
+	to, err := decodeJSON(state)
+
+	if err != nil {
+		return state
+	}
+
+	encoded, err := json.Marshal(&deltaRecord{
+		DeltaBase:  keyframe,
+		DeltaPatch: diff(from, to),
+	})
+
+	if err != nil || len(encoded) >= len(state) {
+		return state
+	}
+
+	return encoded
+}
+
+//MigrateGame re-encodes the states of the game with the given id the way
+//this StorageManager would have saved them: keyframes whole and every other
+//version as a delta from its keyframe. Keyframes that were stored as a delta
+//are replaced with their reconstructed form, which decodes to the same state
+//but, like every state reconstructed by State, isn't byte for byte the
+//original. It's safe to call on games that have already been migrated. The
+//Backend must implement StateReplacer.
+func (s *StorageManager) MigrateGame(gameId string) error {
+
+	replacer, ok := s.Backend.(StateReplacer)
+
+	if !ok {
+		return errors.New("The wrapped storage manager doesn't support replacing states")
+	}
+
+	game, err := s.Backend.Game(gameId)
+
+	if err != nil {
+		return err
+	}
+
+	//First make sure every keyframe is whole. Replacing a state with its
+	//full form never breaks a delta based on it.
+	for version := 0; version <= game.Version; version += s.keyframeInterval {
+
+		record, err := s.Backend.State(gameId, version)
+
+		if err != nil {
+			return errors.New("Couldn't fetch version " + strconv.Itoa(version) + ": " + err.Error())
+		}
+
+		if _, isDelta := parseDelta(record); !isDelta {
+			continue
+		}
+
+		full, err := s.State(gameId, version)
+
+		if err != nil {
+			return err
+		}
+
+		if err := replacer.ReplaceState(gameId, version, full); err != nil {
+			return errors.New("Couldn't replace version " + strconv.Itoa(version) + ": " + err.Error())
+		}
+	}
+
+	//Then encode everything else against its keyframe. Go from newest to
+	//oldest, because a version that is replaced might be the base of a later
+	//delta from a different interval, which must be re-encoded first.
+	for version := game.Version; version > 0; version-- {
+
+		keyframe := version - version%s.keyframeInterval
+
+		if keyframe == version {
+			continue
+		}
+
+		record, err := s.Backend.State(gameId, version)
+
+		if err != nil {
+			return errors.New("Couldn't fetch version " + strconv.Itoa(version) + ": " + err.Error())
+		}
+
+		if delta, isDelta := parseDelta(record); isDelta && delta.DeltaBase == keyframe {
+			continue
+		}
+
+		full, err := s.State(gameId, version)
+
+		if err != nil {
+			return err
+		}
+
+		encoded := s.encode(gameId, version, full)
+
+		if string(encoded) == string(record) {
+			continue
+		}
+
+		if err := replacer.ReplaceState(gameId, version, encoded); err != nil {
+			return errors.New("Couldn't replace version " + strconv.Itoa(version) + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+//Migrate calls MigrateGame on every game in the Backend. Games that are
+//deleted while it runs are skipped. It stops at the first error.
+func (s *StorageManager) Migrate() error {
+
+	gameIds := make([]string, 0)
+
+	for _, game := range s.Backend.ListGames(math.MaxInt32, listing.All, "", "") {
+		gameIds = append(gameIds, game.Id)
+	}
+
+	sort.Strings(gameIds)
+
+	for _, gameId := range gameIds {
+		if err := s.MigrateGame(gameId); err != nil {
+			if errors.Is(err, boardgame.ErrGameNotFound) {
+				continue
+			}
+			return errors.New("Couldn't migrate game " + gameId + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+//CleanUp passes through to the Backend, if it has a CleanUp method.
+func (s *StorageManager) CleanUp() {
+	if cleaner, ok := s.Backend.(interface {
+		CleanUp()
+	}); ok {
+		cleaner.CleanUp()
+	}
+}
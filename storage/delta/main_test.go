@@ -0,0 +1,279 @@
+package delta
+
+import (
+	"encoding/json"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/examples/tictactoe"
+	"github.com/jkomoros/boardgame/storage/memory"
+	"github.com/jkomoros/boardgame/storage/storagetest"
+	"github.com/workfit/tester/assert"
+	"reflect"
+	"testing"
+)
+
+func TestStorageManager(t *testing.T) {
+
+	storagetest.Test(func() storagetest.StorageManager {
+		return NewStorageManager(memory.NewStorageManager(), 3)
+	}, "memory", "", t)
+
+}
+
+func TestDiff(t *testing.T) {
+
+	tests := []struct {
+		description string
+		from        string
+		to          string
+	}{
+		{
+			"Identical",
+			`{"A":1,"B":[1,2,3]}`,
+			`{"A":1,"B":[1,2,3]}`,
+		},
+		{
+			"Changed number",
+			`{"A":1,"B":{"C":2}}`,
+			`{"A":1,"B":{"C":3}}`,
+		},
+		{
+			"Added and removed keys",
+			`{"A":1,"B":2}`,
+			`{"B":2,"C":{"D":true}}`,
+		},
+		{
+			"Array item",
+			`{"A":[{"B":1},{"B":2}]}`,
+			`{"A":[{"B":1},{"B":5}]}`,
+		},
+		{
+			"Array length",
+			`{"A":[1,2]}`,
+			`{"A":[1,2,3]}`,
+		},
+		{
+			"Escaped keys",
+			`{"a/b":1,"c~d":2}`,
+			`{"a/b":3,"c~d":4}`,
+		},
+		{
+			"Large numbers",
+			`{"A":1234567890123456789}`,
+			`{"A":1234567890123456788}`,
+		},
+		{
+			"Different type",
+			`{"A":[1]}`,
+			`{"A":{"B":1}}`,
+		},
+	}
+
+	for i, test := range tests {
+		from, err := decodeJSON([]byte(test.from))
+		assert.For(t, i, test.description).ThatActual(err).IsNil()
+
+		to, err := decodeJSON([]byte(test.to))
+		assert.For(t, i, test.description).ThatActual(err).IsNil()
+
+		ops := diff(from, to)
+
+		if test.from == test.to {
+			assert.For(t, i, test.description).ThatActual(len(ops)).Equals(0)
+		}
+
+		//Round trip the ops through storage.
+		blob, err := json.Marshal(&deltaRecord{DeltaPatch: ops})
+		assert.For(t, i, test.description).ThatActual(err).IsNil()
+
+		decoded, ok := parseDelta(blob)
+		assert.For(t, i, test.description).ThatActual(ok).IsTrue()
+
+		result, err := applyPatch(from, decoded.DeltaPatch)
+		assert.For(t, i, test.description).ThatActual(err).IsNil()
+
+		resultBlob, err := json.Marshal(result)
+		assert.For(t, i, test.description).ThatActual(err).IsNil()
+
+		expected, _ := decodeJSON([]byte(test.to))
+		actual, _ := decodeJSON(resultBlob)
+
+		assert.For(t, i, test.description).ThatActual(reflect.DeepEqual(actual, expected)).IsTrue()
+	}
+
+}
+
+//playGame makes a tictactoe game in storage and plays a few moves in it.
+func playGame(t *testing.T, storage boardgame.StorageManager) *boardgame.Game {
+
+	manager, err := tictactoe.NewManager(storage)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	for i := 0; i < 4; i++ {
+		move := game.PlayerMoveByName("Place Token")
+		assert.For(t, i).ThatActual(move).IsNotNil()
+		assert.For(t, i).ThatActual(<-game.ProposeMove(move, boardgame.AdminPlayerIndex)).IsNil()
+	}
+
+	return game
+
+}
+
+//assertSameJSON checks that a and b encode the same value, ignoring
+//formatting.
+func assertSameJSON(t *testing.T, version int, a, b []byte) {
+	aVal, err := decodeJSON(a)
+	assert.For(t, version).ThatActual(err).IsNil()
+	bVal, err := decodeJSON(b)
+	assert.For(t, version).ThatActual(err).IsNil()
+	assert.For(t, version).ThatActual(reflect.DeepEqual(aVal, bVal)).IsTrue()
+}
+
+func TestKeyframes(t *testing.T) {
+
+	backend := memory.NewStorageManager()
+
+	storage := NewStorageManager(backend, 3)
+
+	game := playGame(t, storage)
+
+	assert.For(t).ThatActual(game.Version() > 3).IsTrue()
+
+	for version := 0; version <= game.Version(); version++ {
+
+		raw, err := backend.State(game.Id(), version)
+		assert.For(t, version).ThatActual(err).IsNil()
+
+		delta, isDelta := parseDelta(raw)
+
+		if version%3 == 0 {
+			assert.For(t, version).ThatActual(isDelta).IsFalse()
+		} else {
+			assert.For(t, version).ThatActual(isDelta).IsTrue()
+			assert.For(t, version).ThatActual(delta.DeltaBase).Equals(version - version%3)
+		}
+
+		decoded, err := storage.State(game.Id(), version)
+		assert.For(t, version).ThatActual(err).IsNil()
+
+		state := game.State(version)
+		assert.For(t, version).ThatActual(state).IsNotNil()
+
+		assertSameJSON(t, version, decoded, state.StorageRecord())
+	}
+
+}
+
+func TestMigrateGame(t *testing.T) {
+
+	backend := memory.NewStorageManager()
+
+	game := playGame(t, backend)
+
+	var originals []boardgame.StateStorageRecord
+
+	for version := 0; version <= game.Version(); version++ {
+		record, err := backend.State(game.Id(), version)
+		assert.For(t, version).ThatActual(err).IsNil()
+		originals = append(originals, record)
+	}
+
+	storage := NewStorageManager(backend, 2)
+
+	//States saved before wrapping are read back unchanged.
+	for version, original := range originals {
+		record, err := storage.State(game.Id(), version)
+		assert.For(t, version).ThatActual(err).IsNil()
+		assert.For(t, version).ThatActual(record).Equals(original)
+	}
+
+	assert.For(t).ThatActual(storage.MigrateGame(game.Id())).IsNil()
+
+	for version, original := range originals {
+
+		raw, err := backend.State(game.Id(), version)
+		assert.For(t, version).ThatActual(err).IsNil()
+
+		_, isDelta := parseDelta(raw)
+		assert.For(t, version).ThatActual(isDelta).Equals(version%2 != 0)
+
+		record, err := storage.State(game.Id(), version)
+		assert.For(t, version).ThatActual(err).IsNil()
+		assertSameJSON(t, version, record, original)
+	}
+
+	//Migrating again, with a different interval, still leaves every state
+	//readable.
+	storage = NewStorageManager(backend, 3)
+
+	assert.For(t).ThatActual(storage.MigrateGame(game.Id())).IsNil()
+	assert.For(t).ThatActual(storage.MigrateGame(game.Id())).IsNil()
+
+	for version, original := range originals {
+		record, err := storage.State(game.Id(), version)
+		assert.For(t, version).ThatActual(err).IsNil()
+		assertSameJSON(t, version, record, original)
+	}
+
+	//Reconstructed states aren't byte for byte the originals, but the engine
+	//loads them as the same states, which encode back to the original bytes.
+	manager, err := tictactoe.NewManager(storage)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	reloaded := manager.Game(game.Id())
+
+	assert.For(t).ThatActual(reloaded).IsNotNil()
+
+	for version, original := range originals {
+		state := reloaded.State(version)
+		assert.For(t, version).ThatActual(state).IsNotNil()
+		assert.For(t, version).ThatActual(string(state.StorageRecord())).Equals(string(original))
+	}
+
+	assert.For(t).ThatActual(storage.MigrateGame("missing")).Equals(boardgame.ErrGameNotFound)
+
+}
+
+func TestMigrate(t *testing.T) {
+
+	backend := memory.NewStorageManager()
+
+	games := []*boardgame.Game{
+		playGame(t, backend),
+		playGame(t, backend),
+	}
+
+	originals := make(map[string][]boardgame.StateStorageRecord)
+
+	for _, game := range games {
+		for version := 0; version <= game.Version(); version++ {
+			record, err := backend.State(game.Id(), version)
+			assert.For(t, game.Id(), version).ThatActual(err).IsNil()
+			originals[game.Id()] = append(originals[game.Id()], record)
+		}
+	}
+
+	storage := NewStorageManager(backend, 2)
+
+	assert.For(t).ThatActual(storage.Migrate()).IsNil()
+
+	for _, game := range games {
+		for version := 0; version <= game.Version(); version++ {
+			raw, err := backend.State(game.Id(), version)
+			assert.For(t, game.Id(), version).ThatActual(err).IsNil()
+
+			_, isDelta := parseDelta(raw)
+			assert.For(t, game.Id(), version).ThatActual(isDelta).Equals(version%2 != 0)
+
+			record, err := storage.State(game.Id(), version)
+			assert.For(t, game.Id(), version).ThatActual(err).IsNil()
+			assertSameJSON(t, version, record, originals[game.Id()][version])
+		}
+	}
+
+}
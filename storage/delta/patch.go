@@ -0,0 +1,185 @@
+package delta
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//patchOp is one operation of a JSON Patch (RFC 6902). Only the add, remove,
+//and replace operations are generated or supported.
+type patchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+const (
+	opAdd     = "add"
+	opRemove  = "remove"
+	opReplace = "replace"
+)
+
+//decodeJSON decodes blob into generic values, keeping numbers as
+//json.Number so that they survive a round trip exactly.
+func decodeJSON(blob []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(blob))
+	decoder.UseNumber()
+
+	var result interface{}
+
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func escapePathToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+func unescapePathToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+}
+
+//diff returns the operations that turn from into to. Objects are diffed key
+//by key and arrays of the same length index by index; anything else that
+//differs is replaced wholesale.
+func diff(from, to interface{}) []patchOp {
+	var ops []patchOp
+	diffValues("", from, to, &ops)
+	return ops
+}
+
+func diffValues(path string, from, to interface{}, ops *[]patchOp) {
+
+	switch fromVal := from.(type) {
+	case map[string]interface{}:
+		toVal, ok := to.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, key := range sortedKeys(fromVal) {
+			if _, ok := toVal[key]; !ok {
+				*ops = append(*ops, patchOp{Op: opRemove, Path: path + "/" + escapePathToken(key)})
+			}
+		}
+		for _, key := range sortedKeys(toVal) {
+			val := toVal[key]
+			childPath := path + "/" + escapePathToken(key)
+			fromChild, ok := fromVal[key]
+			if !ok {
+				*ops = append(*ops, patchOp{Op: opAdd, Path: childPath, Value: val})
+				continue
+			}
+			diffValues(childPath, fromChild, val, ops)
+		}
+		return
+	case []interface{}:
+		toVal, ok := to.([]interface{})
+		if !ok || len(toVal) != len(fromVal) {
+			break
+		}
+		for i := range fromVal {
+			diffValues(path+"/"+strconv.Itoa(i), fromVal[i], toVal[i], ops)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*ops = append(*ops, patchOp{Op: opReplace, Path: path, Value: to})
+	}
+}
+
+//sortedKeys returns the keys of obj in order, so that diffs are
+//deterministic.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+//applyPatch applies ops to doc, which it may modify in place, and returns
+//the result.
+func applyPatch(doc interface{}, ops []patchOp) (interface{}, error) {
+
+	for _, op := range ops {
+
+		if op.Path == "" {
+			if op.Op != opReplace {
+				return nil, errors.New("Only replace is supported on the whole document")
+			}
+			doc = op.Value
+			continue
+		}
+
+		if !strings.HasPrefix(op.Path, "/") {
+			return nil, errors.New("Invalid path: " + op.Path)
+		}
+
+		tokens := strings.Split(op.Path[1:], "/")
+
+		parent := doc
+
+		for _, token := range tokens[:len(tokens)-1] {
+			child, err := childValue(parent, unescapePathToken(token))
+			if err != nil {
+				return nil, errors.New(op.Path + ": " + err.Error())
+			}
+			parent = child
+		}
+
+		last := unescapePathToken(tokens[len(tokens)-1])
+
+		switch parentVal := parent.(type) {
+		case map[string]interface{}:
+			switch op.Op {
+			case opAdd, opReplace:
+				parentVal[last] = op.Value
+			case opRemove:
+				delete(parentVal, last)
+			default:
+				return nil, errors.New("Unsupported op: " + op.Op)
+			}
+		case []interface{}:
+			if op.Op != opReplace {
+				return nil, errors.New("Only replace is supported on array items")
+			}
+			index, err := strconv.Atoi(last)
+			if err != nil || index < 0 || index >= len(parentVal) {
+				return nil, errors.New(op.Path + ": invalid array index")
+			}
+			parentVal[index] = op.Value
+		default:
+			return nil, errors.New(op.Path + ": parent is not an object or array")
+		}
+	}
+
+	return doc, nil
+}
+
+func childValue(parent interface{}, token string) (interface{}, error) {
+	switch parentVal := parent.(type) {
+	case map[string]interface{}:
+		child, ok := parentVal[token]
+		if !ok {
+			return nil, errors.New("no such key " + token)
+		}
+		return child, nil
+	case []interface{}:
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(parentVal) {
+			return nil, errors.New("invalid array index " + token)
+		}
+		return parentVal[index], nil
+	}
+	return nil, errors.New("can't descend into " + token)
+}
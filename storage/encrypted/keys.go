@@ -0,0 +1,183 @@
+package encrypted
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//KeySize is the length in bytes of the keys a KeyProvider must return, and of
+//the data keys generated for each game. They're AES-256 keys.
+const KeySize = 32
+
+//KeyProvider supplies the master keys that wrap each game's data key. A
+//KeyProvider backed by a key management service can keep the master keys
+//out of the server's memory entirely; Keys is a simple one backed by a local
+//file.
+type KeyProvider interface {
+	//CurrentKey returns the key that new data keys should be wrapped with,
+	//and its id. Ids may not contain '.'.
+	CurrentKey() (id string, key []byte, err error)
+	//Key returns the key with the given id, for unwrapping data keys that
+	//were wrapped with it, even if it's no longer current.
+	Key(id string) ([]byte, error)
+}
+
+//Keys is a KeyProvider that keeps its keys in memory, and can be saved to and
+//loaded from a local key file. It's most useful for tests and for small
+//deployments; anyone who can read the key file can decrypt the database.
+type Keys struct {
+	//Current is the id of the key that new data keys are wrapped with.
+	Current string
+	Keys    map[string][]byte
+	lock    sync.RWMutex
+}
+
+//NewKeys returns Keys with a single, newly generated key.
+func NewKeys() (*Keys, error) {
+	keys := &Keys{}
+	if _, err := keys.AddKey(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+//LoadKeyFile reads Keys that were saved to path with Save.
+func LoadKeyFile(path string) (*Keys, error) {
+
+	blob, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, errors.New("Couldn't read key file: " + err.Error())
+	}
+
+	var keys Keys
+
+	if err := json.Unmarshal(blob, &keys); err != nil {
+		return nil, errors.New("Couldn't parse key file: " + err.Error())
+	}
+
+	for id, key := range keys.Keys {
+		if err := validateKey(id, key); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := keys.Keys[keys.Current]; !ok {
+		return nil, errors.New("The key file's current key " + keys.Current + " isn't in it")
+	}
+
+	return &keys, nil
+}
+
+//Save writes the keys to path, readable only by the current user.
+func (k *Keys) Save(path string) error {
+
+	k.lock.RLock()
+	blob, err := json.MarshalIndent(k, "", "\t")
+	k.lock.RUnlock()
+
+	if err != nil {
+		return errors.New("Couldn't serialize keys: " + err.Error())
+	}
+
+	if err := ioutil.WriteFile(path, blob, 0600); err != nil {
+		return errors.New("Couldn't write key file: " + err.Error())
+	}
+
+	//WriteFile doesn't change the permissions of a file that already exists.
+	return os.Chmod(path, 0600)
+}
+
+//AddKey generates a new random key and makes it the current one, returning
+//its id. Games keep using their old key until they're rotated, so the old
+//key must be kept until StorageManager.Rotate has finished.
+func (k *Keys) AddKey() (string, error) {
+
+	key := make([]byte, KeySize)
+
+	if _, err := rand.Read(key); err != nil {
+		return "", errors.New("Couldn't generate key: " + err.Error())
+	}
+
+	rawId := make([]byte, 4)
+
+	if _, err := rand.Read(rawId); err != nil {
+		return "", errors.New("Couldn't generate key id: " + err.Error())
+	}
+
+	id := hex.EncodeToString(rawId)
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if k.Keys == nil {
+		k.Keys = make(map[string][]byte)
+	}
+
+	k.Keys[id] = key
+	k.Current = id
+
+	return id, nil
+}
+
+//RemoveKey forgets the key with the given id, for example once every game
+//has been rotated off of it. The current key can't be removed.
+func (k *Keys) RemoveKey(id string) error {
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if id == k.Current {
+		return errors.New("The current key can't be removed")
+	}
+
+	delete(k.Keys, id)
+
+	return nil
+}
+
+func (k *Keys) CurrentKey() (string, []byte, error) {
+
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	key, ok := k.Keys[k.Current]
+
+	if !ok {
+		return "", nil, errors.New("There is no current key")
+	}
+
+	return k.Current, key, nil
+}
+
+func (k *Keys) Key(id string) ([]byte, error) {
+
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	key, ok := k.Keys[id]
+
+	if !ok {
+		return nil, errors.New("Unknown key " + id)
+	}
+
+	return key, nil
+}
+
+//validateKey returns an error if the key can't be used to wrap data keys.
+func validateKey(id string, key []byte) error {
+	if id == "" || strings.Contains(id, ".") {
+		return errors.New("Invalid key id " + id)
+	}
+	if len(key) != KeySize {
+		return errors.New("Key " + id + " is not " + strconv.Itoa(KeySize) + " bytes long")
+	}
+	return nil
+}
@@ -0,0 +1,604 @@
+/*
+
+encrypted wraps another storage manager to encrypt, at rest, everything in a
+game that could reveal hidden information: its SecretSalt, the full
+unsanitized state blob of every version, and its agents' states. A dump of
+the wrapped storage manager's database doesn't reveal any of them without
+the master keys.
+
+	keys, err := encrypted.LoadKeyFile("keys.SECRET.json")
+	storage := encrypted.NewStorageManager(mysql.NewStorageManager(false), keys)
+
+It uses envelope encryption. Each game has its own random data key, which
+seals its states and agent states with AES-256-GCM. The data key is itself
+sealed ("wrapped") with a master key from the KeyProvider, and stored,
+together with the sealed salt, in place of the game's SecretSalt. Moves,
+extended games, users and everything else are passed straight through: a
+game's moves are already shown to every player.
+
+Rotating master keys doesn't require re-encrypting any states: after the
+KeyProvider's current key changes, new saves wrap their game's data key with
+it, and Rotate (or RotateInBackground) re-wraps the data key of every other
+game, so that the old master key can be removed.
+
+Games that were saved before the storage manager was wrapped are read in
+plaintext, and are encrypted the next time they're saved; Rotate seals the
+states and agent states they already had. Rotate requires the Backend to
+implement GameReplacer and StateReplacer, which every storage manager in
+this repo does.
+
+Data keys are cached in memory once they've been unwrapped. A game's data
+key never changes once it's stored, so several processes, each with its own
+encrypted StorageManager, can share a Backend, for example to serve the same
+games behind a load balancer. A StorageManager reads the game's envelope from
+the Backend whenever it doesn't have the data key cached, or the cached one
+can't open what's stored, and before storing a data key for a game for the
+first time, so it adopts one stored by another process in the meantime.
+Rotate is the exception: it replaces game records, so it must run while no
+other process is saving games to the Backend, or it may overwrite their
+saves.
+
+To use it with storage/delta, wrap it in delta, and not the other way
+around, since sealed states can't be diffed.
+
+*/
+package encrypted
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/extendedgame"
+	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/server/api/query"
+	"github.com/jkomoros/boardgame/storage"
+	"strings"
+	"sync"
+)
+
+//Backend is the interface of the storage managers that can be wrapped.
+type Backend interface {
+	storage.Manager
+}
+
+//GameReplacer is implemented by storage managers that can overwrite a game
+//record without saving a new version. Rotate requires it.
+type GameReplacer interface {
+	ReplaceGame(game *boardgame.GameStorageRecord) error
+}
+
+//StateReplacer is implemented by storage managers that can overwrite a state
+//that has already been saved. Rotate requires it.
+type StateReplacer interface {
+	ReplaceState(gameId string, version int, state boardgame.StateStorageRecord) error
+}
+
+//errNoDataKey is returned by dataKeyForGame for games that are stored in
+//plaintext.
+var errNoDataKey = errors.New("The game is stored in plaintext, and doesn't have a data key yet")
+
+//StorageManager encrypts the secret parts of the games it saves to the
+//Backend it wraps, and passes everything else straight through.
+type StorageManager struct {
+	Backend
+	keys KeyProvider
+	//dataKeys are the unwrapped data keys of games, by upper-cased id. Only
+	//the fields of a dataKey that can change are guarded by dataKeysLock.
+	dataKeys     map[string]*dataKey
+	dataKeysLock sync.Mutex
+	//writeLock is held while game records are written, so that Rotate
+	//can't overwrite a game record that was saved after it read it.
+	writeLock sync.Mutex
+}
+
+//dataKey is a game's unwrapped data key.
+type dataKey struct {
+	key []byte
+	//partial is true if some of the game's data may still be in plaintext.
+	partial bool
+	//persisted is false until an envelope with the key has been stored.
+	//Nothing is sealed with a key that isn't persisted except the state that
+	//is saved along with its envelope, so a failed save can never leave
+	//data that can't be decrypted.
+	persisted bool
+}
+
+//NewStorageManager returns a StorageManager that encrypts the games it saves
+//in backend with data keys wrapped by keys.
+func NewStorageManager(backend Backend, keys KeyProvider) *StorageManager {
+	return &StorageManager{
+		Backend:  backend,
+		keys:     keys,
+		dataKeys: make(map[string]*dataKey),
+	}
+}
+
+func keyForGame(gameId string) string {
+	return strings.ToUpper(gameId)
+}
+
+//cacheDataKey caches key as gameId's data key, unless another one was
+//cached first, in which case that one is returned instead.
+func (s *StorageManager) cacheDataKey(gameId string, key *dataKey) *dataKey {
+	s.dataKeysLock.Lock()
+	defer s.dataKeysLock.Unlock()
+	if existing, ok := s.dataKeys[keyForGame(gameId)]; ok {
+		return existing
+	}
+	s.dataKeys[keyForGame(gameId)] = key
+	return key
+}
+
+func (s *StorageManager) cachedDataKey(gameId string) *dataKey {
+	s.dataKeysLock.Lock()
+	defer s.dataKeysLock.Unlock()
+	return s.dataKeys[keyForGame(gameId)]
+}
+
+//keyState returns whether key is partial and persisted.
+func (s *StorageManager) keyState(key *dataKey) (partial, persisted bool) {
+	s.dataKeysLock.Lock()
+	defer s.dataKeysLock.Unlock()
+	return key.partial, key.persisted
+}
+
+//dropDataKey removes key from the cache, if it's still gameId's data key, so
+//that the next lookup reads the game's envelope from the Backend again.
+func (s *StorageManager) dropDataKey(gameId string, key *dataKey) {
+	s.dataKeysLock.Lock()
+	defer s.dataKeysLock.Unlock()
+	if s.dataKeys[keyForGame(gameId)] == key {
+		delete(s.dataKeys, keyForGame(gameId))
+	}
+}
+
+//dataKeyForEnvelope returns the data key wrapped in env, unwrapping it if it
+//isn't cached.
+func (s *StorageManager) dataKeyForEnvelope(gameId string, env *envelope) (*dataKey, error) {
+
+	if cached := s.cachedDataKey(gameId); cached != nil {
+		s.dataKeysLock.Lock()
+		//Another process may have finished encrypting the game.
+		if cached.persisted && !env.Partial {
+			cached.partial = false
+		}
+		s.dataKeysLock.Unlock()
+		return cached, nil
+	}
+
+	masterKey, err := s.keys.Key(env.KeyId)
+
+	if err != nil {
+		return nil, errors.New("Couldn't get master key: " + err.Error())
+	}
+
+	key, err := open(masterKey, env.WrappedKey, dataKeyData(gameId))
+
+	if err != nil {
+		return nil, errors.New("Couldn't unwrap data key: " + err.Error())
+	}
+
+	return s.cacheDataKey(gameId, &dataKey{
+		key:       key,
+		partial:   env.Partial,
+		persisted: true,
+	}), nil
+}
+
+//dataKeyForGame returns the game's data key. If the game doesn't have one
+//yet, it returns boardgame.ErrGameNotFound or errNoDataKey, unless create is
+//true, in which case it generates one that isn't persisted yet.
+func (s *StorageManager) dataKeyForGame(gameId string, create bool) (*dataKey, error) {
+
+	if cached := s.cachedDataKey(gameId); cached != nil {
+		return cached, nil
+	}
+
+	record, err := s.Backend.Game(gameId)
+
+	partial := false
+
+	if errors.Is(err, boardgame.ErrGameNotFound) {
+		if !create {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	} else {
+		env, err := parseEnvelope(record.SecretSalt)
+		if err != nil {
+			return nil, err
+		}
+		if env != nil {
+			return s.dataKeyForEnvelope(gameId, env)
+		}
+		if !create {
+			return nil, errNoDataKey
+		}
+		//The game was saved before it was wrapped, so its existing data is
+		//still in plaintext.
+		partial = true
+	}
+
+	key, err := newKey()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.cacheDataKey(gameId, &dataKey{
+		key:     key,
+		partial: partial,
+	}), nil
+}
+
+//storedDataKey reads the game's envelope from the Backend and returns the
+//data key in it, replacing whatever was cached, or nil if the game doesn't
+//have one stored.
+func (s *StorageManager) storedDataKey(gameId string) (*dataKey, error) {
+
+	record, err := s.Backend.Game(gameId)
+
+	if errors.Is(err, boardgame.ErrGameNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := parseEnvelope(record.SecretSalt)
+
+	if err != nil || env == nil {
+		return nil, err
+	}
+
+	if cached := s.cachedDataKey(gameId); cached != nil {
+		s.dropDataKey(gameId, cached)
+	}
+
+	return s.dataKeyForEnvelope(gameId, env)
+}
+
+//persistedDataKey returns the game's data key if it has one that's
+//persisted, or nil if the game is still in plaintext.
+func (s *StorageManager) persistedDataKey(gameId string) (*dataKey, error) {
+
+	key, err := s.dataKeyForGame(gameId, false)
+
+	if err == errNoDataKey {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, persisted := s.keyState(key); !persisted {
+		return nil, nil
+	}
+
+	return key, nil
+}
+
+//sealGame returns a copy of game with its SecretSalt replaced by an
+//envelope, wrapping key with the current master key.
+func (s *StorageManager) sealGame(game *boardgame.GameStorageRecord, key *dataKey) (*boardgame.GameStorageRecord, error) {
+
+	keyId, masterKey, err := s.keys.CurrentKey()
+
+	if err != nil {
+		return nil, errors.New("Couldn't get current master key: " + err.Error())
+	}
+
+	if err := validateKey(keyId, masterKey); err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := seal(masterKey, key.key, dataKeyData(game.Id))
+
+	if err != nil {
+		return nil, err
+	}
+
+	sealedSalt, err := seal(key.key, []byte(game.SecretSalt), saltData(game.Id))
+
+	if err != nil {
+		return nil, err
+	}
+
+	partial, _ := s.keyState(key)
+
+	env := &envelope{
+		KeyId:      keyId,
+		WrappedKey: wrappedKey,
+		Salt:       sealedSalt,
+		Partial:    partial,
+	}
+
+	result := *game
+	result.SecretSalt = env.String()
+
+	return &result, nil
+}
+
+//openGame returns game with its SecretSalt decrypted. Games stored in
+//plaintext are returned as is.
+func (s *StorageManager) openGame(game *boardgame.GameStorageRecord) (*boardgame.GameStorageRecord, error) {
+
+	env, err := parseEnvelope(game.SecretSalt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if env == nil {
+		return game, nil
+	}
+
+	key, err := s.dataKeyForEnvelope(game.Id, env)
+
+	if err != nil {
+		return nil, err
+	}
+
+	salt, err := open(key.key, env.Salt, saltData(game.Id))
+
+	if err != nil {
+		//The cached key may be stale; try the one in the envelope.
+		s.dropDataKey(game.Id, key)
+		if key, err = s.dataKeyForEnvelope(game.Id, env); err != nil {
+			return nil, err
+		}
+		if salt, err = open(key.key, env.Salt, saltData(game.Id)); err != nil {
+			return nil, errors.New("Couldn't decrypt salt: " + err.Error())
+		}
+	}
+
+	result := *game
+	result.SecretSalt = string(salt)
+
+	return &result, nil
+}
+
+func (s *StorageManager) openCombinedGame(game *extendedgame.CombinedStorageRecord) (*extendedgame.CombinedStorageRecord, error) {
+
+	gameRecord, err := s.openGame(&game.GameStorageRecord)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := *game
+	result.GameStorageRecord = *gameRecord
+
+	return &result, nil
+}
+
+func (s *StorageManager) Game(id string) (*boardgame.GameStorageRecord, error) {
+
+	record, err := s.Backend.Game(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.openGame(record)
+}
+
+func (s *StorageManager) CombinedGame(id string) (*extendedgame.CombinedStorageRecord, error) {
+
+	record, err := s.Backend.CombinedGame(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.openCombinedGame(record)
+}
+
+//ListGames decrypts the salt of each game. Listings never show salts, so a
+//game whose salt can't be decrypted is listed with an empty one.
+func (s *StorageManager) ListGames(max int, list listing.Type, userId string, gameType string) []*extendedgame.CombinedStorageRecord {
+
+	games := s.Backend.ListGames(max, list, userId, gameType)
+
+	for i, game := range games {
+		opened, err := s.openCombinedGame(game)
+		if err != nil {
+			blanked := *game
+			blanked.SecretSalt = ""
+			opened = &blanked
+		}
+		games[i] = opened
+	}
+
+	return games
+}
+
+func (s *StorageManager) QueryGames(q *query.Query) (*query.Result, error) {
+
+	result, err := s.Backend.QueryGames(q)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, game := range result.Games {
+		opened, err := s.openCombinedGame(game)
+		if err != nil {
+			return nil, errors.New("Couldn't decrypt game " + game.Id + ": " + err.Error())
+		}
+		result.Games[i] = opened
+	}
+
+	return result, nil
+}
+
+//SaveGameAndCurrentState seals state with the game's data key, creating one
+//if it's a new game, and saves the game with its salt in an envelope.
+func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageRecord, state boardgame.StateStorageRecord, move *boardgame.MoveStorageRecord) error {
+
+	if game == nil {
+		return errors.New("No game provided")
+	}
+
+	key, err := s.dataKeyForGame(game.Id, true)
+
+	if err != nil {
+		return errors.New("Couldn't get data key: " + err.Error())
+	}
+
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	if _, persisted := s.keyState(key); !persisted {
+		//Another process may have stored a data key for the game since this
+		//one was generated. Use that one, so the game only ever has one.
+		stored, err := s.storedDataKey(game.Id)
+		if err != nil {
+			return errors.New("Couldn't check for a stored data key: " + err.Error())
+		}
+		if stored != nil {
+			key = stored
+		}
+	}
+
+	sealedState, err := sealBlob(key.key, state, stateData(game.Id, game.Version))
+
+	if err != nil {
+		return errors.New("Couldn't seal state: " + err.Error())
+	}
+
+	//Seal the game while holding the lock, in case Rotate just finished
+	//sealing the game's plaintext data.
+	sealedGame, err := s.sealGame(game, key)
+
+	if err != nil {
+		return errors.New("Couldn't seal game: " + err.Error())
+	}
+
+	if err := s.Backend.SaveGameAndCurrentState(sealedGame, sealedState, move); err != nil {
+		return err
+	}
+
+	s.dataKeysLock.Lock()
+	key.persisted = true
+	s.dataKeysLock.Unlock()
+
+	return nil
+}
+
+//openBlob returns blob decrypted, if it was sealed.
+func (s *StorageManager) openBlob(gameId string, blob []byte, data []byte) ([]byte, error) {
+
+	sealed, ok := parseSealed(blob)
+
+	if !ok {
+		return blob, nil
+	}
+
+	key, err := s.dataKeyForGame(gameId, false)
+
+	if err != nil {
+		return nil, errors.New("Couldn't get data key: " + err.Error())
+	}
+
+	result, err := open(key.key, sealed, data)
+
+	if err == nil {
+		return result, nil
+	}
+
+	if _, persisted := s.keyState(key); !persisted {
+		return nil, err
+	}
+
+	//Another process may have stored the game with a different data key
+	//than the cached one, so read it from the Backend again.
+	s.dropDataKey(gameId, key)
+
+	key, keyErr := s.dataKeyForGame(gameId, false)
+
+	if keyErr != nil {
+		return nil, err
+	}
+
+	return open(key.key, sealed, data)
+}
+
+func (s *StorageManager) State(gameId string, version int) (boardgame.StateStorageRecord, error) {
+
+	record, err := s.Backend.State(gameId, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.openBlob(gameId, record, stateData(gameId, version))
+}
+
+//ReplaceState seals state, if the game has a data key, and passes it through
+//to the Backend, which must implement StateReplacer. It allows the
+//StorageManager to be wrapped by storage/delta.
+func (s *StorageManager) ReplaceState(gameId string, version int, state boardgame.StateStorageRecord) error {
+
+	replacer, ok := s.Backend.(StateReplacer)
+
+	if !ok {
+		return errors.New("The wrapped storage manager doesn't support replacing states")
+	}
+
+	key, err := s.persistedDataKey(gameId)
+
+	if err != nil {
+		return errors.New("Couldn't get data key: " + err.Error())
+	}
+
+	if key != nil {
+		if state, err = sealBlob(key.key, state, stateData(gameId, version)); err != nil {
+			return errors.New("Couldn't seal state: " + err.Error())
+		}
+	}
+
+	return replacer.ReplaceState(gameId, version, state)
+}
+
+func (s *StorageManager) AgentState(gameId string, player boardgame.PlayerIndex) ([]byte, error) {
+
+	state, err := s.Backend.AgentState(gameId, player)
+
+	if err != nil || state == nil {
+		return state, err
+	}
+
+	return s.openBlob(gameId, state, agentData(gameId, player))
+}
+
+//SaveAgentState seals state with the game's data key. Games that are still
+//stored in plaintext have their agent states stored in plaintext too, until
+//they're next saved or rotated.
+func (s *StorageManager) SaveAgentState(gameId string, player boardgame.PlayerIndex, state []byte) error {
+
+	key, err := s.persistedDataKey(gameId)
+
+	if err != nil {
+		return errors.New("Couldn't get data key: " + err.Error())
+	}
+
+	if key != nil {
+		if state, err = sealBlob(key.key, state, agentData(gameId, player)); err != nil {
+			return errors.New("Couldn't seal agent state: " + err.Error())
+		}
+	}
+
+	return s.Backend.SaveAgentState(gameId, player, state)
+}
+
+//CleanUp passes through to the Backend, if it has a CleanUp method.
+func (s *StorageManager) CleanUp() {
+	if cleaner, ok := s.Backend.(interface {
+		CleanUp()
+	}); ok {
+		cleaner.CleanUp()
+	}
+}
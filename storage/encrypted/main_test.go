@@ -0,0 +1,338 @@
+package encrypted
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/storage/memory"
+	"github.com/jkomoros/boardgame/storage/storagetest"
+	"github.com/workfit/tester/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSalt = "SUPERSECRETSALT1"
+
+func testKeys(t *testing.T) *Keys {
+	keys, err := NewKeys()
+	if err != nil {
+		t.Fatal("Couldn't create keys: ", err)
+	}
+	return keys
+}
+
+func testGame(id string, version int) *boardgame.GameStorageRecord {
+	return &boardgame.GameStorageRecord{
+		Name:       "tictactoe",
+		Id:         id,
+		SecretSalt: testSalt,
+		Version:    version,
+		NumPlayers: 2,
+		Agents:     make([]string, 2),
+		Created:    time.Unix(100, 0),
+	}
+}
+
+func testState(version int) boardgame.StateStorageRecord {
+	return boardgame.StateStorageRecord(`{"Version":` + strconv.Itoa(version) + `,"Hidden":"HIDDENCARD"}`)
+}
+
+//assertSealed checks that nothing secret about the game is readable in
+//backend.
+func assertSealed(t *testing.T, backend *memory.StorageManager, gameId string, description string) {
+
+	game, err := backend.Game(gameId)
+
+	assert.For(t, description).ThatActual(err).IsNil()
+	assert.For(t, description).ThatActual(strings.HasPrefix(game.SecretSalt, envelopePrefix)).IsTrue()
+
+	for version := 0; version <= game.Version; version++ {
+		state, err := backend.State(gameId, version)
+		assert.For(t, description, version).ThatActual(err).IsNil()
+		assert.For(t, description, version).ThatActual(strings.Contains(string(state), "HIDDENCARD")).IsFalse()
+		_, sealed := parseSealed(state)
+		assert.For(t, description, version).ThatActual(sealed).IsTrue()
+	}
+
+	agentState, err := backend.AgentState(gameId, 0)
+
+	assert.For(t, description).ThatActual(err).IsNil()
+
+	if agentState != nil {
+		_, sealed := parseSealed(agentState)
+		assert.For(t, description).ThatActual(sealed).IsTrue()
+	}
+}
+
+func TestStorageManager(t *testing.T) {
+
+	keys := testKeys(t)
+
+	storagetest.Test(func() storagetest.StorageManager {
+		return NewStorageManager(memory.NewStorageManager(), keys)
+	}, "memory", "", t)
+
+}
+
+func TestAtRest(t *testing.T) {
+
+	backend := memory.NewStorageManager()
+
+	storage := NewStorageManager(backend, testKeys(t))
+
+	for version := 0; version < 3; version++ {
+		assert.For(t, version).ThatActual(storage.SaveGameAndCurrentState(testGame("ATREST", version), testState(version), nil)).IsNil()
+	}
+
+	assert.For(t).ThatActual(storage.SaveAgentState("ATREST", 0, []byte("AGENTSECRET"))).IsNil()
+
+	assertSealed(t, backend, "ATREST", "At rest")
+
+	raw, _ := backend.Game("ATREST")
+
+	assert.For(t).ThatActual(strings.Contains(raw.SecretSalt, testSalt)).IsFalse()
+
+	game, err := storage.Game("ATREST")
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(game.SecretSalt).Equals(testSalt)
+
+	combined, err := storage.CombinedGame("ATREST")
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(combined.SecretSalt).Equals(testSalt)
+
+	state, err := storage.State("ATREST", 1)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(state).Equals(testState(1))
+
+	agentState, err := storage.AgentState("ATREST", 0)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(agentState).Equals([]byte("AGENTSECRET"))
+
+	//A sealed state can't be passed off as a different version.
+	rawState, _ := backend.State("ATREST", 2)
+
+	assert.For(t).ThatActual(backend.ReplaceState("ATREST", 1, rawState)).IsNil()
+
+	_, err = storage.State("ATREST", 1)
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+	//Without the master key, nothing can be decrypted.
+	_, err = NewStorageManager(backend, testKeys(t)).Game("ATREST")
+
+	assert.For(t).ThatActual(err).IsNotNil()
+
+}
+
+func TestSharedBackend(t *testing.T) {
+
+	backend := memory.NewStorageManager()
+
+	keys := testKeys(t)
+
+	//Two processes, each with its own StorageManager, share the backend.
+	one := NewStorageManager(backend, keys)
+	two := NewStorageManager(backend, keys)
+
+	//A game saved before the backend was wrapped, which both go to encrypt
+	//at once.
+	assert.For(t).ThatActual(backend.SaveGameAndCurrentState(testGame("SHARED", 0), testState(0), nil)).IsNil()
+
+	oneKey, err := one.dataKeyForGame("SHARED", true)
+	assert.For(t).ThatActual(err).IsNil()
+	twoKey, err := two.dataKeyForGame("SHARED", true)
+	assert.For(t).ThatActual(err).IsNil()
+
+	assert.For(t).ThatActual(oneKey.key).DoesNotEqual(twoKey.key)
+
+	assert.For(t).ThatActual(one.SaveGameAndCurrentState(testGame("SHARED", 1), testState(1), nil)).IsNil()
+
+	//two adopts the data key that one stored instead of its own.
+	assert.For(t).ThatActual(two.SaveGameAndCurrentState(testGame("SHARED", 2), testState(2), nil)).IsNil()
+
+	for _, storage := range []*StorageManager{one, two, NewStorageManager(backend, keys)} {
+		for version := 0; version <= 2; version++ {
+			state, err := storage.State("SHARED", version)
+			assert.For(t, version).ThatActual(err).IsNil()
+			assert.For(t, version).ThatActual(state).Equals(testState(version))
+		}
+	}
+
+	//A stale cached key is replaced by the one that's stored.
+	stale, err := newKey()
+	assert.For(t).ThatActual(err).IsNil()
+
+	two.dataKeysLock.Lock()
+	two.dataKeys["SHARED"] = &dataKey{key: stale, persisted: true}
+	two.dataKeysLock.Unlock()
+
+	state, err := two.State("SHARED", 2)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(state).Equals(testState(2))
+
+	two.dataKeysLock.Lock()
+	two.dataKeys["SHARED"] = &dataKey{key: stale, persisted: true}
+	two.dataKeysLock.Unlock()
+
+	game, err := two.Game("SHARED")
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(game.SecretSalt).Equals(testSalt)
+
+}
+
+func TestRotation(t *testing.T) {
+
+	backend := memory.NewStorageManager()
+
+	//LEGACY was saved before the backend was wrapped.
+	for version := 0; version < 2; version++ {
+		assert.For(t, version).ThatActual(backend.SaveGameAndCurrentState(testGame("LEGACY", version), testState(version), nil)).IsNil()
+	}
+
+	assert.For(t).ThatActual(backend.SaveAgentState("LEGACY", 0, []byte("AGENTSECRET"))).IsNil()
+
+	keys := testKeys(t)
+	oldKeyId := keys.Current
+
+	storage := NewStorageManager(backend, keys)
+
+	state, err := storage.State("LEGACY", 1)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(state).Equals(testState(1))
+
+	//Saving a new version encrypts the game, but not its older versions.
+	assert.For(t).ThatActual(storage.SaveGameAndCurrentState(testGame("LEGACY", 2), testState(2), nil)).IsNil()
+
+	raw, _ := backend.Game("LEGACY")
+
+	assert.For(t).ThatActual(strings.HasPrefix(raw.SecretSalt, partialEnvelopePrefix)).IsTrue()
+
+	rawState, _ := backend.State("LEGACY", 0)
+
+	assert.For(t).ThatActual(rawState).Equals(testState(0))
+
+	for version := 0; version < 3; version++ {
+		state, err := storage.State("LEGACY", version)
+		assert.For(t, version).ThatActual(err).IsNil()
+		assert.For(t, version).ThatActual(state).Equals(testState(version))
+	}
+
+	report, err := storage.Rotate()
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(report).Equals(&RotationReport{Games: 1, Encrypted: 1})
+
+	assertSealed(t, backend, "LEGACY", "Rotated legacy game")
+
+	agentState, err := storage.AgentState("LEGACY", 0)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(agentState).Equals([]byte("AGENTSECRET"))
+
+	//After adding a key, new games use it, and Rotate moves the old ones to
+	//it.
+	newKeyId, err := keys.AddKey()
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	assert.For(t).ThatActual(storage.SaveGameAndCurrentState(testGame("NEW", 0), testState(0), nil)).IsNil()
+
+	raw, _ = backend.Game("NEW")
+
+	assert.For(t).ThatActual(strings.HasPrefix(raw.SecretSalt, envelopePrefix+newKeyId+".")).IsTrue()
+
+	raw, _ = backend.Game("LEGACY")
+
+	assert.For(t).ThatActual(strings.HasPrefix(raw.SecretSalt, envelopePrefix+oldKeyId+".")).IsTrue()
+
+	rawState, _ = backend.State("LEGACY", 1)
+
+	report, errChan := storage.RotateInBackground()
+
+	assert.For(t).ThatActual(<-errChan).IsNil()
+	assert.For(t).ThatActual(report).Equals(&RotationReport{Games: 2, Rewrapped: 1})
+
+	raw, _ = backend.Game("LEGACY")
+
+	assert.For(t).ThatActual(strings.HasPrefix(raw.SecretSalt, envelopePrefix+newKeyId+".")).IsTrue()
+
+	//Re-wrapping doesn't re-encrypt any states.
+	rotatedState, _ := backend.State("LEGACY", 1)
+
+	assert.For(t).ThatActual(rotatedState).Equals(rawState)
+
+	//The old key is no longer needed, even without any cached data keys.
+	assert.For(t).ThatActual(keys.RemoveKey(newKeyId)).IsNotNil()
+	assert.For(t).ThatActual(keys.RemoveKey(oldKeyId)).IsNil()
+
+	fresh := NewStorageManager(backend, keys)
+
+	game, err := fresh.Game("LEGACY")
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(game.SecretSalt).Equals(testSalt)
+
+	state, err = fresh.State("LEGACY", 0)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(state).Equals(testState(0))
+
+	report, err = fresh.Rotate()
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(report).Equals(&RotationReport{Games: 2})
+
+}
+
+func TestKeyFile(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "encrypted")
+
+	if err != nil {
+		t.Fatal("Couldn't create temp dir: ", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "keys.json")
+
+	keys := testKeys(t)
+
+	_, err = keys.AddKey()
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(keys.Save(path)).IsNil()
+
+	info, err := os.Stat(path)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(info.Mode().Perm()).Equals(os.FileMode(0600))
+
+	loaded, err := LoadKeyFile(path)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(loaded.Current).Equals(keys.Current)
+	assert.For(t).ThatActual(loaded.Keys).Equals(keys.Keys)
+
+	for _, contents := range []string{
+		"not json",
+		`{"Current":"MISSING","Keys":{}}`,
+		`{"Current":"a.b","Keys":{"a.b":"AAAA"}}`,
+		`{"Current":"short","Keys":{"short":"AAAA"}}`,
+	} {
+		assert.For(t, contents).ThatActual(ioutil.WriteFile(path, []byte(contents), 0600)).IsNil()
+		_, err := LoadKeyFile(path)
+		assert.For(t, contents).ThatActual(err).IsNotNil()
+	}
+
+}
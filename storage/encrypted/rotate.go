@@ -0,0 +1,249 @@
+package encrypted
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/query"
+	"strconv"
+)
+
+//RotationReport describes what Rotate did.
+type RotationReport struct {
+	//Games is the number of games that were looked at.
+	Games int
+	//Rewrapped is the number of games whose data key was wrapped with an
+	//older master key, and is now wrapped with the current one.
+	Rewrapped int
+	//Encrypted is the number of games that were stored in plaintext, and
+	//are now completely encrypted.
+	Encrypted int
+}
+
+//Rotate re-wraps the data key of every game that isn't wrapped with the
+//KeyProvider's current master key, and encrypts every game that is still
+//completely or partially in plaintext. Once it returns without an error, no
+//game depends on any other master key. It's safe to call while the
+//StorageManager is in use, and to call again after an error; games that
+//are already up to date are skipped. It isn't safe to call while another
+//process is saving games to the same Backend; see the package doc. The
+//Backend must implement GameReplacer and StateReplacer.
+func (s *StorageManager) Rotate() (*RotationReport, error) {
+
+	if _, ok := s.Backend.(GameReplacer); !ok {
+		return nil, errors.New("The wrapped storage manager doesn't support replacing games")
+	}
+
+	if _, ok := s.Backend.(StateReplacer); !ok {
+		return nil, errors.New("The wrapped storage manager doesn't support replacing states")
+	}
+
+	report := &RotationReport{}
+
+	//Walk the games oldest first, since games created while rotating are
+	//already wrapped with the current key.
+	q := &query.Query{
+		Sort:      query.Created,
+		Ascending: true,
+		Limit:     query.MaxLimit,
+	}
+
+	for {
+
+		result, err := s.Backend.QueryGames(q)
+
+		if err != nil {
+			return report, errors.New("Couldn't list games: " + err.Error())
+		}
+
+		for _, game := range result.Games {
+			if err := s.rotateGame(game.Id, report); err != nil {
+				return report, errors.New("Couldn't rotate " + game.Id + ": " + err.Error())
+			}
+		}
+
+		if result.NextCursor == "" {
+			return report, nil
+		}
+
+		q.Cursor = result.NextCursor
+	}
+}
+
+//RotateInBackground runs Rotate in a new goroutine. The returned
+//DelayedError resolves once it's done, at which point the report is
+//complete.
+func (s *StorageManager) RotateInBackground() (*RotationReport, boardgame.DelayedError) {
+
+	report := &RotationReport{}
+	errChan := make(boardgame.DelayedError, 1)
+
+	go func() {
+		result, err := s.Rotate()
+		if result != nil {
+			*report = *result
+		}
+		errChan <- err
+	}()
+
+	return report, errChan
+}
+
+//rotateGame brings the game with the given id up to date, recording what it
+//did in report.
+func (s *StorageManager) rotateGame(gameId string, report *RotationReport) error {
+
+	report.Games++
+
+	record, err := s.Backend.Game(gameId)
+
+	if err != nil {
+		return err
+	}
+
+	env, err := parseEnvelope(record.SecretSalt)
+
+	if err != nil {
+		return err
+	}
+
+	currentKeyId, _, err := s.keys.CurrentKey()
+
+	if err != nil {
+		return errors.New("Couldn't get current master key: " + err.Error())
+	}
+
+	if env != nil && !env.Partial && env.KeyId == currentKeyId {
+		return nil
+	}
+
+	key, err := s.dataKeyForGame(gameId, true)
+
+	if err != nil {
+		return errors.New("Couldn't get data key: " + err.Error())
+	}
+
+	if partial, _ := s.keyState(key); partial {
+
+		//Store the data key before sealing anything with it, so that
+		//everything sealed can always be decrypted.
+		if err := s.replaceGame(gameId, key); err != nil {
+			return err
+		}
+
+		if err := s.sealPlaintext(record, key); err != nil {
+			return err
+		}
+
+		s.dataKeysLock.Lock()
+		key.partial = false
+		s.dataKeysLock.Unlock()
+
+		report.Encrypted++
+
+	} else {
+		report.Rewrapped++
+	}
+
+	return s.replaceGame(gameId, key)
+}
+
+//replaceGame re-reads the game and replaces it with a copy sealed with key,
+//wrapped with the current master key.
+func (s *StorageManager) replaceGame(gameId string, key *dataKey) error {
+
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	record, err := s.Backend.Game(gameId)
+
+	if err != nil {
+		return err
+	}
+
+	opened, err := s.openGame(record)
+
+	if err != nil {
+		return err
+	}
+
+	sealed, err := s.sealGame(opened, key)
+
+	if err != nil {
+		return errors.New("Couldn't seal game: " + err.Error())
+	}
+
+	if err := s.Backend.(GameReplacer).ReplaceGame(sealed); err != nil {
+		return errors.New("Couldn't replace game: " + err.Error())
+	}
+
+	s.dataKeysLock.Lock()
+	key.persisted = true
+	s.dataKeysLock.Unlock()
+
+	return nil
+}
+
+//sealPlaintext seals every state and agent state of game that's still in
+//plaintext. Versions that were removed by storage/retention are skipped.
+func (s *StorageManager) sealPlaintext(game *boardgame.GameStorageRecord, key *dataKey) error {
+
+	replacer := s.Backend.(StateReplacer)
+
+	for version := 0; version <= game.Version; version++ {
+
+		state, err := s.Backend.State(game.Id, version)
+
+		if errors.Is(err, boardgame.ErrVersionNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return errors.New("Couldn't fetch version " + strconv.Itoa(version) + ": " + err.Error())
+		}
+
+		if _, sealed := parseSealed(state); sealed {
+			continue
+		}
+
+		sealedState, err := sealBlob(key.key, state, stateData(game.Id, version))
+
+		if err != nil {
+			return err
+		}
+
+		if err := replacer.ReplaceState(game.Id, version, sealedState); err != nil {
+			return errors.New("Couldn't replace version " + strconv.Itoa(version) + ": " + err.Error())
+		}
+	}
+
+	for i := 0; i < game.NumPlayers; i++ {
+
+		player := boardgame.PlayerIndex(i)
+
+		state, err := s.Backend.AgentState(game.Id, player)
+
+		if err != nil {
+			return errors.New("Couldn't fetch agent state: " + err.Error())
+		}
+
+		if state == nil {
+			continue
+		}
+
+		if _, sealed := parseSealed(state); sealed {
+			continue
+		}
+
+		sealedState, err := sealBlob(key.key, state, agentData(game.Id, player))
+
+		if err != nil {
+			return err
+		}
+
+		if err := s.Backend.SaveAgentState(game.Id, player, sealedState); err != nil {
+			return errors.New("Couldn't save agent state: " + err.Error())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package encrypted
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"strconv"
+	"strings"
+)
+
+//The envelope that replaces a game's SecretSalt in storage is one of these
+//prefixes, the id of the master key the data key is wrapped with, the
+//wrapped data key and the sealed salt, separated by '.'. Partial envelopes
+//are for games that were saved in plaintext before they were wrapped, so
+//some of their older states and agent states may still be plaintext until
+//Rotate seals them.
+const (
+	envelopePrefix        = "enc1."
+	partialEnvelopePrefix = "enc1p."
+)
+
+//envelope is the parsed form of an encrypted game's SecretSalt.
+type envelope struct {
+	KeyId      string
+	WrappedKey []byte
+	Salt       []byte
+	Partial    bool
+}
+
+//sealedRecord is what a sealed state or agent state looks like in storage.
+//It's JSON so that storage managers that require states to be JSON, like
+//filesystem, can still store it, and its only key doesn't overlap with any
+//of a state's, so the two can't be confused.
+type sealedRecord struct {
+	Sealed []byte
+}
+
+//parseEnvelope returns the envelope in salt, or nil if salt is a plaintext
+//salt.
+func parseEnvelope(salt string) (*envelope, error) {
+
+	result := &envelope{}
+
+	switch {
+	case strings.HasPrefix(salt, envelopePrefix):
+		salt = strings.TrimPrefix(salt, envelopePrefix)
+	case strings.HasPrefix(salt, partialEnvelopePrefix):
+		salt = strings.TrimPrefix(salt, partialEnvelopePrefix)
+		result.Partial = true
+	default:
+		return nil, nil
+	}
+
+	parts := strings.Split(salt, ".")
+
+	if len(parts) != 3 {
+		return nil, errors.New("Malformed envelope")
+	}
+
+	result.KeyId = parts[0]
+
+	var err error
+
+	if result.WrappedKey, err = base64.RawStdEncoding.DecodeString(parts[1]); err != nil {
+		return nil, errors.New("Malformed wrapped key: " + err.Error())
+	}
+
+	if result.Salt, err = base64.RawStdEncoding.DecodeString(parts[2]); err != nil {
+		return nil, errors.New("Malformed sealed salt: " + err.Error())
+	}
+
+	return result, nil
+}
+
+func (e *envelope) String() string {
+	prefix := envelopePrefix
+	if e.Partial {
+		prefix = partialEnvelopePrefix
+	}
+	return prefix + e.KeyId + "." + base64.RawStdEncoding.EncodeToString(e.WrappedKey) + "." + base64.RawStdEncoding.EncodeToString(e.Salt)
+}
+
+//additionalData binds sealed data to the record it was sealed for, so that
+//it can't be copied into a different game or version. Game ids are compared
+//case-insensitively by the persistent storage managers, so they're
+//normalized here too.
+func additionalData(gameId string, kind string, index int) []byte {
+	result := strings.ToUpper(gameId) + "/" + kind
+	if index >= 0 {
+		result += "/" + strconv.Itoa(index)
+	}
+	return []byte(result)
+}
+
+func saltData(gameId string) []byte {
+	return additionalData(gameId, "salt", -1)
+}
+
+func dataKeyData(gameId string) []byte {
+	return additionalData(gameId, "key", -1)
+}
+
+func stateData(gameId string, version int) []byte {
+	return additionalData(gameId, "state", version)
+}
+
+func agentData(gameId string, player boardgame.PlayerIndex) []byte {
+	return additionalData(gameId, "agent", int(player))
+}
+
+//newKey returns a new random data key.
+func newKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.New("Couldn't generate data key: " + err.Error())
+	}
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.New("Invalid key: " + err.Error())
+	}
+	return cipher.NewGCM(block)
+}
+
+//seal encrypts and authenticates plaintext with AES-GCM, returning the
+//random nonce followed by the ciphertext.
+func seal(key []byte, plaintext []byte, data []byte) ([]byte, error) {
+
+	aead, err := newAEAD(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.New("Couldn't generate nonce: " + err.Error())
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, data), nil
+}
+
+//open reverses seal, failing if sealed was modified or was sealed with a
+//different key or additional data.
+func open(key []byte, sealed []byte, data []byte) ([]byte, error) {
+
+	aead, err := newAEAD(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("Sealed data is too short")
+	}
+
+	result, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], data)
+
+	if err != nil {
+		return nil, errors.New("Couldn't decrypt: " + err.Error())
+	}
+
+	return result, nil
+}
+
+//sealBlob returns blob sealed and wrapped in a sealedRecord.
+func sealBlob(key []byte, blob []byte, data []byte) ([]byte, error) {
+
+	sealed, err := seal(key, blob, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&sealedRecord{
+		Sealed: sealed,
+	})
+}
+
+//parseSealed returns the sealed data in blob, or false if blob wasn't sealed.
+func parseSealed(blob []byte) ([]byte, bool) {
+
+	var probe map[string]json.RawMessage
+
+	if err := json.Unmarshal(blob, &probe); err != nil || len(probe) != 1 {
+		return nil, false
+	}
+
+	if _, ok := probe["Sealed"]; !ok {
+		return nil, false
+	}
+
+	var record sealedRecord
+
+	if err := json.Unmarshal(blob, &record); err != nil {
+		return nil, false
+	}
+
+	return record.Sealed, true
+}
@@ -0,0 +1,263 @@
+package filesystem
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+//maxChangesSize is how big changes.jsonl can grow, in bytes, before it's
+//pruned down to the most recent changes.MaxRetained changes. It's big enough
+//that pruning only happens once in a while.
+const maxChangesSize = 2 << 20
+
+//changeRecord is how a change is stored in changes.jsonl. Its cursor is the
+//offset of the end of its line, counting the bytes that have been pruned
+//from the start of the file, so it isn't stored.
+type changeRecord struct {
+	GameId  string
+	Version int
+}
+
+//changesHeader is the first line of changes.jsonl once it's been pruned.
+//Trimmed is how many bytes of changes have been pruned from before the end
+//of the header, so that cursors don't change when the file is.
+type changesHeader struct {
+	Trimmed *int64
+}
+
+//readChangesHeader returns the Trimmed of the header of the changes in r,
+//and the length of the header line, or 0 for both if there's no header.
+func readChangesHeader(r io.ReaderAt, size int64) (trimmed int64, headerLength int64, err error) {
+
+	line, err := bufio.NewReader(io.NewSectionReader(r, 0, size)).ReadBytes('\n')
+
+	if err == io.EOF {
+		//Either an empty file, or a half written first change.
+		return 0, 0, nil
+	}
+
+	if err != nil {
+		return 0, 0, errors.New("Couldn't read changes: " + err.Error())
+	}
+
+	var header changesHeader
+
+	if err := json.Unmarshal(line, &header); err != nil || header.Trimmed == nil {
+		return 0, 0, nil
+	}
+
+	return *header.Trimmed, int64(len(line)), nil
+}
+
+func (s *StorageManager) changesPath() string {
+	return filepath.Join(s.basePath, changesFile)
+}
+
+//recordChange appends a change to changes.jsonl and sends it to watchers.
+//Unlike moves.jsonl, the file is appended to in place, since it grows with
+//every save; a line left half written by a crash is skipped when reading.
+//Once the file is bigger than maxChangesSize it's pruned.
+func (s *StorageManager) recordChange(gameId string, version int) error {
+
+	line, err := json.Marshal(&changeRecord{
+		GameId:  gameId,
+		Version: version,
+	})
+
+	if err != nil {
+		return errors.New("Couldn't encode change: " + err.Error())
+	}
+
+	f, err := os.OpenFile(s.changesPath(), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+
+	if err != nil {
+		return errors.New("Couldn't open changes: " + err.Error())
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return errors.New("Couldn't stat changes: " + err.Error())
+	}
+
+	trimmed, headerLength, err := readChangesHeader(f, info.Size())
+
+	if err != nil {
+		return err
+	}
+
+	if info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+			return errors.New("Couldn't read changes: " + err.Error())
+		}
+		if last[0] != '\n' {
+			//Start a fresh line after a half written one.
+			line = append([]byte{'\n'}, line...)
+		}
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return errors.New("Couldn't write change: " + err.Error())
+	}
+
+	size := info.Size() + int64(len(line)) + 1
+
+	s.changesHub.Publish(&changes.Change{
+		Cursor:  trimmed + size - headerLength,
+		GameId:  gameId,
+		Version: version,
+	})
+
+	if size > maxChangesSize {
+		return s.pruneChanges()
+	}
+
+	return nil
+}
+
+//pruneChanges rewrites changes.jsonl with only the most recent
+//changes.MaxRetained changes, after a header that keeps their cursors the
+//same.
+func (s *StorageManager) pruneChanges() error {
+
+	blob, err := ioutil.ReadFile(s.changesPath())
+
+	if err != nil {
+		return errors.New("Couldn't read changes: " + err.Error())
+	}
+
+	trimmed, headerLength, err := readChangesHeader(bytes.NewReader(blob), int64(len(blob)))
+
+	if err != nil {
+		return err
+	}
+
+	lines := bytes.SplitAfter(blob[headerLength:], []byte{'\n'})
+
+	//The file ends with a newline, so the last line is empty.
+	lines = lines[:len(lines)-1]
+
+	if len(lines) <= changes.MaxRetained {
+		return nil
+	}
+
+	kept := bytes.Join(lines[len(lines)-changes.MaxRetained:], nil)
+
+	trimmed += int64(len(blob)) - headerLength - int64(len(kept))
+
+	header, err := json.Marshal(&changesHeader{
+		Trimmed: &trimmed,
+	})
+
+	if err != nil {
+		return errors.New("Couldn't encode changes header: " + err.Error())
+	}
+
+	return writeFile(s.changesPath(), append(append(header, '\n'), kept...))
+}
+
+func (s *StorageManager) ChangesSince(cursor int64) (*changes.Result, error) {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	result := &changes.Result{
+		Cursor: cursor,
+	}
+
+	f, err := os.Open(s.changesPath())
+
+	if os.IsNotExist(err) {
+		if cursor == changes.Latest {
+			result.Cursor = 0
+		}
+		return result, nil
+	}
+
+	if err != nil {
+		return nil, errors.New("Couldn't open changes: " + err.Error())
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return nil, errors.New("Couldn't stat changes: " + err.Error())
+	}
+
+	trimmed, headerLength, err := readChangesHeader(f, info.Size())
+
+	if err != nil {
+		return nil, err
+	}
+
+	//base converts offsets in the file to cursors.
+	base := trimmed - headerLength
+
+	if cursor == changes.Latest {
+		result.Cursor = base + info.Size()
+		return result, nil
+	}
+
+	//A cursor from before the changes that are left catches up from the
+	//oldest one.
+	offset := cursor - base
+
+	if offset < headerLength {
+		offset = headerLength
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, errors.New("Couldn't seek in changes: " + err.Error())
+	}
+
+	reader := bufio.NewReader(f)
+
+	for len(result.Changes) < changes.MaxChanges {
+
+		line, err := reader.ReadBytes('\n')
+
+		if err == io.EOF {
+			//Either the end of the file, or a half written line.
+			break
+		}
+
+		if err != nil {
+			return nil, errors.New("Couldn't read changes: " + err.Error())
+		}
+
+		offset += int64(len(line))
+		result.Cursor = base + offset
+
+		var record changeRecord
+
+		if err := json.Unmarshal(line, &record); err != nil {
+			continue
+		}
+
+		result.Changes = append(result.Changes, &changes.Change{
+			Cursor:  base + offset,
+			GameId:  record.GameId,
+			Version: record.Version,
+		})
+	}
+
+	return result, nil
+}
+
+//Watch sends changes to watchers as they're saved, since the lock file
+//keeps other processes from saving to the same directory.
+func (s *StorageManager) Watch(gameId string) *changes.Watcher {
+	return s.changesHub.Watch(gameId)
+}
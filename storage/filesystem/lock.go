@@ -0,0 +1,46 @@
+//go:build !windows
+// +build !windows
+
+package filesystem
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+)
+
+//acquireLock opens the lock file at path and takes an exclusive advisory lock
+//on it. The OS drops the lock when the process exits, even if it never calls
+//Close, so a crash or Ctrl-C doesn't leave the directory locked. Returns
+//errLocked if another StorageManager holds the lock.
+func acquireLock(path string) (*os.File, error) {
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+		if err == syscall.EWOULDBLOCK {
+			return nil, errLocked
+		}
+		return nil, err
+	}
+
+	//The PID is only there for whoever looks at the directory; the lock
+	//itself is what matters.
+	file.Truncate(0)
+	file.WriteString(strconv.Itoa(os.Getpid()))
+
+	return file, nil
+
+}
+
+//releaseLock gives up the lock taken by acquireLock. The file is left in
+//place: removing it would let a process that opened it just before then lock
+//the removed file while another creates and locks a new one.
+func releaseLock(file *os.File, path string) {
+	file.Close()
+}
@@ -0,0 +1,79 @@
+package filesystem
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//acquireLock creates the lock file at path, recording this process's PID in
+//it. If the file already exists but the process that created it is gone, it
+//didn't shut down cleanly, so the stale file is replaced. Returns errLocked if
+//another StorageManager holds the lock.
+func acquireLock(path string) (*os.File, error) {
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+
+	if os.IsExist(err) {
+		if lockHolderAlive(path) {
+			return nil, errLocked
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	}
+
+	if os.IsExist(err) {
+		return nil, errLocked
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	file.WriteString(strconv.Itoa(os.Getpid()))
+
+	return file, nil
+
+}
+
+//lockHolderAlive returns true if the process whose PID is recorded in the
+//lock file at path is still running. On Windows os.FindProcess fails for
+//processes that don't exist.
+func lockHolderAlive(path string) bool {
+
+	contents, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		//Assume the lock is held rather than risk two writers.
+		return true
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+
+	if err != nil {
+		return true
+	}
+
+	if pid == os.Getpid() {
+		return true
+	}
+
+	process, err := os.FindProcess(pid)
+
+	if err != nil {
+		return false
+	}
+
+	process.Release()
+	return true
+
+}
+
+//releaseLock closes and removes the lock file created by acquireLock.
+func releaseLock(file *os.File, path string) {
+	file.Close()
+	os.Remove(path)
+}
@@ -0,0 +1,942 @@
+/*
+
+filesystem is a storage manager that keeps everything in plain, human-readable
+files in a directory, so that games can be opened and inspected in an editor
+when debugging, and a checked-in directory can double as golden test
+fixtures. It implements both boardgame.StorageManager and
+boardgame/server.StorageManager, so it can back a server, too, although it's
+not designed for heavy load.
+
+The layout of the directory is:
+
+	games/<ID>/game.json          the GameStorageRecord
+	games/<ID>/extended.json      the server's extended game record
+	games/<ID>/players.json       the user id in each player slot
+	games/<ID>/states/<N>.json    the state at version N, pretty-printed
+	games/<ID>/moves.jsonl        one move per line, in order
+	games/<ID>/agent-<P>.state    the agent state for player P
+	matches/<ID>.json
+	users/<ID>.json
+	cookies/<COOKIE>.json
+	changes.jsonl                 one change per line, in the order saved,
+	                              after a header once old ones are pruned
+
+Every file is written to a temporary file and then renamed into place, so
+readers never see a partially written file; changes.jsonl, which is appended
+to in place, is the only exception. While a StorageManager has the directory
+open it holds a lock on the .lock file in it, so that two processes can't
+write to the same directory at once. The lock goes away with the process
+that held it, even if it exits without calling Close.
+
+*/
+package filesystem
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"github.com/jkomoros/boardgame/server/api/extendedgame"
+	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/server/api/query"
+	"github.com/jkomoros/boardgame/server/api/users"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	gamesDir     = "games"
+	matchesDir   = "matches"
+	usersDir     = "users"
+	cookiesDir   = "cookies"
+	statesDir    = "states"
+	lockFile     = ".lock"
+	gameFile     = "game.json"
+	extendedFile = "extended.json"
+	playersFile  = "players.json"
+	movesFile    = "moves.jsonl"
+	changesFile  = "changes.jsonl"
+)
+
+type StorageManager struct {
+	basePath string
+	//lock guards every file in basePath. Reads and writes never take long
+	//enough for finer-grained locking to be worth it.
+	lock       sync.RWMutex
+	lockHandle *os.File
+	changesHub changes.Hub
+}
+
+var errLocked = errors.New("already locked")
+
+//moveRecord is how a move is stored in moves.jsonl: like a
+//boardgame.MoveStorageRecord, but with the move's JSON inline instead of
+//base64 encoded, so it's readable.
+type moveRecord struct {
+	Name      string
+	Version   int
+	Initiator int
+	Phase     int
+	Timestamp time.Time
+	Move      json.RawMessage
+}
+
+//cookieRecord is what is stored in a cookie's file.
+type cookieRecord struct {
+	UserId string
+}
+
+//NewStorageManager returns a storage manager that stores everything in the
+//directory at basePath, creating it if necessary. It returns an error if the
+//directory can't be created or another StorageManager already has it open.
+func NewStorageManager(basePath string) (*StorageManager, error) {
+
+	for _, dir := range []string{gamesDir, matchesDir, usersDir, cookiesDir} {
+		if err := os.MkdirAll(filepath.Join(basePath, dir), 0755); err != nil {
+			return nil, errors.New("Couldn't create directory: " + err.Error())
+		}
+	}
+
+	lockPath := filepath.Join(basePath, lockFile)
+
+	lockHandle, err := acquireLock(lockPath)
+
+	if err == errLocked {
+		return nil, errors.New(basePath + " is already open in another StorageManager")
+	}
+
+	if err != nil {
+		return nil, errors.New("Couldn't lock " + lockPath + ": " + err.Error())
+	}
+
+	return &StorageManager{
+		basePath:   basePath,
+		lockHandle: lockHandle,
+	}, nil
+
+}
+
+func (s *StorageManager) Name() string {
+	return "filesystem"
+}
+
+//Game ids are case insensitive, so they're always stored in upper case.
+func (s *StorageManager) gamePath(gameId string, file ...string) string {
+	return filepath.Join(append([]string{s.basePath, gamesDir, strings.ToUpper(gameId)}, file...)...)
+}
+
+func (s *StorageManager) statePath(gameId string, version int) string {
+	return s.gamePath(gameId, statesDir, strconv.Itoa(version)+".json")
+}
+
+func (s *StorageManager) agentPath(gameId string, player boardgame.PlayerIndex) string {
+	return s.gamePath(gameId, "agent-"+player.String()+".state")
+}
+
+func (s *StorageManager) matchPath(id string) string {
+	return filepath.Join(s.basePath, matchesDir, strings.ToUpper(id)+".json")
+}
+
+//User ids and cookies are opaque strings, so they're escaped to be safe to
+//use as file names.
+func (s *StorageManager) userPath(uid string) string {
+	return filepath.Join(s.basePath, usersDir, url.PathEscape(uid)+".json")
+}
+
+func (s *StorageManager) cookiePath(cookie string) string {
+	return filepath.Join(s.basePath, cookiesDir, url.PathEscape(cookie)+".json")
+}
+
+//writeFile atomically replaces the file at path with data, creating any
+//missing directories.
+func writeFile(path string, data []byte) error {
+
+	dir := filepath.Dir(path)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.New("Couldn't create directory: " + err.Error())
+	}
+
+	tmp, err := ioutil.TempFile(dir, ".tmp-")
+
+	if err != nil {
+		return errors.New("Couldn't create temporary file: " + err.Error())
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.New("Couldn't write temporary file: " + err.Error())
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.New("Couldn't close temporary file: " + err.Error())
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return errors.New("Couldn't move file into place: " + err.Error())
+	}
+
+	return nil
+}
+
+//writeJSON pretty-prints obj into the file at path.
+func writeJSON(path string, obj interface{}) error {
+	blob, err := json.MarshalIndent(obj, "", "\t")
+
+	if err != nil {
+		return errors.New("Couldn't encode " + filepath.Base(path) + ": " + err.Error())
+	}
+
+	return writeFile(path, append(blob, '\n'))
+}
+
+//readJSON decodes the file at path into obj. It returns an error for which
+//os.IsNotExist is true if the file doesn't exist.
+func readJSON(path string, obj interface{}) error {
+	blob, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(blob, obj); err != nil {
+		return errors.New("Couldn't decode " + path + ": " + err.Error())
+	}
+
+	return nil
+}
+
+func (s *StorageManager) State(gameId string, version int) (boardgame.StateStorageRecord, error) {
+	if gameId == "" {
+		return nil, errors.New("No game provided")
+	}
+
+	if version < 0 {
+		return nil, errors.New("Invalid version")
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	blob, err := ioutil.ReadFile(s.statePath(gameId, version))
+
+	if os.IsNotExist(err) {
+		if _, err := os.Stat(s.gamePath(gameId)); os.IsNotExist(err) {
+			return nil, boardgame.ErrGameNotFound
+		}
+		return nil, boardgame.ErrVersionNotFound
+	}
+
+	if err != nil {
+		return nil, errors.New("Couldn't read state: " + err.Error())
+	}
+
+	return bytes.TrimSpace(blob), nil
+}
+
+//moves returns every move stored for the game, in order.
+func (s *StorageManager) moves(gameId string) ([]*boardgame.MoveStorageRecord, error) {
+
+	f, err := os.Open(s.gamePath(gameId, movesFile))
+
+	if os.IsNotExist(err) {
+		if _, err := os.Stat(s.gamePath(gameId)); os.IsNotExist(err) {
+			return nil, boardgame.ErrGameNotFound
+		}
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.New("Couldn't open moves: " + err.Error())
+	}
+
+	defer f.Close()
+
+	var result []*boardgame.MoveStorageRecord
+
+	scanner := bufio.NewScanner(f)
+	//Moves can be bigger than the default max line length.
+	scanner.Buffer(nil, 16*1024*1024)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record moveRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, errors.New("Couldn't decode move: " + err.Error())
+		}
+		result = append(result, &boardgame.MoveStorageRecord{
+			Name:      record.Name,
+			Version:   record.Version,
+			Initiator: record.Initiator,
+			Phase:     record.Phase,
+			Timestamp: record.Timestamp,
+			Blob:      []byte(record.Move),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.New("Couldn't read moves: " + err.Error())
+	}
+
+	return result, nil
+}
+
+func (s *StorageManager) Moves(gameId string, fromVersion, toVersion int) ([]*boardgame.MoveStorageRecord, error) {
+
+	if fromVersion == toVersion {
+		fromVersion = fromVersion - 1
+	}
+
+	s.lock.RLock()
+	moves, err := s.moves(gameId)
+	s.lock.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*boardgame.MoveStorageRecord
+
+	for _, move := range moves {
+		if move.Version > fromVersion && move.Version <= toVersion {
+			result = append(result, move)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *StorageManager) Move(gameId string, version int) (*boardgame.MoveStorageRecord, error) {
+	if gameId == "" {
+		return nil, errors.New("No game provided")
+	}
+
+	if version < 0 {
+		return nil, errors.New("Invalid version")
+	}
+
+	s.lock.RLock()
+	moves, err := s.moves(gameId)
+	s.lock.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, move := range moves {
+		if move.Version == version {
+			return move, nil
+		}
+	}
+
+	return nil, boardgame.ErrVersionNotFound
+}
+
+func (s *StorageManager) game(id string) (*boardgame.GameStorageRecord, error) {
+	var game boardgame.GameStorageRecord
+
+	err := readJSON(s.gamePath(id, gameFile), &game)
+
+	if os.IsNotExist(err) {
+		return nil, boardgame.ErrGameNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &game, nil
+}
+
+func (s *StorageManager) Game(id string) (*boardgame.GameStorageRecord, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.game(id)
+}
+
+func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageRecord, state boardgame.StateStorageRecord, move *boardgame.MoveStorageRecord) error {
+	if game == nil {
+		return errors.New("No game provided")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	statePath := s.statePath(game.Id, game.Version)
+
+	if _, err := os.Stat(statePath); err == nil {
+		//Wait, there was already a version stored there?
+		return boardgame.ErrVersionConflict
+	}
+
+	var prettyState bytes.Buffer
+
+	if err := json.Indent(&prettyState, state, "", "\t"); err != nil {
+		return errors.New("State is not valid JSON: " + err.Error())
+	}
+
+	prettyState.WriteByte('\n')
+
+	if move != nil {
+		if err := s.appendMove(game.Id, move); err != nil {
+			return err
+		}
+	}
+
+	//The state is written last of the game's files, because its existence is
+	//what marks the version as saved.
+	if err := writeFile(statePath, prettyState.Bytes()); err != nil {
+		return err
+	}
+
+	eGame, err := s.extendedGame(game.Id)
+
+	if errors.Is(err, boardgame.ErrGameNotFound) {
+		eGame = extendedgame.DefaultStorageRecord()
+	} else if err != nil {
+		return err
+	} else {
+		eGame.LastActivity = time.Now().UnixNano()
+	}
+
+	if err := writeJSON(s.gamePath(game.Id, extendedFile), eGame); err != nil {
+		return err
+	}
+
+	if err := writeJSON(s.gamePath(game.Id, gameFile), game); err != nil {
+		return err
+	}
+
+	return s.recordChange(game.Id, game.Version)
+}
+
+//ReplaceGame overwrites the game record that's already saved, without saving
+//a new version, for example so storage/encrypted can re-encrypt it.
+func (s *StorageManager) ReplaceGame(game *boardgame.GameStorageRecord) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.game(game.Id); err != nil {
+		return err
+	}
+
+	return writeJSON(s.gamePath(game.Id, gameFile), game)
+}
+
+//ReplaceState overwrites the state already saved for the given version of
+//the game, for example so storage/delta can migrate the game.
+func (s *StorageManager) ReplaceState(gameId string, version int, state boardgame.StateStorageRecord) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := s.statePath(gameId, version)
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return boardgame.ErrVersionNotFound
+	}
+
+	var prettyState bytes.Buffer
+
+	if err := json.Indent(&prettyState, state, "", "\t"); err != nil {
+		return errors.New("State is not valid JSON: " + err.Error())
+	}
+
+	prettyState.WriteByte('\n')
+
+	return writeFile(path, prettyState.Bytes())
+}
+
+//appendMove adds move to the end of the game's moves.jsonl.
+func (s *StorageManager) appendMove(gameId string, move *boardgame.MoveStorageRecord) error {
+
+	if !json.Valid(move.Blob) {
+		return errors.New("Move is not valid JSON")
+	}
+
+	line, err := json.Marshal(&moveRecord{
+		Name:      move.Name,
+		Version:   move.Version,
+		Initiator: move.Initiator,
+		Phase:     move.Phase,
+		Timestamp: move.Timestamp,
+		Move:      json.RawMessage(move.Blob),
+	})
+
+	if err != nil {
+		return errors.New("Couldn't encode move: " + err.Error())
+	}
+
+	path := s.gamePath(gameId, movesFile)
+
+	existing, err := ioutil.ReadFile(path)
+
+	if err != nil && !os.IsNotExist(err) {
+		return errors.New("Couldn't read moves: " + err.Error())
+	}
+
+	//Rewrite the whole file instead of appending to it in place, so that a
+	//crash can't leave half a line behind.
+	return writeFile(path, append(append(existing, line...), '\n'))
+}
+
+func (s *StorageManager) AgentState(gameId string, player boardgame.PlayerIndex) ([]byte, error) {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	blob, err := ioutil.ReadFile(s.agentPath(gameId, player))
+
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.New("Couldn't read agent state: " + err.Error())
+	}
+
+	return blob, nil
+}
+
+func (s *StorageManager) SaveAgentState(gameId string, player boardgame.PlayerIndex, state []byte) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return writeFile(s.agentPath(gameId, player), state)
+}
+
+func (s *StorageManager) Match(id string) (*boardgame.MatchStorageRecord, error) {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var match boardgame.MatchStorageRecord
+
+	err := readJSON(s.matchPath(id), &match)
+
+	if os.IsNotExist(err) {
+		return nil, boardgame.ErrMatchNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &match, nil
+}
+
+func (s *StorageManager) SaveMatch(match *boardgame.MatchStorageRecord) error {
+	if match == nil {
+		return errors.New("No match provided")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return writeJSON(s.matchPath(match.Id), match)
+}
+
+//ListGames will return game objects for up to max number of games
+func (s *StorageManager) ListGames(max int, list listing.Type, userId string, gameType string) []*extendedgame.CombinedStorageRecord {
+
+	if (list == listing.ParticipatingActive || list == listing.ParticipatingFinished) && userId == "" {
+		//If we're filtering to only participating games and there's no userId, then there can't be any games,
+		//because the non-user can't be participating in any games.
+		return nil
+	}
+
+	if max < 1 {
+		max = 100
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	dirs, err := ioutil.ReadDir(filepath.Join(s.basePath, gamesDir))
+
+	if err != nil {
+		return nil
+	}
+
+	var result []*extendedgame.CombinedStorageRecord
+
+	for _, dir := range dirs {
+
+		if !dir.IsDir() {
+			continue
+		}
+
+		game, err := s.combinedGame(dir.Name())
+
+		if err != nil {
+			continue
+		}
+
+		if gameType != "" && game.Name != gameType {
+			continue
+		}
+
+		hasUser := false
+		numUsers := 0
+
+		for _, user := range s.userIdsForGame(game.Id) {
+			if user != "" {
+				numUsers++
+			}
+			if userId != "" && user == userId {
+				hasUser = true
+			}
+		}
+
+		numAgents := 0
+
+		for _, agent := range game.Agents {
+			if agent != "" {
+				numAgents++
+			}
+		}
+
+		hasSlots := game.NumPlayers > (numUsers + numAgents)
+
+		switch list {
+		case listing.ParticipatingActive:
+			if game.Finished || !hasUser {
+				continue
+			}
+		case listing.ParticipatingFinished:
+			if !game.Finished || !hasUser {
+				continue
+			}
+		case listing.VisibleJoinableActive:
+			if game.Finished || hasUser || !game.Visible || !game.Open || !hasSlots {
+				continue
+			}
+		case listing.VisibleActive:
+			if game.Finished || hasUser || !game.Visible || (game.Open && hasSlots) {
+				continue
+			}
+		}
+
+		result = append(result, game)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LastActivity > result[j].LastActivity
+	})
+
+	if len(result) > max {
+		result = result[:max]
+	}
+
+	return result
+
+}
+
+//QueryGames reads every game to find the ones matching q.
+func (s *StorageManager) QueryGames(q *query.Query) (*query.Result, error) {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	dirs, err := ioutil.ReadDir(filepath.Join(s.basePath, gamesDir))
+
+	if err != nil {
+		return nil, errors.New("Couldn't list games: " + err.Error())
+	}
+
+	var games []*extendedgame.CombinedStorageRecord
+
+	for _, dir := range dirs {
+
+		if !dir.IsDir() {
+			continue
+		}
+
+		game, err := s.combinedGame(dir.Name())
+
+		if err != nil {
+			continue
+		}
+
+		games = append(games, game)
+	}
+
+	return query.Apply(q, games, s.userIdsForGame)
+}
+
+func (s *StorageManager) extendedGame(id string) (*extendedgame.StorageRecord, error) {
+	var eGame extendedgame.StorageRecord
+
+	err := readJSON(s.gamePath(id, extendedFile), &eGame)
+
+	if os.IsNotExist(err) {
+		return nil, boardgame.ErrGameNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &eGame, nil
+}
+
+func (s *StorageManager) ExtendedGame(id string) (*extendedgame.StorageRecord, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.extendedGame(id)
+}
+
+func (s *StorageManager) combinedGame(id string) (*extendedgame.CombinedStorageRecord, error) {
+	eGame, err := s.extendedGame(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := s.game(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &extendedgame.CombinedStorageRecord{
+		GameStorageRecord: *game,
+		StorageRecord:     *eGame,
+	}, nil
+}
+
+func (s *StorageManager) CombinedGame(id string) (*extendedgame.CombinedStorageRecord, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.combinedGame(id)
+}
+
+func (s *StorageManager) UpdateExtendedGame(id string, eGame *extendedgame.StorageRecord) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := os.Stat(s.gamePath(id)); os.IsNotExist(err) {
+		return boardgame.ErrGameNotFound
+	}
+
+	return writeJSON(s.gamePath(id, extendedFile), eGame)
+}
+
+func (s *StorageManager) userIdsForGame(gameId string) []string {
+
+	var ids []string
+
+	err := readJSON(s.gamePath(gameId, playersFile), &ids)
+
+	if err == nil {
+		return ids
+	}
+
+	game, _ := s.game(gameId)
+
+	if game == nil {
+		return nil
+	}
+
+	return make([]string, game.NumPlayers)
+}
+
+func (s *StorageManager) UserIdsForGame(gameId string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.userIdsForGame(gameId)
+}
+
+func (s *StorageManager) SetPlayerForGame(gameId string, playerIndex boardgame.PlayerIndex, userId string) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ids := s.userIdsForGame(gameId)
+
+	if int(playerIndex) < 0 || int(playerIndex) >= len(ids) {
+		return errors.New("PlayerIndex " + playerIndex.String() + " is not valid for this game.")
+	}
+
+	if ids[playerIndex] != "" {
+		return errors.New("PlayerIndex " + playerIndex.String() + " is already taken.")
+	}
+
+	if s.getUserById(userId) == nil {
+		return errors.New("That uid does not describe an existing user")
+	}
+
+	ids[playerIndex] = userId
+
+	return writeJSON(s.gamePath(gameId, playersFile), ids)
+}
+
+//Store or update all fields
+func (s *StorageManager) UpdateUser(user *users.StorageRecord) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return writeJSON(s.userPath(user.Id), user)
+
+}
+
+func (s *StorageManager) getUserById(uid string) *users.StorageRecord {
+	var user users.StorageRecord
+
+	if err := readJSON(s.userPath(uid), &user); err != nil {
+		return nil
+	}
+
+	return &user
+}
+
+func (s *StorageManager) GetUserById(uid string) *users.StorageRecord {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.getUserById(uid)
+}
+
+func (s *StorageManager) GetUserByCookie(cookie string) *users.StorageRecord {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var record cookieRecord
+
+	if err := readJSON(s.cookiePath(cookie), &record); err != nil {
+		return nil
+	}
+
+	return s.getUserById(record.UserId)
+}
+
+//If user is nil, the cookie should be deleted if it exists. If the user
+//does not yet exist, it should be added to the database.
+func (s *StorageManager) ConnectCookieToUser(cookie string, user *users.StorageRecord) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if user == nil {
+		if err := os.Remove(s.cookiePath(cookie)); err != nil && !os.IsNotExist(err) {
+			return errors.New("Couldn't delete cookie: " + err.Error())
+		}
+		return nil
+	}
+
+	if s.getUserById(user.Id) == nil {
+		if err := writeJSON(s.userPath(user.Id), user); err != nil {
+			return err
+		}
+	}
+
+	return writeJSON(s.cookiePath(cookie), &cookieRecord{UserId: user.Id})
+}
+
+func (s *StorageManager) Connect(config string) error {
+	return nil
+}
+
+//Close releases the lock on the directory, so another StorageManager can
+//open it.
+func (s *StorageManager) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.lockHandle == nil {
+		return
+	}
+
+	releaseLock(s.lockHandle, filepath.Join(s.basePath, lockFile))
+	s.lockHandle = nil
+	s.changesHub.Close()
+}
+
+//CleanUp deletes everything the StorageManager keeps in its directory, and
+//then the directory itself if that leaves it empty. Any other files in the
+//directory are left alone.
+func (s *StorageManager) CleanUp() {
+	s.Close()
+	for _, dir := range []string{gamesDir, matchesDir, usersDir, cookiesDir} {
+		os.RemoveAll(filepath.Join(s.basePath, dir))
+	}
+	os.Remove(filepath.Join(s.basePath, changesFile))
+	os.Remove(filepath.Join(s.basePath, lockFile))
+	os.Remove(s.basePath)
+}
+
+func (s *StorageManager) PlayerMoveApplied(game *boardgame.GameStorageRecord) error {
+	//Don't need to do anything
+	return nil
+}
+
+//ListUsers returns every user, for example so storage/archive can export
+//them.
+func (s *StorageManager) ListUsers() ([]*users.StorageRecord, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var result []*users.StorageRecord
+
+	files, err := filepath.Glob(filepath.Join(s.basePath, usersDir, "*.json"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		var user users.StorageRecord
+		if err := readJSON(file, &user); err != nil {
+			return nil, errors.New("Couldn't read " + file + ": " + err.Error())
+		}
+		result = append(result, &user)
+	}
+
+	return result, nil
+}
+
+//ListCookies returns every cookie, mapped to the id of its user.
+func (s *StorageManager) ListCookies() (map[string]string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	result := make(map[string]string)
+
+	files, err := filepath.Glob(filepath.Join(s.basePath, cookiesDir, "*.json"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		cookie, err := url.PathUnescape(strings.TrimSuffix(filepath.Base(file), ".json"))
+		if err != nil {
+			return nil, errors.New("Invalid cookie file name " + file)
+		}
+		var record cookieRecord
+		if err := readJSON(file, &record); err != nil {
+			return nil, errors.New("Couldn't read " + file + ": " + err.Error())
+		}
+		result[cookie] = record.UserId
+	}
+
+	return result, nil
+}
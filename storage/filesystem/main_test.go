@@ -0,0 +1,200 @@
+package filesystem
+
+import (
+	"bytes"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/examples/tictactoe"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"github.com/jkomoros/boardgame/storage/storagetest"
+	"github.com/workfit/tester/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDir = ".testdb"
+
+func TestStorageManager(t *testing.T) {
+
+	storagetest.Test(func() storagetest.StorageManager {
+		storage, err := NewStorageManager(testDir)
+		if err != nil {
+			t.Fatal("Couldn't create storage manager: " + err.Error())
+		}
+		return storage
+	}, "filesystem", "", t)
+
+}
+
+func TestLayout(t *testing.T) {
+
+	storage, err := NewStorageManager(testDir)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	defer storage.CleanUp()
+
+	//The directory is locked while storage has it open.
+	locked, err := NewStorageManager(testDir)
+
+	assert.For(t).ThatActual(locked).IsNil()
+	assert.For(t).ThatActual(err).IsNotNil()
+
+	manager, err := tictactoe.NewManager(storage)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	move := game.PlayerMoveByName("Place Token")
+
+	assert.For(t).ThatActual(<-game.ProposeMove(move, boardgame.AdminPlayerIndex)).IsNil()
+
+	gameDir := filepath.Join(testDir, gamesDir, strings.ToUpper(game.Id()))
+
+	for _, file := range []string{gameFile, extendedFile, movesFile, filepath.Join(statesDir, "0.json")} {
+		_, err := ioutil.ReadFile(filepath.Join(gameDir, file))
+		assert.For(t, file).ThatActual(err).IsNil()
+	}
+
+	state, err := ioutil.ReadFile(filepath.Join(gameDir, statesDir, "1.json"))
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(bytes.Contains(state, []byte("\n\t"))).IsTrue()
+
+	moves, err := ioutil.ReadFile(filepath.Join(gameDir, movesFile))
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	lines := strings.Split(strings.TrimSpace(string(moves)), "\n")
+
+	assert.For(t).ThatActual(len(lines)).Equals(game.Version())
+	assert.For(t).ThatActual(strings.Contains(lines[len(lines)-1], `"Move":{`)).IsTrue()
+
+	//Once closed, another manager can open the directory and read the game
+	//back, as it would a checked-in fixture.
+	storage.Close()
+
+	reopened, err := NewStorageManager(testDir)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	defer reopened.Close()
+
+	reopenedManager, err := tictactoe.NewManager(reopened)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	refried := reopenedManager.Game(strings.ToLower(game.Id()))
+
+	assert.For(t).ThatActual(refried).IsNotNil()
+	assert.For(t).ThatActual(refried.Version()).Equals(game.Version())
+	assert.For(t).ThatActual(refried.CurrentState().StorageRecord()).Equals(game.CurrentState().StorageRecord())
+
+	lastMove, err := refried.Move(game.Version())
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(lastMove.Info().Version()).Equals(game.Version())
+
+}
+
+func TestStaleLock(t *testing.T) {
+
+	assert.For(t).ThatActual(os.MkdirAll(testDir, 0755)).IsNil()
+
+	//A lock file left behind by a process that exited without closing its
+	//StorageManager doesn't keep the directory locked.
+	assert.For(t).ThatActual(ioutil.WriteFile(filepath.Join(testDir, lockFile), []byte("999999999"), 0644)).IsNil()
+
+	//Files that the StorageManager didn't create survive CleanUp.
+	otherFile := filepath.Join(testDir, "notes.txt")
+	assert.For(t).ThatActual(ioutil.WriteFile(otherFile, []byte("notes"), 0644)).IsNil()
+	defer os.RemoveAll(testDir)
+
+	storage, err := NewStorageManager(testDir)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	storage.Close()
+
+	//Once closed, the directory can be opened again.
+	storage, err = NewStorageManager(testDir)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	storage.CleanUp()
+
+	_, err = os.Stat(otherFile)
+	assert.For(t).ThatActual(err).IsNil()
+
+	_, err = os.Stat(filepath.Join(testDir, gamesDir))
+	assert.For(t).ThatActual(os.IsNotExist(err)).IsTrue()
+
+}
+
+func TestPruneChanges(t *testing.T) {
+
+	storage, err := NewStorageManager(testDir)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	defer storage.CleanUp()
+
+	total := changes.MaxRetained + 10
+
+	for i := 0; i < total; i++ {
+		assert.For(t, i).ThatActual(storage.recordChange("PRUNE", i)).IsNil()
+	}
+
+	before, err := storage.ChangesSince(0)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	latest, err := storage.ChangesSince(changes.Latest)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	assert.For(t).ThatActual(storage.pruneChanges()).IsNil()
+
+	//Cursors stay the same once the start of the file is gone.
+	after, err := storage.ChangesSince(changes.Latest)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(after.Cursor).Equals(latest.Cursor)
+
+	result, err := storage.ChangesSince(before.Changes[14].Cursor)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(*result.Changes[0]).Equals(*before.Changes[15])
+
+	//A cursor from before the pruned changes starts at the oldest one left.
+	result, err = storage.ChangesSince(0)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(*result.Changes[0]).Equals(*before.Changes[10])
+
+	//Pruning a file that's already been pruned keeps the cursors, too.
+	assert.For(t).ThatActual(storage.recordChange("PRUNE", total)).IsNil()
+	assert.For(t).ThatActual(storage.pruneChanges()).IsNil()
+
+	result, err = storage.ChangesSince(before.Changes[14].Cursor)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(*result.Changes[0]).Equals(*before.Changes[15])
+
+	result, err = storage.ChangesSince(0)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(*result.Changes[0]).Equals(*before.Changes[11])
+
+	last, err := storage.ChangesSince(after.Cursor)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(len(last.Changes)).Equals(1)
+	assert.For(t).ThatActual(last.Changes[0].Version).Equals(total)
+
+}
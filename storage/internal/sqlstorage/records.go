@@ -1,8 +1,10 @@
-package mysql
+package sqlstorage
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/changes"
 	"github.com/jkomoros/boardgame/server/api/extendedgame"
 	"github.com/jkomoros/boardgame/server/api/users"
 	"strconv"
@@ -12,7 +14,8 @@ import (
 
 //We define our own records, primarily to decorate with tags for Gorp, but
 //also because e.g boardgame.storage.users.StorageRecord isn't structured the
-//way we actually want to store in DB.
+//way we actually want to store in DB. The size tags only matter to mysql;
+//SQLite ignores column sizes, and storage/sqlite creates its tables itself.
 
 type UserStorageRecord struct {
 	Id          string `db:",size:128"`
@@ -21,6 +24,7 @@ type UserStorageRecord struct {
 	DisplayName string `db:",size:64"`
 	PhotoUrl    string `db:",size:1024"`
 	Email       string `db:",size:128"`
+	Locale      string `db:",size:16"`
 }
 
 type CookieStorageRecord struct {
@@ -31,20 +35,45 @@ type CookieStorageRecord struct {
 type GameStorageRecord struct {
 	Name       string `db:",size:64"`
 	Id         string `db:",size:16"`
-	SecretSalt string `db:",size:16"`
+	SecretSalt string `db:",size:256"`
 	Version    int64
 	Winners    string `db:",size:128"`
-	Finished   bool
-	Created    int64
+	//Result is the JSON-encoded GameResult, or "" for nil.
+	Result   string `db:",size:65535"`
+	Finished bool
+	Created  int64
 	//NumPlayers is the reported number of players when it was created.
 	//Primarily for convenience to storage layer so they know how many players
 	//are in the game.
 	NumPlayers int64
 	Agents     string `db:",size:1024"`
+	//Components is the JSON-encoded ComponentSelection, or "" for nil.
+	Components string `db:",size:65535"`
+	//MatchId is the id of the match the game is part of, or "".
+	MatchId string `db:",size:16"`
 	//Derived field to enable HasEmptySlots SQL query
 	NumAgents int64
 }
 
+//MatchStorageRecord keeps the fields of a match that might be queried in
+//their own columns, and the whole match as JSON in Blob.
+type MatchStorageRecord struct {
+	Id       string `db:",size:16"`
+	Name     string `db:",size:64"`
+	Finished bool
+	Created  int64
+	Blob     string `db:",size:65535"`
+}
+
+//ChangeStorageRecord is a row in the change feed. Created is when it was
+//inserted, in nanoseconds.
+type ChangeStorageRecord struct {
+	Id      int64
+	GameId  string `db:",size:16"`
+	Version int64
+	Created int64
+}
+
 type ExtendedGameStorageRecord struct {
 	Id           string `db:",size:16"`
 	LastActivity int64
@@ -60,10 +89,12 @@ type CombinedGameStorageRecord struct {
 	SecretSalt   string
 	Version      int64
 	Winners      string
+	Result       string
 	Finished     bool
 	NumPlayers   int64
 	Agents       string
 	Created      int64
+	MatchId      string
 	LastActivity int64
 	Open         bool
 	Visible      bool
@@ -120,6 +151,56 @@ func winnersToString(winners []boardgame.PlayerIndex) string {
 	return strings.Join(strs, ",")
 }
 
+func componentsToString(components boardgame.ComponentSelection) string {
+	if components == nil {
+		return ""
+	}
+	blob, err := json.Marshal(components)
+	if err != nil {
+		return ""
+	}
+	return string(blob)
+}
+
+func stringToComponents(components string) (boardgame.ComponentSelection, error) {
+	if components == "" {
+		return nil, nil
+	}
+
+	var result boardgame.ComponentSelection
+
+	if err := json.Unmarshal([]byte(components), &result); err != nil {
+		return nil, errors.New("couldn't decode components: " + err.Error())
+	}
+
+	return result, nil
+}
+
+func resultToString(result *boardgame.GameResult) string {
+	if result == nil {
+		return ""
+	}
+	blob, err := json.Marshal(result)
+	if err != nil {
+		return ""
+	}
+	return string(blob)
+}
+
+func stringToResult(result string) (*boardgame.GameResult, error) {
+	if result == "" {
+		return nil, nil
+	}
+
+	var decoded boardgame.GameResult
+
+	if err := json.Unmarshal([]byte(result), &decoded); err != nil {
+		return nil, errors.New("couldn't decode result: " + err.Error())
+	}
+
+	return &decoded, nil
+}
+
 func stringToAgents(agents string) []string {
 	if agents == "" {
 		return nil
@@ -162,16 +243,31 @@ func (g *GameStorageRecord) ToStorageRecord() *boardgame.GameStorageRecord {
 		return nil
 	}
 
+	components, err := stringToComponents(g.Components)
+
+	if err != nil {
+		return nil
+	}
+
+	result, err := stringToResult(g.Result)
+
+	if err != nil {
+		return nil
+	}
+
 	return &boardgame.GameStorageRecord{
 		Name:       g.Name,
 		Id:         g.Id,
 		SecretSalt: g.SecretSalt,
 		Version:    int(g.Version),
 		Winners:    winners,
+		Result:     result,
 		Created:    time.Unix(0, g.Created),
 		Finished:   g.Finished,
 		NumPlayers: int(g.NumPlayers),
 		Agents:     stringToAgents(g.Agents),
+		Components: components,
+		Match:      g.MatchId,
 	}
 }
 
@@ -194,10 +290,13 @@ func NewGameStorageRecord(game *boardgame.GameStorageRecord) *GameStorageRecord
 		SecretSalt: game.SecretSalt,
 		Version:    int64(game.Version),
 		Winners:    winnersToString(game.Winners),
+		Result:     resultToString(game.Result),
 		NumPlayers: int64(game.NumPlayers),
 		Finished:   game.Finished,
 		Created:    game.Created.UnixNano(),
 		Agents:     agentsToString(game.Agents),
+		Components: componentsToString(game.Components),
+		MatchId:    game.Match,
 		NumAgents:  int64(numAgents),
 	}
 }
@@ -213,6 +312,12 @@ func (c *CombinedGameStorageRecord) ToStorageRecord() *extendedgame.CombinedStor
 		return nil
 	}
 
+	result, err := stringToResult(c.Result)
+
+	if err != nil {
+		return nil
+	}
+
 	return &extendedgame.CombinedStorageRecord{
 		GameStorageRecord: boardgame.GameStorageRecord{
 			Name:       c.Name,
@@ -220,10 +325,12 @@ func (c *CombinedGameStorageRecord) ToStorageRecord() *extendedgame.CombinedStor
 			SecretSalt: c.SecretSalt,
 			Version:    int(c.Version),
 			Winners:    winners,
+			Result:     result,
 			Finished:   c.Finished,
 			NumPlayers: int(c.NumPlayers),
 			Agents:     stringToAgents(c.Agents),
 			Created:    time.Unix(0, c.Created),
+			Match:      c.MatchId,
 		},
 		StorageRecord: extendedgame.StorageRecord{
 			LastActivity: c.LastActivity,
@@ -247,10 +354,12 @@ func NewCombinedGameStorageRecord(combined *extendedgame.CombinedStorageRecord)
 		SecretSalt:   combined.SecretSalt,
 		Version:      int64(combined.Version),
 		Winners:      winnersToString(combined.Winners),
+		Result:       resultToString(combined.Result),
 		NumPlayers:   int64(combined.NumPlayers),
 		Finished:     combined.Finished,
 		Agents:       agentsToString(combined.Agents),
 		Created:      combined.Created.UnixNano(),
+		MatchId:      combined.Match,
 		LastActivity: combined.LastActivity,
 		Open:         combined.Open,
 		Visible:      combined.Visible,
@@ -259,6 +368,40 @@ func NewCombinedGameStorageRecord(combined *extendedgame.CombinedStorageRecord)
 
 }
 
+func (m *MatchStorageRecord) ToStorageRecord() (*boardgame.MatchStorageRecord, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	var result boardgame.MatchStorageRecord
+
+	if err := json.Unmarshal([]byte(m.Blob), &result); err != nil {
+		return nil, errors.New("couldn't decode match: " + err.Error())
+	}
+
+	return &result, nil
+}
+
+func NewMatchStorageRecord(match *boardgame.MatchStorageRecord) (*MatchStorageRecord, error) {
+	if match == nil {
+		return nil, nil
+	}
+
+	blob, err := json.Marshal(match)
+
+	if err != nil {
+		return nil, errors.New("couldn't encode match: " + err.Error())
+	}
+
+	return &MatchStorageRecord{
+		Id:       match.Id,
+		Name:     match.Name,
+		Finished: match.Finished,
+		Created:  match.Created.UnixNano(),
+		Blob:     string(blob),
+	}, nil
+}
+
 func (e *ExtendedGameStorageRecord) ToStorageRecord() *extendedgame.StorageRecord {
 	if e == nil {
 		return nil
@@ -316,6 +459,7 @@ func (s *UserStorageRecord) ToStorageRecord() *users.StorageRecord {
 		LastSeen:    s.LastSeen,
 		PhotoUrl:    s.PhotoUrl,
 		Email:       s.Email,
+		Locale:      s.Locale,
 	}
 }
 
@@ -327,6 +471,7 @@ func NewUserStorageRecord(user *users.StorageRecord) *UserStorageRecord {
 		LastSeen:    user.LastSeen,
 		PhotoUrl:    user.PhotoUrl,
 		Email:       user.Email,
+		Locale:      user.Locale,
 	}
 }
 
@@ -359,3 +504,19 @@ func NewAgentStateStorageRecord(gameId string, player boardgame.PlayerIndex, sta
 		Blob:        string(state),
 	}
 }
+
+func NewChangeStorageRecord(gameId string, version int) *ChangeStorageRecord {
+	return &ChangeStorageRecord{
+		GameId:  gameId,
+		Version: int64(version),
+		Created: time.Now().UnixNano(),
+	}
+}
+
+func (c *ChangeStorageRecord) ToChange() *changes.Change {
+	return &changes.Change{
+		Cursor:  c.Id,
+		GameId:  c.GameId,
+		Version: int(c.Version),
+	}
+}
@@ -0,0 +1,641 @@
+/*
+
+sqlstorage holds the records, queries and read methods that storage/mysql and
+storage/sqlite share. Both store the same tables, and the queries here are
+written in SQL that both databases understand.
+
+*/
+package sqlstorage
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/go-gorp/gorp"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"github.com/jkomoros/boardgame/server/api/extendedgame"
+	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/server/api/query"
+	"github.com/jkomoros/boardgame/server/api/users"
+	"log"
+	"time"
+)
+
+const (
+	TableGames         = "games"
+	TableExtendedGames = "extendedgames"
+	TableMoves         = "moves"
+	TableUsers         = "users"
+	TableStates        = "states"
+	TableCookies       = "cookies"
+	TablePlayers       = "players"
+	TableAgentStates   = "agentstates"
+	TableMatches       = "matches"
+	TableChanges       = "changes"
+)
+
+const baseCombinedSelectQuery = "select g.Name, g.Id, g.SecretSalt, g.Version, g.Winners, g.Result, g.Finished, g.NumPlayers, g.Agents, " +
+	"g.Created, g.MatchId, e.LastActivity, e.Open, e.Visible, e.Owner"
+
+const baseCombinedFromQuery = "from " + TableGames + " g join " + TableExtendedGames + " e on g.Id = e.Id"
+
+//CombinedGameStorageRecordQuery selects every CombinedGameStorageRecord.
+//More conditions can be added with " and ...".
+const CombinedGameStorageRecordQuery = baseCombinedSelectQuery + " " + baseCombinedFromQuery + " where 1 = 1"
+
+const combinedPlayerFilterQuery = baseCombinedSelectQuery + " " + baseCombinedFromQuery + " join " + TablePlayers + " p on p.GameId = g.Id" +
+	" where p.UserId = ?"
+
+const userNotInQuery = "not exists (select * from " + TablePlayers + " where GameId = g.Id and UserId = ?)"
+
+const emptySlotsQuery = "(g.NumPlayers > coalesce(c.NumActivePlayers, 0) + g.NumAgents)"
+
+//SQLite doesn't support mysql's nested join syntax, so the player counts are
+//joined in flat.
+const combinedHasSlots = baseCombinedSelectQuery + " " + baseCombinedFromQuery +
+	" left join (select GameId, count(*) as NumActivePlayers from " + TablePlayers + " group by GameId) c on c.GameId = g.Id" +
+	" where"
+
+const combinedNotPlayerFilterQuery = combinedHasSlots + " " + userNotInQuery
+
+const combinedNotPlayerOpenSlotsQuery = combinedNotPlayerFilterQuery + " and " + emptySlotsQuery
+
+const combinedNotPlayerNoOpenSlotsQuery = combinedNotPlayerFilterQuery + " and (not " + emptySlotsQuery + " or e.Open = 0)"
+
+//Store implements the StorageManager methods that storage/mysql and
+//storage/sqlite share, on top of a connected gorp.DbMap with every record
+//type's table added. A nil *Store acts like a storage manager that isn't
+//connected yet, so the storage managers can embed one and only set it once
+//they connect.
+type Store struct {
+	dbMap *gorp.DbMap
+}
+
+//NewStore returns a Store that reads and writes with dbMap.
+func NewStore(dbMap *gorp.DbMap) *Store {
+	return &Store{
+		dbMap: dbMap,
+	}
+}
+
+func (s *Store) State(gameId string, version int) (boardgame.StateStorageRecord, error) {
+
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var state StateStorageRecord
+
+	err := s.dbMap.SelectOne(&state, "select * from "+TableStates+" where GameId=? and Version=?", gameId, version)
+
+	if err == sql.ErrNoRows {
+		return nil, boardgame.ErrVersionNotFound
+	}
+
+	if err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	return (&state).ToStorageRecord(), nil
+}
+
+func (s *Store) Move(gameId string, version int) (*boardgame.MoveStorageRecord, error) {
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var move MoveStorageRecord
+
+	err := s.dbMap.SelectOne(&move, "select * from "+TableMoves+" where GameId=? and Version=?", gameId, version)
+
+	if err == sql.ErrNoRows {
+		return nil, boardgame.ErrVersionNotFound
+	}
+
+	if err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	return (&move).ToStorageRecord(), nil
+}
+
+func (s *Store) Moves(gameId string, fromVersion, toVersion int) ([]*boardgame.MoveStorageRecord, error) {
+
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var moves []*MoveStorageRecord
+
+	if fromVersion == toVersion {
+		fromVersion = fromVersion - 1
+	}
+
+	_, err := s.dbMap.Select(&moves, "select * from "+TableMoves+" where GameId=? and Version>? and Version<=? order by Version", gameId, fromVersion, toVersion)
+
+	if err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	result := make([]*boardgame.MoveStorageRecord, len(moves))
+
+	for i, move := range moves {
+		result[i] = move.ToStorageRecord()
+	}
+
+	return result, nil
+
+}
+
+func (s *Store) Game(id string) (*boardgame.GameStorageRecord, error) {
+
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var game GameStorageRecord
+
+	err := s.dbMap.SelectOne(&game, "select * from "+TableGames+" where Id=?", id)
+
+	if err == sql.ErrNoRows {
+		return nil, boardgame.ErrGameNotFound
+	}
+
+	if err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	return (&game).ToStorageRecord(), nil
+}
+
+func (s *Store) ExtendedGame(id string) (*extendedgame.StorageRecord, error) {
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var record ExtendedGameStorageRecord
+
+	err := s.dbMap.SelectOne(&record, "select * from "+TableExtendedGames+" where Id=?", id)
+
+	if err == sql.ErrNoRows {
+		return nil, boardgame.ErrGameNotFound
+	}
+
+	if err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	return (&record).ToStorageRecord(), nil
+}
+
+func (s *Store) CombinedGame(id string) (*extendedgame.CombinedStorageRecord, error) {
+
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var record CombinedGameStorageRecord
+
+	err := s.dbMap.SelectOne(&record, CombinedGameStorageRecordQuery+" and g.Id = ?", id)
+
+	if err == sql.ErrNoRows {
+		return nil, boardgame.ErrGameNotFound
+	}
+
+	if err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	return (&record).ToStorageRecord(), nil
+}
+
+//ReplaceGame overwrites the game record that's already saved, without saving
+//a new version, for example so storage/encrypted can re-encrypt it.
+func (s *Store) ReplaceGame(game *boardgame.GameStorageRecord) error {
+
+	if s == nil {
+		return errors.New("Database not connected yet")
+	}
+
+	count, _ := s.dbMap.SelectInt("select count(*) from "+TableGames+" where Id=?", game.Id)
+
+	if count < 1 {
+		return boardgame.ErrGameNotFound
+	}
+
+	if _, err := s.dbMap.Update(NewGameStorageRecord(game)); err != nil {
+		return errors.New("Couldn't update game: " + err.Error())
+	}
+
+	return nil
+}
+
+//ReplaceState overwrites the state already saved for the given version of
+//the game, for example so storage/delta can migrate the game.
+func (s *Store) ReplaceState(gameId string, version int, state boardgame.StateStorageRecord) error {
+
+	if s == nil {
+		return errors.New("Database not connected yet")
+	}
+
+	result, err := s.dbMap.Exec("update "+TableStates+" set Blob=? where GameId=? and Version=?", string(state), gameId, version)
+
+	if err != nil {
+		return errors.New("Couldn't update state: " + err.Error())
+	}
+
+	if count, err := result.RowsAffected(); err == nil && count < 1 {
+		return boardgame.ErrVersionNotFound
+	}
+
+	return nil
+}
+
+func (s *Store) AgentState(gameId string, player boardgame.PlayerIndex) ([]byte, error) {
+
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var agent AgentStateStorageRecord
+
+	err := s.dbMap.SelectOne(&agent, "select * from "+TableAgentStates+" where GameId=? and PlayerIndex=? order by Id desc limit 1", gameId, int64(player))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return agent.ToStorageRecord(), nil
+
+}
+
+func (s *Store) SaveAgentState(gameId string, player boardgame.PlayerIndex, state []byte) error {
+	if s == nil {
+		return errors.New("Database not connected yet")
+	}
+
+	record := NewAgentStateStorageRecord(gameId, player, state)
+
+	err := s.dbMap.Insert(record)
+
+	if err != nil {
+		return errors.New("Couldn't save record: " + err.Error())
+	}
+
+	return nil
+}
+
+func (s *Store) Match(id string) (*boardgame.MatchStorageRecord, error) {
+
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var match MatchStorageRecord
+
+	err := s.dbMap.SelectOne(&match, "select * from "+TableMatches+" where Id=?", id)
+
+	if err == sql.ErrNoRows {
+		return nil, boardgame.ErrMatchNotFound
+	}
+
+	if err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	return (&match).ToStorageRecord()
+}
+
+func (s *Store) UpdateExtendedGame(id string, eGame *extendedgame.StorageRecord) error {
+
+	if s == nil {
+		return errors.New("Database not connected yet")
+	}
+
+	record := NewExtendedGameStorageRecord(eGame)
+	record.Id = id
+
+	_, err := s.dbMap.Update(record)
+
+	return err
+}
+
+func (s *Store) ListGames(max int, list listing.Type, userId string, gameType string) []*extendedgame.CombinedStorageRecord {
+
+	if s == nil {
+		return nil
+	}
+
+	var games []CombinedGameStorageRecord
+
+	if max < 1 {
+		max = 100
+	}
+
+	if (list == listing.ParticipatingActive || list == listing.ParticipatingFinished) && userId == "" {
+		//If we're filtering to only participating games and there's no userId, then there can't be any games,
+		//because the non-user can't be participating in any games.
+		return nil
+	}
+
+	query := CombinedGameStorageRecordQuery
+
+	var args []interface{}
+
+	if list != listing.All {
+
+		switch list {
+		case listing.VisibleActive:
+			query = combinedNotPlayerNoOpenSlotsQuery
+		case listing.VisibleJoinableActive:
+			query = combinedNotPlayerOpenSlotsQuery
+		default:
+			query = combinedPlayerFilterQuery
+		}
+		args = append(args, userId)
+	}
+
+	switch list {
+	case listing.ParticipatingActive:
+		query += " and g.Finished = 0"
+	case listing.ParticipatingFinished:
+		query += " and g.Finished = 1"
+	case listing.VisibleJoinableActive:
+		query += " and g.Finished = 0 and e.Visible = 1 and e.Open = 1"
+	case listing.VisibleActive:
+		query += " and g.Finished = 0 and e.Visible = 1"
+	}
+
+	if gameType != "" {
+		query += " and g.Name = ?"
+		args = append(args, gameType)
+	}
+
+	query += " order by e.LastActivity desc limit ?"
+
+	args = append(args, max)
+
+	if _, err := s.dbMap.Select(&games, query, args...); err != nil {
+		log.Println("List games failed: " + err.Error())
+		return nil
+	}
+
+	result := make([]*extendedgame.CombinedStorageRecord, len(games))
+
+	for i, record := range games {
+		result[i] = (&record).ToStorageRecord()
+	}
+
+	return result
+}
+
+//flagClause returns the where clause for column that the flag requires, or
+//"" if it doesn't filter.
+func flagClause(column string, flag query.Flag) string {
+	switch flag {
+	case query.Yes:
+		return column + " = 1"
+	case query.No:
+		return column + " = 0"
+	}
+	return ""
+}
+
+//QueryGames translates q into a single sql query, which sorts and pages with
+//the indexes on LastActivity and Created.
+func (s *Store) QueryGames(q *query.Query) (*query.Result, error) {
+
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	start, err := q.Start()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var clauses []string
+	var args []interface{}
+
+	if q.GameType != "" {
+		clauses = append(clauses, "g.Name = ?")
+		args = append(args, q.GameType)
+	}
+
+	if q.UserId != "" {
+		clauses = append(clauses, "exists (select * from "+TablePlayers+" where GameId = g.Id and UserId = ?)")
+		args = append(args, q.UserId)
+	}
+
+	switch q.HasAgents {
+	case query.Yes:
+		clauses = append(clauses, "g.NumAgents > 0")
+	case query.No:
+		clauses = append(clauses, "coalesce(g.NumAgents, 0) = 0")
+	}
+
+	for _, clause := range []string{
+		flagClause("g.Finished", q.Finished),
+		flagClause("e.Open", q.Open),
+		flagClause("e.Visible", q.Visible),
+	} {
+		if clause != "" {
+			clauses = append(clauses, clause)
+		}
+	}
+
+	for _, bound := range []struct {
+		clause string
+		time   time.Time
+	}{
+		{"g.Created >= ?", q.CreatedAfter},
+		{"g.Created < ?", q.CreatedBefore},
+		{"e.LastActivity >= ?", q.ActiveAfter},
+		{"e.LastActivity < ?", q.ActiveBefore},
+	} {
+		if !bound.time.IsZero() {
+			clauses = append(clauses, bound.clause)
+			args = append(args, bound.time.UnixNano())
+		}
+	}
+
+	if q.NumPlayers != 0 {
+		clauses = append(clauses, "g.NumPlayers = ?")
+		args = append(args, q.NumPlayers)
+	}
+
+	if q.Owner != "" {
+		clauses = append(clauses, "e.Owner = ?")
+		args = append(args, q.Owner)
+	}
+
+	sortColumn := "e.LastActivity"
+
+	if q.Sort == query.Created {
+		sortColumn = "g.Created"
+	}
+
+	direction := "desc"
+	comparison := "<"
+
+	if q.Ascending {
+		direction = "asc"
+		comparison = ">"
+	}
+
+	if start != nil {
+		clauses = append(clauses, "("+sortColumn+" "+comparison+" ? or ("+sortColumn+" = ? and g.Id "+comparison+" ?))")
+		args = append(args, start.Key, start.Key, start.Id)
+	}
+
+	sqlQuery := CombinedGameStorageRecordQuery
+
+	for _, clause := range clauses {
+		sqlQuery += " and " + clause
+	}
+
+	pageSize := q.PageSize()
+
+	//Fetch one more game than fits in the page, to know whether there's
+	//another page.
+	sqlQuery += " order by " + sortColumn + " " + direction + ", g.Id " + direction + " limit ?"
+	args = append(args, pageSize+1)
+
+	var games []CombinedGameStorageRecord
+
+	if _, err := s.dbMap.Select(&games, sqlQuery, args...); err != nil {
+		return nil, errors.New("Query games failed: " + err.Error())
+	}
+
+	result := &query.Result{
+		Games: make([]*extendedgame.CombinedStorageRecord, len(games)),
+	}
+
+	for i, record := range games {
+		result.Games[i] = (&record).ToStorageRecord()
+	}
+
+	if len(result.Games) > pageSize {
+		result.Games = result.Games[:pageSize]
+		result.NextCursor = q.CursorFor(result.Games[pageSize-1])
+	}
+
+	return result, nil
+}
+
+func (s *Store) GetUserById(uid string) *users.StorageRecord {
+	if s == nil {
+		return nil
+	}
+
+	var user UserStorageRecord
+
+	err := s.dbMap.SelectOne(&user, "select * from "+TableUsers+" where Id=?", uid)
+
+	if err == sql.ErrNoRows {
+		//Normal
+		return nil
+	}
+
+	if err != nil {
+		log.Println("Unexpected error getting user:", err)
+		return nil
+	}
+
+	return (&user).ToStorageRecord()
+}
+
+func (s *Store) GetUserByCookie(cookie string) *users.StorageRecord {
+
+	if s == nil {
+		return nil
+	}
+
+	var cookieRecord CookieStorageRecord
+
+	err := s.dbMap.SelectOne(&cookieRecord, "select * from "+TableCookies+" where Cookie=?", cookie)
+
+	if err == sql.ErrNoRows {
+		//No user
+		return nil
+	}
+
+	if err != nil {
+		log.Println("Unexpected error getting user by cookie: " + err.Error())
+		return nil
+	}
+
+	return s.GetUserById(cookieRecord.UserId)
+
+}
+
+func (s *Store) PlayerMoveApplied(game *boardgame.GameStorageRecord) error {
+	//Don't need to do anything
+	return nil
+}
+
+//ListUsers returns every user, for example so storage/archive can export
+//them.
+func (s *Store) ListUsers() ([]*users.StorageRecord, error) {
+
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var records []*UserStorageRecord
+
+	if _, err := s.dbMap.Select(&records, "select * from "+TableUsers); err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	result := make([]*users.StorageRecord, len(records))
+
+	for i, record := range records {
+		result[i] = record.ToStorageRecord()
+	}
+
+	return result, nil
+}
+
+//ListCookies returns every cookie, mapped to the id of its user.
+func (s *Store) ListCookies() (map[string]string, error) {
+
+	if s == nil {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var records []*CookieStorageRecord
+
+	if _, err := s.dbMap.Select(&records, "select * from "+TableCookies); err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	result := make(map[string]string, len(records))
+
+	for _, record := range records {
+		result[record.Cookie] = record.UserId
+	}
+
+	return result, nil
+}
+
+//PruneChanges deletes all but the most recent changes.MaxRetained changes,
+//given the id of the change that was just inserted. Pass the transaction the
+//change was inserted in, if there is one.
+func PruneChanges(exec gorp.SqlExecutor, latest int64) error {
+
+	if latest <= changes.MaxRetained {
+		return nil
+	}
+
+	if _, err := exec.Exec("delete from "+TableChanges+" where Id <= ?", latest-changes.MaxRetained); err != nil {
+		return errors.New("Couldn't prune changes: " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+
+storage holds the interface that every storage manager in its subpackages
+implements, so that the wrappers (like storage/cache and storage/delta), the
+tools (like storage/archive) and server/api can all share one definition
+without depending on each other.
+
+*/
+package storage
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"github.com/jkomoros/boardgame/server/api/extendedgame"
+	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/server/api/query"
+	"github.com/jkomoros/boardgame/server/api/users"
+)
+
+//Manager extends the base boardgame.StorageManager with the methods that
+//server/api needs. Every storage manager in this repo implements it, as does
+//every wrapper around one.
+type Manager interface {
+
+	//Manager extends the boardgame.StorageManager interface. Those
+	//methods have two additional semantic expectations, however:
+	//SaveGameAndCurrentState should create an ExtendedGameStorageRecord on
+	//the first save of a game. And each time SaveGameAndCurrentState is
+	//called, that game's Extended storage record's LastActivity should be set
+	//to the current time.
+	boardgame.StorageManager
+
+	//Name returns the name of the storage manager type, for example "memory", "bolt", "mysql", or "sqlite"
+	Name() string
+
+	//Connect will be called before issuing any other substantive calls. The
+	//config string is specific to the type of storage layer, which can be
+	//interrogated with Nmae().
+	Connect(config string) error
+
+	//ExtendedGame is like Game(), but it returns an extended storage record
+	//with additional fields necessary for Server.
+	ExtendedGame(id string) (*extendedgame.StorageRecord, error)
+
+	CombinedGame(id string) (*extendedgame.CombinedStorageRecord, error)
+
+	//UpdateExtendedGame updates the extended game with the given Id.
+	UpdateExtendedGame(id string, eGame *extendedgame.StorageRecord) error
+
+	//Close should be called before the server is shut down.
+	Close()
+
+	//ListGames should list up to max games, in descending order based on the
+	//LastActivity. If gameType is not "", only returns games that are that
+	//gameType. If gameType is "", all gametypes are fine.
+	ListGames(max int, list listing.Type, userId string, gameType string) []*extendedgame.CombinedStorageRecord
+
+	//QueryGames returns the page of games that match the query, in the
+	//query's order, starting after its Cursor. See the query package for
+	//details.
+	QueryGames(q *query.Query) (*query.Result, error)
+
+	//ChangesSince returns the changes recorded after cursor, oldest first.
+	//Every version saved by SaveGameAndCurrentState, by any process sharing
+	//the storage, is recorded. See the changes package for details.
+	ChangesSince(cursor int64) (*changes.Result, error)
+
+	//Watch returns a Watcher that receives the changes to the game with the
+	//given id, or to every game if gameId is "", as they're recorded.
+	Watch(gameId string) *changes.Watcher
+
+	//UserIdsForGame returns an array whose length equals game.NumPlayers.
+	//Each one is either empty if there is no user in that slot yet, or the
+	//uid representing the user.
+	UserIdsForGame(gameId string) []string
+
+	SetPlayerForGame(gameId string, playerIndex boardgame.PlayerIndex, userId string) error
+
+	//Store or update all fields
+	UpdateUser(user *users.StorageRecord) error
+
+	GetUserById(uid string) *users.StorageRecord
+
+	GetUserByCookie(cookie string) *users.StorageRecord
+
+	//If user is nil, the cookie should be deleted if it exists. If the user
+	//does not yet exist, it should be added to the database.
+	ConnectCookieToUser(cookie string, user *users.StorageRecord) error
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"github.com/jkomoros/boardgame/server/api/changes"
+)
+
+//recordChange adds a change to the log and sends it to watchers. The
+//cursor of each change is its index in the log, plus one, counting the
+//changes that have been pruned.
+func (s *StorageManager) recordChange(gameId string, version int) {
+
+	s.changesLock.Lock()
+
+	change := &changes.Change{
+		Cursor:  s.prunedChanges + int64(len(s.changeLog)) + 1,
+		GameId:  gameId,
+		Version: version,
+	}
+
+	s.changeLog = append(s.changeLog, change)
+
+	//Prune in batches, so the log isn't copied on every change.
+	if len(s.changeLog) >= 2*changes.MaxRetained {
+		pruned := len(s.changeLog) - changes.MaxRetained
+		s.changeLog = append([]*changes.Change(nil), s.changeLog[pruned:]...)
+		s.prunedChanges += int64(pruned)
+	}
+
+	//Publish while still holding the lock, so that watchers get changes in
+	//order.
+	s.changesHub.Publish(change)
+
+	s.changesLock.Unlock()
+}
+
+func (s *StorageManager) ChangesSince(cursor int64) (*changes.Result, error) {
+
+	s.changesLock.RLock()
+	defer s.changesLock.RUnlock()
+
+	end := s.prunedChanges + int64(len(s.changeLog))
+
+	if cursor == changes.Latest || cursor > end {
+		return &changes.Result{
+			Cursor: end,
+		}, nil
+	}
+
+	if cursor < s.prunedChanges {
+		cursor = s.prunedChanges
+	}
+
+	if end-cursor > changes.MaxChanges {
+		end = cursor + changes.MaxChanges
+	}
+
+	return &changes.Result{
+		Changes: append([]*changes.Change(nil), s.changeLog[cursor-s.prunedChanges:end-s.prunedChanges]...),
+		Cursor:  end,
+	}, nil
+}
+
+//Watch sends changes to watchers as they're saved, since no other process
+//can save to a memory storage manager.
+func (s *StorageManager) Watch(gameId string) *changes.Watcher {
+	return s.changesHub.Watch(gameId)
+}
@@ -12,10 +12,13 @@ package memory
 import (
 	"errors"
 	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/changes"
 	"github.com/jkomoros/boardgame/server/api/extendedgame"
 	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/server/api/query"
 	"github.com/jkomoros/boardgame/server/api/users"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -29,6 +32,7 @@ type StorageManager struct {
 	usersByCookie     map[string]*users.StorageRecord
 	usersForGames     map[string][]string
 	agentStates       map[string][]byte
+	matches           map[string]*boardgame.MatchStorageRecord
 	statesLock        sync.RWMutex
 	movesLock         sync.RWMutex
 	gamesLock         sync.RWMutex
@@ -36,6 +40,13 @@ type StorageManager struct {
 	usersLock         sync.RWMutex
 	usersForGamesLock sync.RWMutex
 	agentStatesLock   sync.RWMutex
+	matchesLock       sync.RWMutex
+	changeLog         []*changes.Change
+	changesLock       sync.RWMutex
+	changesHub        changes.Hub
+	//prunedChanges is how many changes have been pruned from the start of
+	//changeLog.
+	prunedChanges int64
 }
 
 func NewStorageManager() *StorageManager {
@@ -50,6 +61,7 @@ func NewStorageManager() *StorageManager {
 		usersByCookie: make(map[string]*users.StorageRecord),
 		usersForGames: make(map[string][]string),
 		agentStates:   make(map[string][]byte),
+		matches:       make(map[string]*boardgame.MatchStorageRecord),
 	}
 }
 
@@ -73,14 +85,14 @@ func (s *StorageManager) State(gameId string, version int) (boardgame.StateStora
 	s.statesLock.RUnlock()
 
 	if !ok {
-		return nil, errors.New("No such game")
+		return nil, boardgame.ErrGameNotFound
 	}
 	s.statesLock.RLock()
 	record, ok := versionMap[version]
 	s.statesLock.RUnlock()
 
 	if !ok {
-		return nil, errors.New("No such version for that game")
+		return nil, boardgame.ErrVersionNotFound
 	}
 
 	return record, nil
@@ -95,16 +107,18 @@ func (s *StorageManager) Moves(gameId string, fromVersion, toVersion int) ([]*bo
 		fromVersion = fromVersion - 1
 	}
 
-	result := make([]*boardgame.MoveStorageRecord, toVersion-fromVersion)
+	result := make([]*boardgame.MoveStorageRecord, 0, toVersion-fromVersion)
 
-	index := 0
 	for i := fromVersion + 1; i <= toVersion; i++ {
 		move, err := s.Move(gameId, i)
+		if errors.Is(err, boardgame.ErrVersionNotFound) {
+			//Skip versions with no move, like the other storage managers.
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
-		result[index] = move
-		index++
+		result = append(result, move)
 	}
 	return result, nil
 }
@@ -125,14 +139,14 @@ func (s *StorageManager) Move(gameId string, version int) (*boardgame.MoveStorag
 	s.movesLock.RUnlock()
 
 	if !ok {
-		return nil, errors.New("No such game")
+		return nil, boardgame.ErrGameNotFound
 	}
 	s.movesLock.RLock()
 	record, ok := versionMap[version]
 	s.movesLock.RUnlock()
 
 	if !ok {
-		return nil, errors.New("No such version for that game")
+		return nil, boardgame.ErrVersionNotFound
 	}
 
 	return record, nil
@@ -146,7 +160,7 @@ func (s *StorageManager) Game(id string) (*boardgame.GameStorageRecord, error) {
 	s.gamesLock.RUnlock()
 
 	if record == nil {
-		return nil, errors.New("No such game")
+		return nil, boardgame.ErrGameNotFound
 	}
 
 	return record, nil
@@ -184,7 +198,7 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 
 	if ok {
 		//Wait, there was already a version stored there?
-		return errors.New("There was already a version for that game stored")
+		return boardgame.ErrVersionConflict
 	}
 
 	s.movesLock.RLock()
@@ -194,7 +208,7 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 
 	if ok {
 		//Wait, there was already a version stored there?
-		return errors.New("There was already a version for that game stored")
+		return boardgame.ErrVersionConflict
 	}
 
 	s.extendedGamesLock.RLock()
@@ -222,6 +236,44 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 	s.games[game.Id] = game
 	s.gamesLock.Unlock()
 
+	s.recordChange(game.Id, game.Version)
+
+	return nil
+}
+
+//ReplaceGame overwrites the game record that's already saved, without saving
+//a new version, for example so storage/encrypted can re-encrypt it.
+func (s *StorageManager) ReplaceGame(game *boardgame.GameStorageRecord) error {
+	s.gamesLock.Lock()
+	defer s.gamesLock.Unlock()
+
+	if _, ok := s.games[game.Id]; !ok {
+		return boardgame.ErrGameNotFound
+	}
+
+	s.games[game.Id] = game
+
+	return nil
+}
+
+//ReplaceState overwrites the state already saved for the given version of
+//the game, for example so storage/delta can migrate the game.
+func (s *StorageManager) ReplaceState(gameId string, version int, state boardgame.StateStorageRecord) error {
+	s.statesLock.Lock()
+	defer s.statesLock.Unlock()
+
+	versionMap, ok := s.states[gameId]
+
+	if !ok {
+		return boardgame.ErrGameNotFound
+	}
+
+	if _, ok := versionMap[version]; !ok {
+		return boardgame.ErrVersionNotFound
+	}
+
+	versionMap[version] = state
+
 	return nil
 }
 
@@ -250,6 +302,41 @@ func (s *StorageManager) SaveAgentState(gameId string, player boardgame.PlayerIn
 	return nil
 }
 
+//copyMatch returns a copy of match that shares no slices with it, so that
+//records handed out can't modify the ones that are stored.
+func copyMatch(match *boardgame.MatchStorageRecord) *boardgame.MatchStorageRecord {
+	result := *match
+	result.Games = append([]string(nil), match.Games...)
+	result.Scores = append([]int(nil), match.Scores...)
+	result.Wins = append([]int(nil), match.Wins...)
+	result.Winners = append([]boardgame.PlayerIndex(nil), match.Winners...)
+	return &result
+}
+
+func (s *StorageManager) Match(id string) (*boardgame.MatchStorageRecord, error) {
+	s.matchesLock.RLock()
+	record := s.matches[id]
+	s.matchesLock.RUnlock()
+
+	if record == nil {
+		return nil, boardgame.ErrMatchNotFound
+	}
+
+	return copyMatch(record), nil
+}
+
+func (s *StorageManager) SaveMatch(match *boardgame.MatchStorageRecord) error {
+	if match == nil {
+		return errors.New("No match provided")
+	}
+
+	s.matchesLock.Lock()
+	s.matches[match.Id] = copyMatch(match)
+	s.matchesLock.Unlock()
+
+	return nil
+}
+
 //ListGames will return game objects for up to max number of games
 func (s *StorageManager) ListGames(max int, list listing.Type, userId string, gameType string) []*extendedgame.CombinedStorageRecord {
 
@@ -333,6 +420,29 @@ func (s *StorageManager) ListGames(max int, list listing.Type, userId string, ga
 
 }
 
+//QueryGames looks at every game to find the ones matching q.
+func (s *StorageManager) QueryGames(q *query.Query) (*query.Result, error) {
+
+	s.gamesLock.RLock()
+	ids := make([]string, 0, len(s.games))
+	for id := range s.games {
+		ids = append(ids, id)
+	}
+	s.gamesLock.RUnlock()
+
+	var games []*extendedgame.CombinedStorageRecord
+
+	for _, id := range ids {
+		game, err := s.CombinedGame(id)
+		if err != nil {
+			continue
+		}
+		games = append(games, game)
+	}
+
+	return query.Apply(q, games, s.UserIdsForGame)
+}
+
 func (s *StorageManager) ExtendedGame(id string) (*extendedgame.StorageRecord, error) {
 	s.extendedGamesLock.RLock()
 	eGame := s.extendedGames[id]
@@ -470,7 +580,7 @@ func (s *StorageManager) Connect(config string) error {
 }
 
 func (s *StorageManager) Close() {
-	//Don't need to do anything
+	s.changesHub.Close()
 }
 
 func (s *StorageManager) CleanUp() {
@@ -481,3 +591,168 @@ func (s *StorageManager) PlayerMoveApplied(game *boardgame.GameStorageRecord) er
 	//Don't need to do anything
 	return nil
 }
+
+//StateVersions returns the versions of the game that have a state stored, in
+//ascending order.
+func (s *StorageManager) StateVersions(gameId string) ([]int, error) {
+	s.statesLock.RLock()
+	defer s.statesLock.RUnlock()
+
+	versionMap, ok := s.states[gameId]
+
+	if !ok {
+		return nil, boardgame.ErrGameNotFound
+	}
+
+	result := make([]int, 0, len(versionMap))
+
+	for version := range versionMap {
+		result = append(result, version)
+	}
+
+	sort.Ints(result)
+
+	return result, nil
+}
+
+//DeleteStates deletes the states stored for the given versions of the game.
+func (s *StorageManager) DeleteStates(gameId string, versions []int) error {
+	s.statesLock.Lock()
+	defer s.statesLock.Unlock()
+
+	versionMap, ok := s.states[gameId]
+
+	if !ok {
+		return boardgame.ErrGameNotFound
+	}
+
+	for _, version := range versions {
+		delete(versionMap, version)
+	}
+
+	return nil
+}
+
+//DeleteGame deletes the game and everything stored for it.
+func (s *StorageManager) DeleteGame(gameId string) error {
+
+	s.gamesLock.Lock()
+	_, ok := s.games[gameId]
+	delete(s.games, gameId)
+	s.gamesLock.Unlock()
+
+	if !ok {
+		return boardgame.ErrGameNotFound
+	}
+
+	s.extendedGamesLock.Lock()
+	delete(s.extendedGames, gameId)
+	s.extendedGamesLock.Unlock()
+
+	s.statesLock.Lock()
+	delete(s.states, gameId)
+	s.statesLock.Unlock()
+
+	s.movesLock.Lock()
+	delete(s.moves, gameId)
+	s.movesLock.Unlock()
+
+	s.usersForGamesLock.Lock()
+	delete(s.usersForGames, gameId)
+	s.usersForGamesLock.Unlock()
+
+	return s.DeleteAgentStates(gameId)
+}
+
+//gameIdForAgentKey returns the id of the game that the key from keyForAgent
+//is for.
+func gameIdForAgentKey(key string) string {
+	return key[:strings.LastIndex(key, "-")]
+}
+
+//OrphanedAgentStates returns the ids of the games that have agent states
+//stored but no longer exist.
+func (s *StorageManager) OrphanedAgentStates() ([]string, error) {
+
+	s.agentStatesLock.RLock()
+	defer s.agentStatesLock.RUnlock()
+	s.gamesLock.RLock()
+	defer s.gamesLock.RUnlock()
+
+	seen := make(map[string]bool)
+
+	var result []string
+
+	for key := range s.agentStates {
+		gameId := gameIdForAgentKey(key)
+		if seen[gameId] {
+			continue
+		}
+		seen[gameId] = true
+		if _, ok := s.games[gameId]; !ok {
+			result = append(result, gameId)
+		}
+	}
+
+	return result, nil
+}
+
+//DeleteAgentStates deletes every agent state stored for the game.
+func (s *StorageManager) DeleteAgentStates(gameId string) error {
+	s.agentStatesLock.Lock()
+	defer s.agentStatesLock.Unlock()
+
+	for key := range s.agentStates {
+		if gameIdForAgentKey(key) == gameId {
+			delete(s.agentStates, key)
+		}
+	}
+
+	return nil
+}
+
+//OrphanedCookies returns the cookies connected to users that no longer
+//exist.
+func (s *StorageManager) OrphanedCookies() ([]string, error) {
+	s.usersLock.RLock()
+	defer s.usersLock.RUnlock()
+
+	var result []string
+
+	for cookie, user := range s.usersByCookie {
+		if _, ok := s.usersById[user.Id]; !ok {
+			result = append(result, cookie)
+		}
+	}
+
+	return result, nil
+}
+
+//ListUsers returns every user, for example so storage/archive can export
+//them.
+func (s *StorageManager) ListUsers() ([]*users.StorageRecord, error) {
+	s.usersLock.RLock()
+	defer s.usersLock.RUnlock()
+
+	result := make([]*users.StorageRecord, 0, len(s.usersById))
+
+	for _, user := range s.usersById {
+		result = append(result, user)
+	}
+
+	return result, nil
+}
+
+//ListCookies returns every cookie, mapped to the id of its user.
+func (s *StorageManager) ListCookies() (map[string]string, error) {
+	s.usersLock.RLock()
+	defer s.usersLock.RUnlock()
+
+	result := make(map[string]string, len(s.usersByCookie))
+
+	for cookie, user := range s.usersByCookie {
+		result[cookie] = user.Id
+	}
+
+	return result, nil
+}
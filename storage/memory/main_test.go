@@ -1,14 +1,46 @@
 package memory
 
 import (
-	"github.com/jkomoros/boardgame/storage/internal/test"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"github.com/jkomoros/boardgame/storage/storagetest"
+	"github.com/workfit/tester/assert"
 	"testing"
 )
 
 func TestStorageManager(t *testing.T) {
 
-	test.Test(func() test.StorageManager {
+	storagetest.Test(func() storagetest.StorageManager {
 		return NewStorageManager()
 	}, "memory", "", t)
 
 }
+
+func TestChangesPruned(t *testing.T) {
+
+	storage := NewStorageManager()
+
+	for i := 0; i < 2*changes.MaxRetained; i++ {
+		storage.recordChange("PRUNE", i)
+	}
+
+	assert.For(t).ThatActual(len(storage.changeLog)).Equals(changes.MaxRetained)
+
+	latest, err := storage.ChangesSince(changes.Latest)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(latest.Cursor).Equals(int64(2 * changes.MaxRetained))
+
+	//A cursor from before the pruned changes starts at the oldest one left.
+	result, err := storage.ChangesSince(0)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(len(result.Changes)).Equals(changes.MaxChanges)
+	assert.For(t).ThatActual(result.Changes[0].Version).Equals(changes.MaxRetained)
+	assert.For(t).ThatActual(result.Changes[0].Cursor).Equals(int64(changes.MaxRetained + 1))
+
+	next, err := storage.ChangesSince(result.Changes[0].Cursor)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(next.Changes[0].Version).Equals(changes.MaxRetained + 1)
+
+}
@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"github.com/jkomoros/boardgame/storage/internal/sqlstorage"
+	"time"
+)
+
+//changeGapWait is how long ChangesSince waits for a missing change to show
+//up before skipping it. Auto increment ids are handed out when a change is
+//inserted, not when it's committed, so a change with a lower id can become
+//visible after one with a higher id; a gap that lasts longer than this was
+//left by an insert that failed. It assumes that the clocks of the processes
+//sharing the database are roughly in sync.
+const changeGapWait = 5 * time.Second
+
+func (s *StorageManager) ChangesSince(cursor int64) (*changes.Result, error) {
+
+	if !s.connected {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	result := &changes.Result{
+		Cursor: cursor,
+	}
+
+	if cursor == changes.Latest {
+		latest, err := s.dbMap.SelectInt("select coalesce(max(Id), 0) from " + sqlstorage.TableChanges)
+		if err != nil {
+			return nil, errors.New("Couldn't get latest change: " + err.Error())
+		}
+		result.Cursor = latest
+		return result, nil
+	}
+
+	if cursor < 0 {
+		cursor = 0
+	}
+
+	var records []*sqlstorage.ChangeStorageRecord
+
+	if _, err := s.dbMap.Select(&records, "select * from "+sqlstorage.TableChanges+" where Id > ? order by Id limit ?", cursor, changes.MaxChanges); err != nil {
+		return nil, errors.New("Couldn't get changes: " + err.Error())
+	}
+
+	for _, record := range records {
+
+		if record.Id != cursor+1 && time.Since(time.Unix(0, record.Created)) < changeGapWait {
+			//Wait for the missing change to be committed.
+			break
+		}
+
+		result.Changes = append(result.Changes, record.ToChange())
+		result.Cursor = record.Id
+		cursor = record.Id
+	}
+
+	return result, nil
+}
+
+//Watch polls the changes table for new changes, so it sees changes saved by
+//every process using the database.
+func (s *StorageManager) Watch(gameId string) *changes.Watcher {
+	return s.poller.Watch(gameId)
+}
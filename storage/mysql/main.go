@@ -11,68 +11,35 @@ import (
 	"errors"
 	"github.com/go-gorp/gorp"
 	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/changes"
 	"github.com/jkomoros/boardgame/server/api/extendedgame"
-	"github.com/jkomoros/boardgame/server/api/listing"
 	"github.com/jkomoros/boardgame/server/api/users"
+	"github.com/jkomoros/boardgame/storage/internal/sqlstorage"
 	"github.com/jkomoros/boardgame/storage/mysql/connect"
 	"log"
 	"time"
 )
 
-const (
-	TableGames         = "games"
-	TableExtendedGames = "extendedgames"
-	TableMoves         = "moves"
-	TableUsers         = "users"
-	TableStates        = "states"
-	TableCookies       = "cookies"
-	TablePlayers       = "players"
-	TableAgentStates   = "agentstates"
-)
-
-const baseCombinedSelectQuery = "select g.Name, g.Id, g.SecretSalt, g.Version, g.Winners, g.Finished, g.NumPlayers, g.Agents, " +
-	"g.Created, e.LastActivity, e.Open, e.Visible, e.Owner"
-
-const baseCombinedFromQuery = "from " + TableGames + " g, " + TableExtendedGames + " e"
-
-const baseCombinedWhereQuery = "where g.Id = e.Id"
-
-const combinedPlayerFilterQuery = baseCombinedSelectQuery + " " + baseCombinedFromQuery + ", players p " + baseCombinedWhereQuery +
-	" and p.GameId = g.Id and p.UserId = ?"
-
-const combinedGameStorageRecordQuery = baseCombinedSelectQuery + " " + baseCombinedFromQuery + " " + baseCombinedWhereQuery
-
-const userNotInQuery = "not exists (select * from players where GameId = g.Id and UserId = ?)"
-
-const emptySlotsQuery = "(g.NumPlayers > coalesce(c.NumActivePlayers, 0) + g.NumAgents)"
-
-const combinedHasSlots = baseCombinedSelectQuery + ` from games as g
-left join extendedgames as e
-	left join (select GameId as Id, count(*) as NumActivePlayers from players group by GameId) as c
-	on e.Id = c.Id
-on g.Id = e.Id
-where`
-
-const combinedNotPlayerFilterQuery = combinedHasSlots + " " + userNotInQuery
-
-const combinedNotPlayerOpenSlotsQuery = combinedNotPlayerFilterQuery + " and " + emptySlotsQuery
-
-const combinedNotPlayerNoOpenSlotsQuery = combinedNotPlayerFilterQuery + " and (not " + emptySlotsQuery + " or e.Open = 0)"
-
+//StorageManager embeds a sqlstorage.Store, which implements the methods it
+//shares with storage/sqlite. The Store is only set while connected.
 type StorageManager struct {
+	*sqlstorage.Store
 	db       *sql.DB
 	dbMap    *gorp.DbMap
 	testMode bool
 	//The config string that we were provided in connect.
 	config    string
 	connected bool
+	poller    *changes.Poller
 }
 
 func NewStorageManager(testMode bool) *StorageManager {
 	//We actually don't do much; we do more of our work in Connect()
-	return &StorageManager{
+	result := &StorageManager{
 		testMode: testMode,
 	}
+	result.poller = changes.NewPoller(result.ChangesSince, 0)
+	return result
 
 }
 
@@ -97,21 +64,24 @@ func (s *StorageManager) Connect(config string) error {
 		},
 	}
 
-	s.dbMap.AddTableWithName(UserStorageRecord{}, TableUsers).SetKeys(false, "Id")
-	s.dbMap.AddTableWithName(GameStorageRecord{}, TableGames).SetKeys(false, "Id")
-	s.dbMap.AddTableWithName(ExtendedGameStorageRecord{}, TableExtendedGames).SetKeys(false, "Id")
-	s.dbMap.AddTableWithName(StateStorageRecord{}, TableStates).SetKeys(true, "Id")
-	s.dbMap.AddTableWithName(CookieStorageRecord{}, TableCookies).SetKeys(false, "Cookie")
-	s.dbMap.AddTableWithName(PlayerStorageRecord{}, TablePlayers).SetKeys(true, "Id")
-	s.dbMap.AddTableWithName(AgentStateStorageRecord{}, TableAgentStates).SetKeys(true, "Id")
-	s.dbMap.AddTableWithName(MoveStorageRecord{}, TableMoves).SetKeys(true, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.UserStorageRecord{}, sqlstorage.TableUsers).SetKeys(false, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.GameStorageRecord{}, sqlstorage.TableGames).SetKeys(false, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.ExtendedGameStorageRecord{}, sqlstorage.TableExtendedGames).SetKeys(false, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.StateStorageRecord{}, sqlstorage.TableStates).SetKeys(true, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.CookieStorageRecord{}, sqlstorage.TableCookies).SetKeys(false, "Cookie")
+	s.dbMap.AddTableWithName(sqlstorage.PlayerStorageRecord{}, sqlstorage.TablePlayers).SetKeys(true, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.AgentStateStorageRecord{}, sqlstorage.TableAgentStates).SetKeys(true, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.MoveStorageRecord{}, sqlstorage.TableMoves).SetKeys(true, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.MatchStorageRecord{}, sqlstorage.TableMatches).SetKeys(false, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.ChangeStorageRecord{}, sqlstorage.TableChanges).SetKeys(true, "Id")
 
-	_, err = s.dbMap.SelectInt("select count(*) from " + TableGames)
+	_, err = s.dbMap.SelectInt("select count(*) from " + sqlstorage.TableGames)
 
 	if err != nil {
 		return errors.New("Sanity check failed for db. Have you used the admin tool to migrate it up? " + err.Error())
 	}
 
+	s.Store = sqlstorage.NewStore(s.dbMap)
 	s.connected = true
 
 	return nil
@@ -122,9 +92,14 @@ func (s *StorageManager) Close() {
 	if s.db == nil {
 		return
 	}
+	//Stop polling before the database goes away, and start a new poller in
+	//case the storage manager is connected again.
+	s.poller.Close()
+	s.poller = changes.NewPoller(s.ChangesSince, 0)
 	s.db.Close()
 	s.db = nil
 	s.dbMap = nil
+	s.Store = nil
 	s.connected = false
 }
 
@@ -140,133 +115,6 @@ func (s *StorageManager) Name() string {
 	return "mysql"
 }
 
-func (s *StorageManager) State(gameId string, version int) (boardgame.StateStorageRecord, error) {
-
-	if !s.connected {
-		return nil, errors.New("Database not connected yet")
-	}
-
-	var state StateStorageRecord
-
-	err := s.dbMap.SelectOne(&state, "select * from "+TableStates+" where GameId=? and Version=?", gameId, version)
-
-	if err == sql.ErrNoRows {
-		return nil, errors.New("No such state")
-	}
-
-	if err != nil {
-		return nil, errors.New("Unexpected error: " + err.Error())
-	}
-
-	return (&state).ToStorageRecord(), nil
-}
-
-func (s *StorageManager) Moves(gameId string, fromVersion, toVersion int) ([]*boardgame.MoveStorageRecord, error) {
-
-	if !s.connected {
-		return nil, errors.New("Database not connected yet")
-	}
-
-	var moves []*MoveStorageRecord
-
-	if fromVersion == toVersion {
-		fromVersion = fromVersion - 1
-	}
-
-	_, err := s.dbMap.Select(&moves, "select * from "+TableMoves+" where GameId=? and Version>? and Version<=? order by Version", gameId, fromVersion, toVersion)
-
-	if err == sql.ErrNoRows {
-		return nil, errors.New("No moves returned")
-	}
-
-	if err != nil {
-		return nil, errors.New("Unexpected error: " + err.Error())
-	}
-
-	result := make([]*boardgame.MoveStorageRecord, len(moves))
-
-	for i, move := range moves {
-		result[i] = move.ToStorageRecord()
-	}
-
-	return result, nil
-
-}
-
-func (s *StorageManager) Move(gameId string, version int) (*boardgame.MoveStorageRecord, error) {
-	if !s.connected {
-		return nil, errors.New("Database not connected yet")
-	}
-
-	var move MoveStorageRecord
-
-	err := s.dbMap.SelectOne(&move, "select * from "+TableMoves+" where GameId=? and Version=?", gameId, version)
-
-	if err == sql.ErrNoRows {
-		return nil, errors.New("No such state")
-	}
-
-	if err != nil {
-		return nil, errors.New("Unexpected error: " + err.Error())
-	}
-
-	return (&move).ToStorageRecord(), nil
-}
-
-func (s *StorageManager) Game(id string) (*boardgame.GameStorageRecord, error) {
-
-	if !s.connected {
-		return nil, errors.New("Database not connected yet")
-	}
-
-	var game GameStorageRecord
-
-	err := s.dbMap.SelectOne(&game, "select * from "+TableGames+" where Id=?", id)
-
-	if err == sql.ErrNoRows {
-		return nil, errors.New("No such game")
-	}
-
-	if err != nil {
-		return nil, errors.New("Unexpected error: " + err.Error())
-	}
-
-	return (&game).ToStorageRecord(), nil
-}
-
-func (s *StorageManager) ExtendedGame(id string) (*extendedgame.StorageRecord, error) {
-	if !s.connected {
-		return nil, errors.New("Database not connected yet")
-	}
-
-	var record ExtendedGameStorageRecord
-
-	err := s.dbMap.SelectOne(&record, "select * from "+TableExtendedGames+" where Id=?", id)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return (&record).ToStorageRecord(), nil
-}
-
-func (s *StorageManager) CombinedGame(id string) (*extendedgame.CombinedStorageRecord, error) {
-
-	if !s.connected {
-		return nil, errors.New("Database not connected yet")
-	}
-
-	var record CombinedGameStorageRecord
-
-	err := s.dbMap.SelectOne(&record, combinedGameStorageRecordQuery+" and g.Id = ?", id)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return (&record).ToStorageRecord(), nil
-}
-
 func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageRecord, state boardgame.StateStorageRecord, move *boardgame.MoveStorageRecord) error {
 
 	if !s.connected {
@@ -275,16 +123,16 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 
 	version := game.Version
 
-	gameRecord := NewGameStorageRecord(game)
-	stateRecord := NewStateStorageRecord(game.Id, version, state)
+	gameRecord := sqlstorage.NewGameStorageRecord(game)
+	stateRecord := sqlstorage.NewStateStorageRecord(game.Id, version, state)
 
-	var moveRecord *MoveStorageRecord
+	var moveRecord *sqlstorage.MoveStorageRecord
 
 	if move != nil {
-		moveRecord = NewMoveStorageRecord(game.Id, version, move)
+		moveRecord = sqlstorage.NewMoveStorageRecord(game.Id, version, move)
 	}
 
-	count, _ := s.dbMap.SelectInt("select count(*) from "+TableGames+" where Id=?", game.Id)
+	count, _ := s.dbMap.SelectInt("select count(*) from "+sqlstorage.TableGames+" where Id=?", game.Id)
 
 	if count < 1 {
 		//Need to insert
@@ -294,7 +142,7 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 			return errors.New("Couldn't update game: " + err.Error())
 		}
 
-		extendedRecord := NewExtendedGameStorageRecord(extendedgame.DefaultStorageRecord())
+		extendedRecord := sqlstorage.NewExtendedGameStorageRecord(extendedgame.DefaultStorageRecord())
 
 		extendedRecord.Id = game.Id
 
@@ -334,13 +182,25 @@ func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageReco
 		}
 	}
 
+	changeRecord := sqlstorage.NewChangeStorageRecord(game.Id, version)
+
+	if err := s.dbMap.Insert(changeRecord); err != nil {
+		return errors.New("Couldn't insert change: " + err.Error())
+	}
+
+	if err := sqlstorage.PruneChanges(s.dbMap, changeRecord.Id); err != nil {
+		return err
+	}
+
+	s.poller.Notify()
+
 	return nil
 }
 
 func (s *StorageManager) touchExtendedGameLastActivity(id string) error {
-	var rec ExtendedGameStorageRecord
+	var rec sqlstorage.ExtendedGameStorageRecord
 
-	if err := s.dbMap.SelectOne(&rec, "select * from "+TableExtendedGames+" where Id=? limit 1", id); err != nil {
+	if err := s.dbMap.SelectOne(&rec, "select * from "+sqlstorage.TableExtendedGames+" where Id=? limit 1", id); err != nil {
 		return errors.New("Couldn't fetch lastActivity: " + err.Error())
 	}
 
@@ -353,125 +213,32 @@ func (s *StorageManager) touchExtendedGameLastActivity(id string) error {
 	return nil
 }
 
-func (s *StorageManager) AgentState(gameId string, player boardgame.PlayerIndex) ([]byte, error) {
+func (s *StorageManager) SaveMatch(match *boardgame.MatchStorageRecord) error {
 
-	if !s.connected {
-		return nil, errors.New("Database not connected yet")
-	}
-
-	var agent AgentStateStorageRecord
-
-	err := s.dbMap.SelectOne(&agent, "select * from "+TableAgentStates+" where GameId=? and PlayerIndex=? order by Id desc limit 1", gameId, int64(player))
-
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
-
-	if err != nil {
-		return nil, err
-	}
-
-	return agent.ToStorageRecord(), nil
-
-}
-
-func (s *StorageManager) SaveAgentState(gameId string, player boardgame.PlayerIndex, state []byte) error {
 	if !s.connected {
 		return errors.New("Database not connected yet")
 	}
 
-	record := NewAgentStateStorageRecord(gameId, player, state)
-
-	err := s.dbMap.Insert(record)
+	record, err := sqlstorage.NewMatchStorageRecord(match)
 
 	if err != nil {
-		return errors.New("Couldn't save record: " + err.Error())
+		return err
 	}
 
-	return nil
-}
-
-func (s *StorageManager) UpdateExtendedGame(id string, eGame *extendedgame.StorageRecord) error {
-
-	if !s.connected {
-		return errors.New("Database not connected yet")
-	}
-
-	record := NewExtendedGameStorageRecord(eGame)
-	record.Id = id
-
-	_, err := s.dbMap.Update(record)
-
-	return err
-}
-
-func (s *StorageManager) ListGames(max int, list listing.Type, userId string, gameType string) []*extendedgame.CombinedStorageRecord {
+	count, _ := s.dbMap.SelectInt("select count(*) from "+sqlstorage.TableMatches+" where Id=?", match.Id)
 
-	if !s.connected {
-		return nil
-	}
-
-	var games []CombinedGameStorageRecord
-
-	if max < 1 {
-		max = 100
-	}
-
-	if (list == listing.ParticipatingActive || list == listing.ParticipatingFinished) && userId == "" {
-		//If we're filtering to only participating games and there's no userId, then there can't be any games,
-		//because the non-user can't be participating in any games.
-		return nil
-	}
-
-	query := combinedGameStorageRecordQuery
-
-	var args []interface{}
-
-	if list != listing.All {
-
-		switch list {
-		case listing.VisibleActive:
-			query = combinedNotPlayerNoOpenSlotsQuery
-		case listing.VisibleJoinableActive:
-			query = combinedNotPlayerOpenSlotsQuery
-		default:
-			query = combinedPlayerFilterQuery
+	if count < 1 {
+		if err := s.dbMap.Insert(record); err != nil {
+			return errors.New("Couldn't insert match: " + err.Error())
 		}
-		args = append(args, userId)
-	}
-
-	switch list {
-	case listing.ParticipatingActive:
-		query += " and g.Finished = 0"
-	case listing.ParticipatingFinished:
-		query += " and g.Finished = 1"
-	case listing.VisibleJoinableActive:
-		query += " and g.Finished = 0 and e.Visible = 1 and e.Open = 1"
-	case listing.VisibleActive:
-		query += " and g.Finished = 0 and e.Visible = 1"
-	}
-
-	if gameType != "" {
-		query += " and g.Name = ?"
-		args = append(args, gameType)
-	}
-
-	query += " order by e.LastActivity desc limit ?"
-
-	args = append(args, max)
-
-	if _, err := s.dbMap.Select(&games, query, args...); err != nil {
-		log.Println("List games failed: " + err.Error())
 		return nil
 	}
 
-	result := make([]*extendedgame.CombinedStorageRecord, len(games))
-
-	for i, record := range games {
-		result[i] = (&record).ToStorageRecord()
+	if _, err := s.dbMap.Update(record); err != nil {
+		return errors.New("Couldn't update match: " + err.Error())
 	}
 
-	return result
+	return nil
 }
 
 func (s *StorageManager) SetPlayerForGame(gameId string, playerIndex boardgame.PlayerIndex, userId string) error {
@@ -496,14 +263,14 @@ func (s *StorageManager) SetPlayerForGame(gameId string, playerIndex boardgame.P
 
 	//TODO: should we validate that this is a real userId?
 
-	var player PlayerStorageRecord
+	var player sqlstorage.PlayerStorageRecord
 
-	err = s.dbMap.SelectOne(&player, "select * from "+TablePlayers+" where GameId=? and PlayerIndex=?", game.Id, int(playerIndex))
+	err = s.dbMap.SelectOne(&player, "select * from "+sqlstorage.TablePlayers+" where GameId=? and PlayerIndex=?", game.Id, int(playerIndex))
 
 	if err == sql.ErrNoRows {
 		// Insert the row
 
-		player = PlayerStorageRecord{
+		player = sqlstorage.PlayerStorageRecord{
 			GameId:      game.Id,
 			PlayerIndex: int64(playerIndex),
 			UserId:      userId,
@@ -554,9 +321,9 @@ func (s *StorageManager) UserIdsForGame(gameId string) []string {
 		return nil
 	}
 
-	var players []PlayerStorageRecord
+	var players []sqlstorage.PlayerStorageRecord
 
-	_, err = s.dbMap.Select(&players, "select * from "+TablePlayers+" where GameId=? order by PlayerIndex desc", game.Id)
+	_, err = s.dbMap.Select(&players, "select * from "+sqlstorage.TablePlayers+" where GameId=? order by PlayerIndex desc", game.Id)
 
 	result := make([]string, game.NumPlayers)
 
@@ -585,9 +352,9 @@ func (s *StorageManager) UserIdsForGame(gameId string) []string {
 }
 
 func (s *StorageManager) UpdateUser(user *users.StorageRecord) error {
-	userRecord := NewUserStorageRecord(user)
+	userRecord := sqlstorage.NewUserStorageRecord(user)
 
-	existingRecord, _ := s.dbMap.SelectInt("select count(*) from "+TableUsers+" where Id=?", user.Id)
+	existingRecord, _ := s.dbMap.SelectInt("select count(*) from "+sqlstorage.TableUsers+" where Id=?", user.Id)
 
 	if existingRecord < 1 {
 		//Need to insert
@@ -612,52 +379,6 @@ func (s *StorageManager) UpdateUser(user *users.StorageRecord) error {
 	return nil
 }
 
-func (s *StorageManager) GetUserById(uid string) *users.StorageRecord {
-	if !s.connected {
-		return nil
-	}
-
-	var user UserStorageRecord
-
-	err := s.dbMap.SelectOne(&user, "select * from "+TableUsers+" where Id=?", uid)
-
-	if err == sql.ErrNoRows {
-		//Normal
-		return nil
-	}
-
-	if err != nil {
-		log.Println("Unexpected error getting user:", err)
-		return nil
-	}
-
-	return (&user).ToStorageRecord()
-}
-
-func (s *StorageManager) GetUserByCookie(cookie string) *users.StorageRecord {
-
-	if !s.connected {
-		return nil
-	}
-
-	var cookieRecord CookieStorageRecord
-
-	err := s.dbMap.SelectOne(&cookieRecord, "select * from "+TableCookies+" where Cookie=?", cookie)
-
-	if err == sql.ErrNoRows {
-		//No user
-		return nil
-	}
-
-	if err != nil {
-		log.Println("Unexpected error getting user by cookie: " + err.Error())
-		return nil
-	}
-
-	return s.GetUserById(cookieRecord.UserId)
-
-}
-
 func (s *StorageManager) ConnectCookieToUser(cookie string, user *users.StorageRecord) error {
 
 	if !s.connected {
@@ -667,9 +388,9 @@ func (s *StorageManager) ConnectCookieToUser(cookie string, user *users.StorageR
 	//If user is nil, then delete any records with that cookie.
 	if user == nil {
 
-		var cookieRecord CookieStorageRecord
+		var cookieRecord sqlstorage.CookieStorageRecord
 
-		err := s.dbMap.SelectOne(&cookieRecord, "select * from "+TableCookies+" where Cookie=?", cookie)
+		err := s.dbMap.SelectOne(&cookieRecord, "select * from "+sqlstorage.TableCookies+" where Cookie=?", cookie)
 
 		if err == sql.ErrNoRows {
 			//We're fine, because it wasn't in the table any way!
@@ -704,7 +425,7 @@ func (s *StorageManager) ConnectCookieToUser(cookie string, user *users.StorageR
 		return nil
 	}
 
-	record := &CookieStorageRecord{
+	record := &sqlstorage.CookieStorageRecord{
 		Cookie: cookie,
 		UserId: user.Id,
 	}
@@ -715,7 +436,138 @@ func (s *StorageManager) ConnectCookieToUser(cookie string, user *users.StorageR
 	return nil
 }
 
-func (s *StorageManager) PlayerMoveApplied(game *boardgame.GameStorageRecord) error {
-	//Don't need to do anything
+//StateVersions returns the versions of the game that have a state stored, in
+//ascending order.
+func (s *StorageManager) StateVersions(gameId string) ([]int, error) {
+
+	if !s.connected {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var versions []int64
+
+	if _, err := s.dbMap.Select(&versions, "select Version from "+sqlstorage.TableStates+" where GameId=? order by Version", gameId); err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	result := make([]int, len(versions))
+
+	for i, version := range versions {
+		result[i] = int(version)
+	}
+
+	return result, nil
+}
+
+//DeleteStates deletes the states stored for the given versions of the game.
+func (s *StorageManager) DeleteStates(gameId string, versions []int) error {
+
+	if !s.connected {
+		return errors.New("Database not connected yet")
+	}
+
+	tx, err := s.dbMap.Begin()
+
+	if err != nil {
+		return errors.New("Couldn't start transaction: " + err.Error())
+	}
+
+	for _, version := range versions {
+		if _, err := tx.Exec("delete from "+sqlstorage.TableStates+" where GameId=? and Version=?", gameId, version); err != nil {
+			tx.Rollback()
+			return errors.New("Couldn't delete state: " + err.Error())
+		}
+	}
+
+	return tx.Commit()
+}
+
+//DeleteGame deletes the game and everything stored for it.
+func (s *StorageManager) DeleteGame(gameId string) error {
+
+	if !s.connected {
+		return errors.New("Database not connected yet")
+	}
+
+	tx, err := s.dbMap.Begin()
+
+	if err != nil {
+		return errors.New("Couldn't start transaction: " + err.Error())
+	}
+
+	result, err := tx.Exec("delete from "+sqlstorage.TableGames+" where Id=?", gameId)
+
+	if err != nil {
+		tx.Rollback()
+		return errors.New("Couldn't delete game: " + err.Error())
+	}
+
+	if count, err := result.RowsAffected(); err == nil && count < 1 {
+		tx.Rollback()
+		return boardgame.ErrGameNotFound
+	}
+
+	for _, query := range []string{
+		"delete from " + sqlstorage.TableExtendedGames + " where Id=?",
+		"delete from " + sqlstorage.TableStates + " where GameId=?",
+		"delete from " + sqlstorage.TableMoves + " where GameId=?",
+		"delete from " + sqlstorage.TablePlayers + " where GameId=?",
+		"delete from " + sqlstorage.TableAgentStates + " where GameId=?",
+	} {
+		if _, err := tx.Exec(query, gameId); err != nil {
+			tx.Rollback()
+			return errors.New("Couldn't delete game records: " + err.Error())
+		}
+	}
+
+	return tx.Commit()
+}
+
+//OrphanedAgentStates returns the ids of the games that have agent states
+//stored but no longer exist.
+func (s *StorageManager) OrphanedAgentStates() ([]string, error) {
+
+	if !s.connected {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var result []string
+
+	if _, err := s.dbMap.Select(&result, "select distinct a.GameId from "+sqlstorage.TableAgentStates+" a where not exists (select * from "+sqlstorage.TableGames+" where Id = a.GameId)"); err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	return result, nil
+}
+
+//DeleteAgentStates deletes every agent state stored for the game.
+func (s *StorageManager) DeleteAgentStates(gameId string) error {
+
+	if !s.connected {
+		return errors.New("Database not connected yet")
+	}
+
+	if _, err := s.dbMap.Exec("delete from "+sqlstorage.TableAgentStates+" where GameId=?", gameId); err != nil {
+		return errors.New("Couldn't delete agent states: " + err.Error())
+	}
+
 	return nil
 }
+
+//OrphanedCookies returns the cookies connected to users that no longer
+//exist.
+func (s *StorageManager) OrphanedCookies() ([]string, error) {
+
+	if !s.connected {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	var result []string
+
+	if _, err := s.dbMap.Select(&result, "select c.Cookie from "+sqlstorage.TableCookies+" c where not exists (select * from "+sqlstorage.TableUsers+" where Id = c.UserId)"); err != nil {
+		return nil, errors.New("Unexpected error: " + err.Error())
+	}
+
+	return result, nil
+}
+
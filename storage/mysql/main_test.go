@@ -1,11 +1,9 @@
 package mysql
 
 import (
-	"github.com/jkomoros/boardgame"
-	"github.com/jkomoros/boardgame/storage/internal/test"
 	"github.com/jkomoros/boardgame/storage/mysql/connect"
+	"github.com/jkomoros/boardgame/storage/storagetest"
 	"github.com/mattes/migrate"
-	"github.com/workfit/tester/assert"
 	"log"
 	"os"
 	"testing"
@@ -83,54 +81,8 @@ func GetTestDatabase(t *testing.T) *StorageManager {
 
 func TestStorageManager(t *testing.T) {
 
-	test.Test(func() test.StorageManager {
+	storagetest.Test(func() storagetest.StorageManager {
 		return GetTestDatabase(t)
 	}, "mysql", testDSN, t)
 
 }
-
-func TestWinnersConversion(t *testing.T) {
-	tests := []struct {
-		input       string
-		result      []boardgame.PlayerIndex
-		expectError bool
-	}{
-		{
-			"",
-			nil,
-			false,
-		},
-		{
-			"1,2",
-			[]boardgame.PlayerIndex{1, 2},
-			false,
-		},
-		{
-			"-1",
-			[]boardgame.PlayerIndex{-1},
-			false,
-		},
-		{
-			"1,2,",
-			nil,
-			true,
-		},
-	}
-
-	for i, test := range tests {
-		winners, err := stringToWinners(test.input)
-
-		if test.expectError {
-			assert.For(t, i).ThatActual(err).IsNotNil()
-			continue
-		} else {
-			assert.For(t, i).ThatActual(err).IsNil()
-		}
-
-		assert.For(t, i).ThatActual(winners).Equals(test.result).ThenDiffOnFail()
-
-		reInput := winnersToString(test.result)
-
-		assert.For(t, i).ThatActual(reInput).Equals(test.input)
-	}
-}
@@ -0,0 +1 @@
+alter table `games` drop column `Components`;
\ No newline at end of file
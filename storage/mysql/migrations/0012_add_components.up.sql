@@ -0,0 +1 @@
+alter table `games` add column `Components` text;
\ No newline at end of file
@@ -0,0 +1 @@
+alter table `users` drop column `Locale`;
\ No newline at end of file
@@ -0,0 +1 @@
+alter table `users` add column `Locale` varchar(16);
\ No newline at end of file
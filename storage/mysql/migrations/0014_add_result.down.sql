@@ -0,0 +1 @@
+alter table `games` drop column `Result`;
\ No newline at end of file
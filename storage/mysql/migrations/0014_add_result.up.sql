@@ -0,0 +1 @@
+alter table `games` add column `Result` text;
\ No newline at end of file
@@ -0,0 +1,2 @@
+drop table `matches`;
+alter table `games` drop column `MatchId`;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table `games` add column `MatchId` varchar(16);
+create table if not exists `matches` (`Id` varchar(16) not null primary key, `Name` varchar(64), `Finished` boolean, `Created` bigint, `Blob` text)  engine=InnoDB charset=utf8;
\ No newline at end of file
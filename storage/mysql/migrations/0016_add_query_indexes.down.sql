@@ -0,0 +1,5 @@
+drop index `players_user_game` on `players`;
+drop index `games_name_finished` on `games`;
+drop index `games_created` on `games`;
+drop index `extendedgames_owner` on `extendedgames`;
+drop index `extendedgames_last_activity` on `extendedgames`;
\ No newline at end of file
@@ -0,0 +1,5 @@
+create index `extendedgames_last_activity` on `extendedgames` (`LastActivity`, `Id`);
+create index `extendedgames_owner` on `extendedgames` (`Owner`);
+create index `games_created` on `games` (`Created`, `Id`);
+create index `games_name_finished` on `games` (`Name`, `Finished`);
+create index `players_user_game` on `players` (`UserId`, `GameId`);
\ No newline at end of file
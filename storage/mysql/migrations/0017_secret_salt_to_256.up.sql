@@ -0,0 +1 @@
+alter table games modify SecretSalt varchar(256);
\ No newline at end of file
@@ -0,0 +1 @@
+drop table `changes`;
\ No newline at end of file
@@ -0,0 +1 @@
+create table if not exists `changes` (`Id` bigint not null auto_increment primary key, `GameId` varchar(16), `Version` bigint, `Created` bigint)  engine=InnoDB charset=utf8;
\ No newline at end of file
@@ -0,0 +1,272 @@
+/*
+
+retention deletes data that storage managers would otherwise keep forever.
+Nothing in the engine or the server ever deletes a game, so without it
+storage grows without bound, including with games that were abandoned long
+before they finished.
+
+What is deleted is configured per game type with a Policy:
+
+	policies := &retention.Policies{
+		Default: retention.Policy{
+			CompactFinishedAfter: 30 * retention.Day,
+			PurgeInactiveAfter:   365 * retention.Day,
+		},
+		GameTypes: map[string]retention.Policy{
+			"tictactoe": {
+				PurgeInactiveAfter: 7 * retention.Day,
+			},
+		},
+	}
+
+	report, err := retention.Apply(storage, policies, true)
+
+Apply with dryRun set to true deletes nothing, and just reports what would
+have been deleted. Orphaned agent states (whose game no longer exists) and
+orphaned cookies (whose user no longer exists) are always deleted, since
+nothing can ever use them.
+
+Games that are part of a match are never purged, because the match would
+be left pointing at games that no longer exist. They are still compacted.
+
+*/
+package retention
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame/server/api/extendedgame"
+	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/server/api/users"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Day is a convenience for specifying Policy durations in days.
+const Day = 24 * time.Hour
+
+//DefaultKeyframeInterval is the KeyframeInterval used for policies that
+//don't set one. It's the same as storage/delta's default.
+const DefaultKeyframeInterval = 20
+
+//Storage is implemented by the storage managers that retention policies can
+//be applied to.
+type Storage interface {
+	ListGames(max int, list listing.Type, userId string, gameType string) []*extendedgame.CombinedStorageRecord
+
+	//StateVersions returns the versions of the game that still have a state
+	//stored, in ascending order.
+	StateVersions(gameId string) ([]int, error)
+
+	//DeleteStates deletes the states stored for the given versions of the
+	//game. The game's other records are untouched.
+	DeleteStates(gameId string, versions []int) error
+
+	//DeleteGame deletes the game and everything stored for it: its extended
+	//game, states, moves, players and agent states.
+	DeleteGame(gameId string) error
+
+	//OrphanedAgentStates returns the ids of the games that have agent states
+	//stored, but that no longer exist.
+	OrphanedAgentStates() ([]string, error)
+
+	//DeleteAgentStates deletes all of the agent states stored for the game.
+	DeleteAgentStates(gameId string) error
+
+	//OrphanedCookies returns the cookies that are connected to users that no
+	//longer exist.
+	OrphanedCookies() ([]string, error)
+
+	//ConnectCookieToUser with a nil user deletes the cookie.
+	ConnectCookieToUser(cookie string, user *users.StorageRecord) error
+}
+
+//Policy configures what is deleted for games of one type. A zero duration
+//disables that part of the policy, so the zero Policy deletes nothing.
+type Policy struct {
+	//CompactFinishedAfter is how long after its last activity a finished
+	//game is compacted, keeping only its keyframe states and its final
+	//state. Moves are kept, so the game's history can still be inspected.
+	CompactFinishedAfter time.Duration
+	//PurgeInactiveAfter is how long after its last activity a game, whether
+	//or not it is finished, is deleted entirely.
+	PurgeInactiveAfter time.Duration
+	//KeyframeInterval is how often a state is kept when compacting. If the
+	//storage is wrapped in storage/delta, this should be the same interval,
+	//so that the states that deltas are based on are the ones kept. If 0,
+	//DefaultKeyframeInterval is used.
+	KeyframeInterval int
+}
+
+//Policies configures retention for every game type.
+type Policies struct {
+	//Default is the policy for game types not in GameTypes.
+	Default Policy
+	//GameTypes is the policy for games of each type, by their manager's name.
+	GameTypes map[string]Policy
+}
+
+//For returns the policy for games of the given type.
+func (p *Policies) For(gameType string) Policy {
+	if policy, ok := p.GameTypes[gameType]; ok {
+		return policy
+	}
+	return p.Default
+}
+
+//Report describes what Apply deleted, or, if DryRun is true, what it would
+//have deleted.
+type Report struct {
+	DryRun bool
+	//PurgedGames are the ids of the games deleted entirely.
+	PurgedGames []string
+	//CompactedStates is the number of states deleted from each compacted
+	//game, by game id.
+	CompactedStates map[string]int
+	//OrphanedAgentStates are the ids of the missing games whose agent states
+	//were deleted.
+	OrphanedAgentStates []string
+	//OrphanedCookies are the cookies deleted because their user is missing.
+	OrphanedCookies []string
+}
+
+//String returns a human readable summary of the report.
+func (r *Report) String() string {
+
+	verb := "Deleted"
+
+	if r.DryRun {
+		verb = "Would delete"
+	}
+
+	numStates := 0
+
+	for _, count := range r.CompactedStates {
+		numStates += count
+	}
+
+	lines := []string{
+		verb + " " + strconv.Itoa(len(r.PurgedGames)) + " inactive games",
+		verb + " " + strconv.Itoa(numStates) + " states from " + strconv.Itoa(len(r.CompactedStates)) + " finished games",
+		verb + " agent states for " + strconv.Itoa(len(r.OrphanedAgentStates)) + " missing games",
+		verb + " " + strconv.Itoa(len(r.OrphanedCookies)) + " cookies for missing users",
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+//Apply applies policies to every game in storage, and deletes orphaned
+//agent states and cookies. If dryRun is true, nothing is deleted. If an
+//error is returned, the report describes what was done before it happened.
+func Apply(storage Storage, policies *Policies, dryRun bool) (*Report, error) {
+
+	if policies == nil {
+		return nil, errors.New("No policies provided")
+	}
+
+	report := &Report{
+		DryRun:          dryRun,
+		CompactedStates: make(map[string]int),
+	}
+
+	now := time.Now()
+
+	for _, game := range storage.ListGames(math.MaxInt32, listing.All, "", "") {
+
+		policy := policies.For(game.Name)
+
+		inactive := now.Sub(time.Unix(0, game.LastActivity))
+
+		if policy.PurgeInactiveAfter > 0 && inactive > policy.PurgeInactiveAfter && game.Match == "" {
+			if !dryRun {
+				if err := storage.DeleteGame(game.Id); err != nil {
+					return report, errors.New("Couldn't delete game " + game.Id + ": " + err.Error())
+				}
+			}
+			report.PurgedGames = append(report.PurgedGames, game.Id)
+			continue
+		}
+
+		if policy.CompactFinishedAfter > 0 && inactive > policy.CompactFinishedAfter && game.Finished {
+			versions, err := statesToCompact(storage, game.Id, game.Version, policy.KeyframeInterval)
+			if err != nil {
+				return report, errors.New("Couldn't list states for game " + game.Id + ": " + err.Error())
+			}
+			if len(versions) == 0 {
+				continue
+			}
+			if !dryRun {
+				if err := storage.DeleteStates(game.Id, versions); err != nil {
+					return report, errors.New("Couldn't delete states for game " + game.Id + ": " + err.Error())
+				}
+			}
+			report.CompactedStates[game.Id] = len(versions)
+		}
+	}
+
+	orphanedGames, err := storage.OrphanedAgentStates()
+
+	if err != nil {
+		return report, errors.New("Couldn't list orphaned agent states: " + err.Error())
+	}
+
+	sort.Strings(orphanedGames)
+
+	for _, gameId := range orphanedGames {
+		if !dryRun {
+			if err := storage.DeleteAgentStates(gameId); err != nil {
+				return report, errors.New("Couldn't delete agent states for " + gameId + ": " + err.Error())
+			}
+		}
+		report.OrphanedAgentStates = append(report.OrphanedAgentStates, gameId)
+	}
+
+	orphanedCookies, err := storage.OrphanedCookies()
+
+	if err != nil {
+		return report, errors.New("Couldn't list orphaned cookies: " + err.Error())
+	}
+
+	sort.Strings(orphanedCookies)
+
+	for _, cookie := range orphanedCookies {
+		if !dryRun {
+			if err := storage.ConnectCookieToUser(cookie, nil); err != nil {
+				return report, errors.New("Couldn't delete cookie: " + err.Error())
+			}
+		}
+		report.OrphanedCookies = append(report.OrphanedCookies, cookie)
+	}
+
+	sort.Strings(report.PurgedGames)
+
+	return report, nil
+}
+
+//statesToCompact returns the versions of the states of the game that
+//compacting it would delete.
+func statesToCompact(storage Storage, gameId string, currentVersion int, keyframeInterval int) ([]int, error) {
+
+	if keyframeInterval <= 0 {
+		keyframeInterval = DefaultKeyframeInterval
+	}
+
+	versions, err := storage.StateVersions(gameId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []int
+
+	for _, version := range versions {
+		if version%keyframeInterval == 0 || version == currentVersion {
+			continue
+		}
+		result = append(result, version)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package retention
+
+import (
+	"github.com/jkomoros/boardgame/examples/tictactoe"
+	"github.com/jkomoros/boardgame/storage/memory"
+	"github.com/workfit/tester/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGameTypePolicies(t *testing.T) {
+
+	storage := memory.NewStorageManager()
+
+	manager, err := tictactoe.NewManager(storage)
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	game := manager.NewGame()
+
+	assert.For(t).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	eGame, err := storage.ExtendedGame(game.Id())
+
+	assert.For(t).ThatActual(err).IsNil()
+
+	eGame.LastActivity = time.Now().Add(-10 * Day).UnixNano()
+
+	assert.For(t).ThatActual(storage.UpdateExtendedGame(game.Id(), eGame)).IsNil()
+
+	policies := &Policies{
+		Default: Policy{
+			PurgeInactiveAfter: 7 * Day,
+		},
+		GameTypes: map[string]Policy{
+			manager.Delegate().Name(): {
+				PurgeInactiveAfter: 30 * Day,
+			},
+		},
+	}
+
+	assert.For(t).ThatActual(policies.For("other").PurgeInactiveAfter).Equals(7 * Day)
+
+	report, err := Apply(storage, policies, true)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(len(report.PurgedGames)).Equals(0)
+
+	delete(policies.GameTypes, manager.Delegate().Name())
+
+	report, err = Apply(storage, policies, true)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(report.PurgedGames).Equals([]string{game.Id()})
+	assert.For(t).ThatActual(strings.HasPrefix(report.String(), "Would delete 1 inactive games\n")).IsTrue()
+
+	//The zero policy deletes nothing.
+	report, err = Apply(storage, &Policies{}, false)
+
+	assert.For(t).ThatActual(err).IsNil()
+	assert.For(t).ThatActual(len(report.PurgedGames)).Equals(0)
+
+	_, err = storage.Game(game.Id())
+
+	assert.For(t).ThatActual(err).IsNil()
+
+}
@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"github.com/jkomoros/boardgame/storage/internal/sqlstorage"
+)
+
+//ChangesSince returns the changes after cursor. Changes are inserted in the
+//same transaction as the version they record, and sqlite only has one
+//writer at a time, so they become visible in the order of their ids.
+func (s *StorageManager) ChangesSince(cursor int64) (*changes.Result, error) {
+
+	if !s.connected {
+		return nil, errors.New("Database not connected yet")
+	}
+
+	result := &changes.Result{
+		Cursor: cursor,
+	}
+
+	if cursor == changes.Latest {
+		latest, err := s.dbMap.SelectInt("select coalesce(max(Id), 0) from " + sqlstorage.TableChanges)
+		if err != nil {
+			return nil, errors.New("Couldn't get latest change: " + err.Error())
+		}
+		result.Cursor = latest
+		return result, nil
+	}
+
+	var records []*sqlstorage.ChangeStorageRecord
+
+	if _, err := s.dbMap.Select(&records, "select * from "+sqlstorage.TableChanges+" where Id > ? order by Id limit ?", cursor, changes.MaxChanges); err != nil {
+		return nil, errors.New("Couldn't get changes: " + err.Error())
+	}
+
+	for _, record := range records {
+		result.Changes = append(result.Changes, record.ToChange())
+		result.Cursor = record.Id
+	}
+
+	return result, nil
+}
+
+//Watch polls the changes table for new changes, so it sees changes saved by
+//every process using the database file.
+func (s *StorageManager) Watch(gameId string) *changes.Watcher {
+	return s.poller.Watch(gameId)
+}
@@ -0,0 +1,401 @@
+/*
+
+sqlite provides a SQLite-backed database that implements both
+boardgame.StorageManager and boardgame/server.StorageManager. It uses a pure
+Go driver, so it needs neither cgo nor a database server, which makes it a
+good fit for single-box deployments and CI. The tables are the same as the
+ones in storage/mysql, and are created or migrated automatically by Connect.
+
+The config string passed to Connect is the path of the database file, which
+will be created if it doesn't exist.
+
+*/
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/go-gorp/gorp"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"github.com/jkomoros/boardgame/server/api/extendedgame"
+	"github.com/jkomoros/boardgame/server/api/users"
+	"github.com/jkomoros/boardgame/storage/internal/sqlstorage"
+	"log"
+	"os"
+	"time"
+	//Registers the "sqlite" driver.
+	_ "modernc.org/sqlite"
+)
+
+//StorageManager embeds a sqlstorage.Store, which implements the methods it
+//shares with storage/mysql. The Store is only set while connected.
+type StorageManager struct {
+	*sqlstorage.Store
+	db       *sql.DB
+	dbMap    *gorp.DbMap
+	testMode bool
+	//The filename that we were provided in connect.
+	filename  string
+	connected bool
+	poller    *changes.Poller
+}
+
+//NewStorageManager returns a new storage manager, which won't do anything
+//until Connect is called. If testMode is true, CleanUp deletes the database
+//file.
+func NewStorageManager(testMode bool) *StorageManager {
+	result := &StorageManager{
+		testMode: testMode,
+	}
+	result.poller = changes.NewPoller(result.ChangesSince, 0)
+	return result
+}
+
+//Connect opens the database file at the path given by config, creating it if
+//necessary, and brings its tables up to date.
+func (s *StorageManager) Connect(config string) error {
+
+	if config == "" {
+		return errors.New("No database filename provided")
+	}
+
+	db, err := sql.Open("sqlite", config)
+
+	if err != nil {
+		return errors.New("Couldn't open db: " + err.Error())
+	}
+
+	//SQLite only allows one writer at a time. Funnelling everything through
+	//one connection avoids "database is locked" errors, and means the pragmas
+	//below apply to every query.
+	db.SetMaxOpenConns(1)
+
+	for _, pragma := range []string{
+		"pragma journal_mode = wal",
+		"pragma busy_timeout = 5000",
+		"pragma synchronous = normal",
+	} {
+		if _, err := db.Exec(pragma); err != nil {
+			db.Close()
+			return errors.New("Couldn't configure db: " + err.Error())
+		}
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return errors.New("Couldn't migrate db: " + err.Error())
+	}
+
+	s.filename = config
+
+	s.db = db
+
+	s.dbMap = &gorp.DbMap{
+		Db:      db,
+		Dialect: gorp.SqliteDialect{},
+	}
+
+	s.dbMap.AddTableWithName(sqlstorage.UserStorageRecord{}, sqlstorage.TableUsers).SetKeys(false, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.GameStorageRecord{}, sqlstorage.TableGames).SetKeys(false, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.ExtendedGameStorageRecord{}, sqlstorage.TableExtendedGames).SetKeys(false, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.StateStorageRecord{}, sqlstorage.TableStates).SetKeys(true, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.CookieStorageRecord{}, sqlstorage.TableCookies).SetKeys(false, "Cookie")
+	s.dbMap.AddTableWithName(sqlstorage.PlayerStorageRecord{}, sqlstorage.TablePlayers).SetKeys(true, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.AgentStateStorageRecord{}, sqlstorage.TableAgentStates).SetKeys(true, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.MoveStorageRecord{}, sqlstorage.TableMoves).SetKeys(true, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.MatchStorageRecord{}, sqlstorage.TableMatches).SetKeys(false, "Id")
+	s.dbMap.AddTableWithName(sqlstorage.ChangeStorageRecord{}, sqlstorage.TableChanges).SetKeys(true, "Id")
+
+	s.Store = sqlstorage.NewStore(s.dbMap)
+	s.connected = true
+
+	return nil
+
+}
+
+func (s *StorageManager) Close() {
+	if s.db == nil {
+		return
+	}
+	//Stop polling before the database goes away, and start a new poller in
+	//case the storage manager is connected again.
+	s.poller.Close()
+	s.poller = changes.NewPoller(s.ChangesSince, 0)
+	s.db.Close()
+	s.db = nil
+	s.dbMap = nil
+	s.Store = nil
+	s.connected = false
+}
+
+func (s *StorageManager) CleanUp() {
+	if !s.testMode || s.filename == "" {
+		return
+	}
+	s.Close()
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		os.Remove(s.filename + suffix)
+	}
+}
+
+func (s *StorageManager) Name() string {
+	return "sqlite"
+}
+
+//SaveGameAndCurrentState saves the game, state, and move in one transaction,
+//so a crash can't leave a game whose version has no state.
+func (s *StorageManager) SaveGameAndCurrentState(game *boardgame.GameStorageRecord, state boardgame.StateStorageRecord, move *boardgame.MoveStorageRecord) error {
+
+	if !s.connected {
+		return errors.New("Database not connected yet")
+	}
+
+	version := game.Version
+
+	gameRecord := sqlstorage.NewGameStorageRecord(game)
+	stateRecord := sqlstorage.NewStateStorageRecord(game.Id, version, state)
+
+	var moveRecord *sqlstorage.MoveStorageRecord
+
+	if move != nil {
+		moveRecord = sqlstorage.NewMoveStorageRecord(game.Id, version, move)
+	}
+
+	tx, err := s.dbMap.Begin()
+
+	if err != nil {
+		return errors.New("Couldn't start transaction: " + err.Error())
+	}
+
+	if err := saveGame(tx, gameRecord, stateRecord, moveRecord); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.New("Couldn't commit game: " + err.Error())
+	}
+
+	s.poller.Notify()
+
+	return nil
+}
+
+func saveGame(tx *gorp.Transaction, gameRecord *sqlstorage.GameStorageRecord, stateRecord *sqlstorage.StateStorageRecord, moveRecord *sqlstorage.MoveStorageRecord) error {
+
+	count, err := tx.SelectInt("select count(*) from "+sqlstorage.TableGames+" where Id=?", gameRecord.Id)
+
+	if err != nil {
+		return errors.New("Couldn't check for existing game: " + err.Error())
+	}
+
+	if count < 1 {
+		//Need to insert
+		if err := tx.Insert(gameRecord); err != nil {
+			return errors.New("Couldn't insert game: " + err.Error())
+		}
+
+		extendedRecord := sqlstorage.NewExtendedGameStorageRecord(extendedgame.DefaultStorageRecord())
+
+		extendedRecord.Id = gameRecord.Id
+
+		if err := tx.Insert(extendedRecord); err != nil {
+			return errors.New("Couldn't insert the extended game info: " + err.Error())
+		}
+
+	} else {
+		//Need to update
+		if _, err := tx.Update(gameRecord); err != nil {
+			return errors.New("Couldn't update game: " + err.Error())
+		}
+
+		if _, err := tx.Exec("update "+sqlstorage.TableExtendedGames+" set LastActivity=? where Id=?", time.Now().UnixNano(), gameRecord.Id); err != nil {
+			return errors.New("Couldn't update LastActivity on game: " + err.Error())
+		}
+
+	}
+
+	if err := tx.Insert(stateRecord); err != nil {
+		return errors.New("Couldn't insert state: " + err.Error())
+	}
+
+	if moveRecord != nil {
+		if err := tx.Insert(moveRecord); err != nil {
+			return errors.New("Couldn't insert move: " + err.Error())
+		}
+	}
+
+	changeRecord := sqlstorage.NewChangeStorageRecord(gameRecord.Id, int(stateRecord.Version))
+
+	if err := tx.Insert(changeRecord); err != nil {
+		return errors.New("Couldn't insert change: " + err.Error())
+	}
+
+	return sqlstorage.PruneChanges(tx, changeRecord.Id)
+}
+
+func (s *StorageManager) SaveMatch(match *boardgame.MatchStorageRecord) error {
+
+	if !s.connected {
+		return errors.New("Database not connected yet")
+	}
+
+	record, err := sqlstorage.NewMatchStorageRecord(match)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := s.dbMap.Update(record)
+
+	if err != nil {
+		return errors.New("Couldn't update match: " + err.Error())
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	if err := s.dbMap.Insert(record); err != nil {
+		return errors.New("Couldn't insert match: " + err.Error())
+	}
+
+	return nil
+}
+
+func (s *StorageManager) SetPlayerForGame(gameId string, playerIndex boardgame.PlayerIndex, userId string) error {
+
+	if !s.connected {
+		return errors.New("Database not connected yet")
+	}
+
+	game, err := s.Game(gameId)
+
+	if err != nil {
+		return errors.New("Couldn't get game: " + err.Error())
+	}
+
+	if playerIndex < 0 || int(playerIndex) >= int(game.NumPlayers) {
+		return errors.New("PlayerIndex " + playerIndex.String() + " is not valid for this game")
+	}
+
+	if s.GetUserById(userId) == nil {
+		return errors.New("That userId does not describe an existing user")
+	}
+
+	count, err := s.dbMap.SelectInt("select count(*) from "+sqlstorage.TablePlayers+" where GameId=? and PlayerIndex=?", game.Id, int64(playerIndex))
+
+	if err != nil {
+		return errors.New("Failed to retrieve existing Player line: " + err.Error())
+	}
+
+	if count > 0 {
+		return errors.New("PlayerIndex " + playerIndex.String() + " is already taken")
+	}
+
+	player := &sqlstorage.PlayerStorageRecord{
+		GameId:      game.Id,
+		PlayerIndex: int64(playerIndex),
+		UserId:      userId,
+	}
+
+	if err := s.dbMap.Insert(player); err != nil {
+		return errors.New("Couldn't insert new player line: " + err.Error())
+	}
+
+	return nil
+
+}
+
+func (s *StorageManager) UserIdsForGame(gameId string) []string {
+
+	if !s.connected {
+		return nil
+	}
+
+	game, err := s.Game(gameId)
+
+	if err != nil {
+		return nil
+	}
+
+	var players []sqlstorage.PlayerStorageRecord
+
+	_, err = s.dbMap.Select(&players, "select * from "+sqlstorage.TablePlayers+" where GameId=? order by PlayerIndex desc", game.Id)
+
+	result := make([]string, game.NumPlayers)
+
+	if err != nil {
+		log.Println("Couldn't get rows: ", err.Error())
+		return result
+	}
+
+	for _, rec := range players {
+		index := int(rec.PlayerIndex)
+
+		if index < 0 || index >= len(result) {
+			log.Println("Invalid index", rec)
+			continue
+		}
+
+		result[index] = rec.UserId
+	}
+
+	return result
+
+}
+
+func (s *StorageManager) UpdateUser(user *users.StorageRecord) error {
+
+	if !s.connected {
+		return errors.New("Database not connected yet")
+	}
+
+	userRecord := sqlstorage.NewUserStorageRecord(user)
+
+	count, err := s.dbMap.Update(userRecord)
+
+	if err != nil {
+		return errors.New("Couldn't update user: " + err.Error())
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	if err := s.dbMap.Insert(userRecord); err != nil {
+		return errors.New("Couldn't insert user: " + err.Error())
+	}
+
+	return nil
+}
+
+func (s *StorageManager) ConnectCookieToUser(cookie string, user *users.StorageRecord) error {
+
+	if !s.connected {
+		return errors.New("Database not connected yet")
+	}
+
+	//If user is nil, then delete any records with that cookie.
+	if user == nil {
+		if _, err := s.dbMap.Exec("delete from "+sqlstorage.TableCookies+" where Cookie=?", cookie); err != nil {
+			return errors.New("Couldn't delete cookie record: " + err.Error())
+		}
+		return nil
+	}
+
+	//If user does not yet exist in database, put them in.
+	if s.GetUserById(user.Id) == nil {
+		if err := s.UpdateUser(user); err != nil {
+			return errors.New("Couldn't add a new user to the database when connecting to cookie: " + err.Error())
+		}
+	}
+
+	if _, err := s.dbMap.Exec("insert or replace into "+sqlstorage.TableCookies+" (Cookie, UserId) values (?, ?)", cookie, user.Id); err != nil {
+		return errors.New("Failed to insert cookie pointer record: " + err.Error())
+	}
+
+	return nil
+}
+
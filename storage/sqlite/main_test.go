@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"github.com/jkomoros/boardgame/storage/storagetest"
+	"github.com/workfit/tester/assert"
+	"testing"
+)
+
+const testDbFile = ".testdb.sqlite"
+
+func TestStorageManager(t *testing.T) {
+
+	storagetest.Test(func() storagetest.StorageManager {
+		return NewStorageManager(true)
+	}, "sqlite", testDbFile, t)
+
+}
+
+func TestMigrate(t *testing.T) {
+
+	manager := NewStorageManager(true)
+
+	defer manager.CleanUp()
+
+	assert.For(t).ThatActual(manager.Connect("")).IsNotNil()
+
+	assert.For(t).ThatActual(manager.Connect(testDbFile)).IsNil()
+
+	manager.Close()
+
+	//Connecting to an existing, up-to-date database shouldn't migrate it
+	//again.
+	assert.For(t).ThatActual(manager.Connect(testDbFile)).IsNil()
+
+	var version int
+
+	assert.For(t).ThatActual(manager.db.QueryRow("pragma user_version").Scan(&version)).IsNil()
+	assert.For(t).ThatActual(version).Equals(len(migrations))
+
+}
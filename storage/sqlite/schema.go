@@ -0,0 +1,151 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+//migrations is the schema of the database, in the same order as the
+//migrations in storage/mysql/migrations, but collapsed to the current
+//shape of the tables. Connect applies every migration past the database's
+//user_version, and then sets user_version to len(migrations), so when
+//making a change to the database structure append a new entry rather than
+//modifying an existing one.
+//
+//Ids are compared case-insensitively, like they are with mysql's default
+//collation, and every column that the listing queries filter or sort on is
+//indexed.
+var migrations = []string{
+	//1: initial tables
+	`create table if not exists users (
+		Id text not null primary key collate nocase,
+		Created integer,
+		LastSeen integer,
+		DisplayName text,
+		PhotoUrl text,
+		Email text,
+		Locale text
+	);
+	create table if not exists games (
+		Name text,
+		Id text not null primary key collate nocase,
+		SecretSalt text,
+		Version integer,
+		Winners text,
+		Result text,
+		Finished boolean,
+		Created integer,
+		NumPlayers integer,
+		Agents text,
+		Components text,
+		MatchId text collate nocase,
+		NumAgents integer
+	);
+	create table if not exists extendedgames (
+		Id text not null primary key collate nocase,
+		LastActivity integer,
+		Open boolean,
+		Visible boolean,
+		Owner text
+	);
+	create table if not exists states (
+		Id integer not null primary key autoincrement,
+		GameId text collate nocase,
+		Version integer,
+		Blob text
+	);
+	create table if not exists moves (
+		Id integer not null primary key autoincrement,
+		GameId text collate nocase,
+		Version integer,
+		Initiator integer,
+		Timestamp integer,
+		Phase integer,
+		Name text,
+		Blob text
+	);
+	create table if not exists cookies (
+		Cookie text not null primary key,
+		UserId text collate nocase
+	);
+	create table if not exists players (
+		Id integer not null primary key autoincrement,
+		GameId text collate nocase,
+		PlayerIndex integer,
+		UserId text collate nocase
+	);
+	create table if not exists agentstates (
+		Id integer not null primary key autoincrement,
+		GameId text collate nocase,
+		PlayerIndex integer,
+		Blob text
+	);
+	create table if not exists matches (
+		Id text not null primary key collate nocase,
+		Name text,
+		Finished boolean,
+		Created integer,
+		Blob text
+	);`,
+	//2: indexes for lookups and listing queries
+	`create unique index if not exists states_game_version on states (GameId, Version);
+	create unique index if not exists moves_game_version on moves (GameId, Version);
+	create unique index if not exists players_game_index on players (GameId, PlayerIndex);
+	create index if not exists players_user on players (UserId);
+	create index if not exists agentstates_game_player on agentstates (GameId, PlayerIndex);
+	create index if not exists games_name_finished on games (Name, Finished);
+	create index if not exists games_match on games (MatchId);
+	create index if not exists extendedgames_last_activity on extendedgames (LastActivity);
+	create index if not exists cookies_user on cookies (UserId);`,
+	//3: indexes for QueryGames
+	`create index if not exists games_created on games (Created, Id);
+	create index if not exists extendedgames_owner on extendedgames (Owner);`,
+	//4: the change feed
+	`create table if not exists changes (
+		Id integer not null primary key autoincrement,
+		GameId text collate nocase,
+		Version integer,
+		Created integer
+	);`,
+}
+
+//migrate brings the schema of db up to date.
+func migrate(db *sql.DB) error {
+
+	var version int
+
+	if err := db.QueryRow("pragma user_version").Scan(&version); err != nil {
+		return errors.New("Couldn't read schema version: " + err.Error())
+	}
+
+	if version > len(migrations) {
+		return errors.New("The database's schema version is " + strconv.Itoa(version) + ", which is newer than this package knows about")
+	}
+
+	for i := version; i < len(migrations); i++ {
+
+		tx, err := db.Begin()
+
+		if err != nil {
+			return errors.New("Couldn't start transaction: " + err.Error())
+		}
+
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return errors.New("Couldn't apply migration " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+
+		//pragmas can't take parameters.
+		if _, err := tx.Exec("pragma user_version = " + strconv.Itoa(i+1)); err != nil {
+			tx.Rollback()
+			return errors.New("Couldn't set schema version: " + err.Error())
+		}
+
+		if err := tx.Commit(); err != nil {
+			return errors.New("Couldn't commit migration " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
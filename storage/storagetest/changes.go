@@ -0,0 +1,137 @@
+package storagetest
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/changes"
+	"github.com/workfit/tester/assert"
+	"testing"
+	"time"
+)
+
+//changeWait is how long ChangesTest waits for a watcher to receive a change.
+//It's longer than changes.DefaultPollInterval, for storage managers that
+//poll.
+const changeWait = 5 * time.Second
+
+//ChangesTest checks that every saved version shows up in ChangesSince and in
+//watchers.
+func ChangesTest(factory StorageManagerFactory, testName string, connectConfig string, t *testing.T) {
+
+	storage := factory()
+
+	defer storage.Close()
+	defer storage.CleanUp()
+
+	if err := storage.Connect(connectConfig); err != nil {
+		t.Fatal("Err connecting to storage: ", err)
+	}
+
+	start, err := storage.ChangesSince(changes.Latest)
+
+	if !assert.For(t, testName).ThatActual(err).IsNil().Passed() {
+		return
+	}
+
+	assert.For(t, testName).ThatActual(len(start.Changes)).Equals(0)
+
+	all := storage.Watch("")
+	one := storage.Watch("CHANGES1")
+
+	expected := []changes.Change{
+		{GameId: "CHANGES1", Version: 0},
+		{GameId: "CHANGES2", Version: 0},
+		{GameId: "CHANGES1", Version: 1},
+	}
+
+	for _, change := range expected {
+		record := &boardgame.GameStorageRecord{
+			Name:       "tictactoe",
+			Id:         change.GameId,
+			SecretSalt: "SALT",
+			Version:    change.Version,
+			NumPlayers: 2,
+			Created:    time.Now(),
+		}
+		assert.For(t, testName, change).ThatActual(storage.SaveGameAndCurrentState(record, rawState(change.Version), nil)).IsNil()
+	}
+
+	result, err := storage.ChangesSince(start.Cursor)
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(withoutCursors(result.Changes)).Equals(expected)
+
+	if len(result.Changes) == len(expected) {
+		for i := 1; i < len(result.Changes); i++ {
+			assert.For(t, testName, i).ThatActual(result.Changes[i].Cursor > result.Changes[i-1].Cursor).IsTrue()
+		}
+		assert.For(t, testName).ThatActual(result.Cursor).Equals(result.Changes[len(result.Changes)-1].Cursor)
+	}
+
+	//Each change can be fetched on its own by starting from the one before.
+	if len(result.Changes) > 1 {
+		second, err := storage.ChangesSince(result.Changes[0].Cursor)
+		assert.For(t, testName).ThatActual(err).IsNil()
+		assert.For(t, testName).ThatActual(withoutCursors(second.Changes)).Equals(expected[1:])
+	}
+
+	//Nothing has happened since.
+	empty, err := storage.ChangesSince(result.Cursor)
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(len(empty.Changes)).Equals(0)
+	assert.For(t, testName).ThatActual(empty.Cursor).Equals(result.Cursor)
+
+	latest, err := storage.ChangesSince(changes.Latest)
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(latest.Cursor).Equals(result.Cursor)
+
+	assert.For(t, testName, "All").ThatActual(receiveChanges(all, len(expected))).Equals(expected)
+	assert.For(t, testName, "One").ThatActual(receiveChanges(one, 2)).Equals([]changes.Change{expected[0], expected[2]})
+
+	//Closing a watcher closes its channel.
+	one.Close()
+	one.Close()
+
+	_, ok := <-one.Changes()
+
+	assert.For(t, testName).ThatActual(ok).IsFalse()
+
+	all.Close()
+
+}
+
+//withoutCursors returns copies of the changes with their Cursors zeroed, for
+//comparing to expected changes.
+func withoutCursors(records []*changes.Change) []changes.Change {
+	result := make([]changes.Change, len(records))
+	for i, change := range records {
+		result[i] = *change
+		result[i].Cursor = 0
+	}
+	return result
+}
+
+//receiveChanges waits for count changes from watcher, returning early if
+//they don't all arrive within changeWait.
+func receiveChanges(watcher *changes.Watcher, count int) []changes.Change {
+
+	var result []changes.Change
+
+	timeout := time.After(changeWait)
+
+	for len(result) < count {
+		select {
+		case change, ok := <-watcher.Changes():
+			if !ok {
+				return result
+			}
+			change.Cursor = 0
+			result = append(result, *change)
+		case <-timeout:
+			return result
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,78 @@
+package storagetest
+
+import (
+	"github.com/workfit/tester/assert"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+const (
+	concurrentGames    = 8
+	concurrentVersions = 5
+)
+
+//ConcurrencyTest saves different games from many goroutines at once, and
+//checks that every one of them was stored intact.
+func ConcurrencyTest(factory StorageManagerFactory, testName string, connectConfig string, t *testing.T) {
+
+	storage := factory()
+
+	defer storage.Close()
+	defer storage.CleanUp()
+
+	if err := storage.Connect(connectConfig); err != nil {
+		t.Fatal("Err connecting to storage: ", err)
+	}
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, concurrentGames*concurrentVersions)
+
+	for i := 0; i < concurrentGames; i++ {
+		wg.Add(1)
+		go func(gameId string) {
+			defer wg.Done()
+			for version := 0; version < concurrentVersions; version++ {
+				if err := storage.SaveGameAndCurrentState(rawGameRecord(gameId, version), rawState(version), rawMove(version)); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}("CONCURRENT" + strconv.Itoa(i))
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(testName, "Error saving concurrently:", err)
+	}
+
+	for i := 0; i < concurrentGames; i++ {
+
+		gameId := "CONCURRENT" + strconv.Itoa(i)
+
+		game, err := storage.Game(gameId)
+
+		assert.For(t, testName, gameId).ThatActual(err).IsNil()
+
+		if game == nil {
+			continue
+		}
+
+		assert.For(t, testName, gameId).ThatActual(game.Version).Equals(concurrentVersions - 1)
+
+		for version := 0; version < concurrentVersions; version++ {
+			state, err := storage.State(gameId, version)
+			assert.For(t, testName, gameId, version).ThatActual(err).IsNil()
+			compareJSONObjects(state, rawState(version), testName+" "+gameId+" state "+strconv.Itoa(version), t)
+		}
+
+		moves, err := storage.Moves(gameId, 0, concurrentVersions-1)
+
+		assert.For(t, testName, gameId).ThatActual(err).IsNil()
+		assert.For(t, testName, gameId).ThatActual(len(moves)).Equals(concurrentVersions - 1)
+	}
+
+}
@@ -0,0 +1,255 @@
+package storagetest
+
+import (
+	"errors"
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/examples/blackjack"
+	"github.com/jkomoros/boardgame/examples/tictactoe"
+	"github.com/jkomoros/boardgame/server/api/listing"
+	"github.com/jkomoros/boardgame/server/api/users"
+	"github.com/workfit/tester/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+//rawGameRecord returns a game record for a game that doesn't belong to any
+//manager, for tests that save records directly.
+func rawGameRecord(id string, version int) *boardgame.GameStorageRecord {
+	return &boardgame.GameStorageRecord{
+		Name:       "tictactoe",
+		Id:         id,
+		SecretSalt: "SALT",
+		Version:    version,
+		NumPlayers: 2,
+		Created:    time.Unix(1000, 0),
+	}
+}
+
+func rawState(version int) boardgame.StateStorageRecord {
+	return []byte(`{"Version":` + strconv.Itoa(version) + `}`)
+}
+
+func rawMove(version int) *boardgame.MoveStorageRecord {
+	if version == 0 {
+		return nil
+	}
+	return &boardgame.MoveStorageRecord{
+		Name:      "Place Token",
+		Version:   version,
+		Timestamp: time.Unix(1000, 0),
+		Blob:      []byte(`{"Version":` + strconv.Itoa(version) + `}`),
+	}
+}
+
+//EdgeCasesTest saves records directly, without a manager, to check edge
+//cases like gaps between versions and the semantics of Moves' arguments.
+func EdgeCasesTest(factory StorageManagerFactory, testName string, connectConfig string, t *testing.T) {
+
+	storage := factory()
+
+	defer storage.Close()
+	defer storage.CleanUp()
+
+	if err := storage.Connect(connectConfig); err != nil {
+		t.Fatal("Err connecting to storage: ", err)
+	}
+
+	gameId := "EDGECASES"
+
+	//Version 2 is skipped.
+	for _, version := range []int{0, 1, 3} {
+		err := storage.SaveGameAndCurrentState(rawGameRecord(gameId, version), rawState(version), rawMove(version))
+		assert.For(t, testName, version).ThatActual(err).IsNil()
+	}
+
+	game, err := storage.Game(gameId)
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(game.Version).Equals(3)
+
+	for _, version := range []int{0, 1, 3} {
+		state, err := storage.State(gameId, version)
+		assert.For(t, testName, version).ThatActual(err).IsNil()
+		compareJSONObjects(state, rawState(version), testName+" state "+strconv.Itoa(version), t)
+	}
+
+	_, err = storage.State(gameId, 2)
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	_, err = storage.State(gameId, 4)
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	_, err = storage.Move(gameId, 2)
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	move, err := storage.Move(gameId, 3)
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(move.Version).Equals(3)
+	assert.For(t, testName).ThatActual(move.Name).Equals("Place Token")
+
+	movesTests := []struct {
+		from     int
+		to       int
+		versions []int
+	}{
+		//If from and to are the same, just to is returned.
+		{3, 3, []int{3}},
+		{1, 1, []int{1}},
+		//from is exclusive and to is inclusive, and missing versions are
+		//skipped.
+		{0, 3, []int{1, 3}},
+		{1, 3, []int{3}},
+		{0, 2, []int{1}},
+	}
+
+	for i, test := range movesTests {
+		moves, err := storage.Moves(gameId, test.from, test.to)
+		assert.For(t, testName, i).ThatActual(err).IsNil()
+		var versions []int
+		for _, move := range moves {
+			versions = append(versions, move.Version)
+		}
+		assert.For(t, testName, i).ThatActual(versions).Equals(test.versions)
+	}
+
+	//Not found errors may be copies of the sentinel errors with more detail,
+	//so they have to match with errors.Is.
+	_, err = storage.Game("MISSINGGAME")
+
+	assert.For(t, testName).ThatActual(errors.Is(err, boardgame.ErrGameNotFound)).IsTrue()
+
+	_, err = storage.State("MISSINGGAME", 0)
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	_, err = storage.Move("MISSINGGAME", 1)
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	_, err = storage.State(gameId, 2)
+
+	assert.For(t, testName).ThatActual(errors.Is(err, boardgame.ErrVersionNotFound)).IsTrue()
+
+	_, err = storage.Move(gameId, 2)
+
+	assert.For(t, testName).ThatActual(errors.Is(err, boardgame.ErrVersionNotFound)).IsTrue()
+
+	//A game that selects no components at all has to stay different from
+	//one that selects the whole chest.
+	selections := map[string]boardgame.ComponentSelection{
+		"ALLCOMPONENTS":  nil,
+		"NOCOMPONENTS":   {},
+		"SOMECOMPONENTS": {"cards": {1, 2}},
+	}
+
+	for id, selection := range selections {
+		record := rawGameRecord(id, 0)
+		record.Components = selection
+		assert.For(t, testName, id).ThatActual(storage.SaveGameAndCurrentState(record, rawState(0), nil)).IsNil()
+
+		game, err := storage.Game(id)
+
+		assert.For(t, testName, id).ThatActual(err).IsNil()
+
+		if game == nil {
+			continue
+		}
+
+		assert.For(t, testName, id).ThatActual(game.Components == nil).Equals(selection == nil)
+		assert.For(t, testName, id).ThatActual(game.Components).Equals(selection)
+	}
+
+}
+
+//ServerMethodsTest checks the methods of server/api.StorageManager that the
+//other tests don't cover.
+func ServerMethodsTest(factory StorageManagerFactory, testName string, connectConfig string, t *testing.T) {
+
+	storage := factory()
+
+	defer storage.Close()
+	defer storage.CleanUp()
+
+	if err := storage.Connect(connectConfig); err != nil {
+		t.Fatal("Err connecting to storage: ", err)
+	}
+
+	manager, _ := tictactoe.NewManager(storage)
+	blackjackManager, _ := blackjack.NewManager(storage)
+
+	game := manager.NewGame()
+
+	assert.For(t, testName).ThatActual(game.SetUp(0, nil, nil)).IsNil()
+
+	blackjackGame := blackjackManager.NewGame()
+
+	assert.For(t, testName).ThatActual(blackjackGame.SetUp(0, nil, nil)).IsNil()
+
+	combined, err := storage.CombinedGame(game.Id())
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+
+	eGame, err := storage.ExtendedGame(game.Id())
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+
+	assert.For(t, testName).ThatActual(combined.Id).Equals(game.Id())
+	assert.For(t, testName).ThatActual(combined.Name).Equals(manager.Delegate().Name())
+	assert.For(t, testName).ThatActual(combined.Version).Equals(game.Version())
+	assert.For(t, testName).ThatActual(combined.NumPlayers).Equals(game.NumPlayers())
+	assert.For(t, testName).ThatActual(combined.StorageRecord).Equals(*eGame)
+
+	_, err = storage.CombinedGame("MISSINGGAME")
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	_, err = storage.ExtendedGame("MISSINGGAME")
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	gameRecord, err := storage.Game(game.Id())
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(storage.PlayerMoveApplied(gameRecord)).IsNil()
+
+	games := storage.ListGames(10, listing.All, "", manager.Delegate().Name())
+
+	assert.For(t, testName).ThatActual(len(games)).Equals(1)
+
+	if len(games) > 0 {
+		assert.For(t, testName).ThatActual(games[0].Id).Equals(game.Id())
+	}
+
+	games = storage.ListGames(10, listing.All, "", blackjackManager.Delegate().Name())
+
+	assert.For(t, testName).ThatActual(len(games)).Equals(1)
+
+	games = storage.ListGames(1, listing.All, "", "")
+
+	assert.For(t, testName).ThatActual(len(games)).Equals(1)
+
+	user := &users.StorageRecord{Id: "COOKIEUSER"}
+
+	assert.For(t, testName).ThatActual(storage.UpdateUser(user)).IsNil()
+
+	assert.For(t, testName).ThatActual(storage.ConnectCookieToUser("COOKIE", user)).IsNil()
+
+	assert.For(t, testName).ThatActual(storage.GetUserByCookie("COOKIE")).Equals(user)
+
+	//Connecting a cookie to a nil user deletes it.
+	assert.For(t, testName).ThatActual(storage.ConnectCookieToUser("COOKIE", nil)).IsNil()
+
+	var nilUser *users.StorageRecord
+
+	assert.For(t, testName).ThatActual(storage.GetUserByCookie("COOKIE")).Equals(nilUser)
+	assert.For(t, testName).ThatActual(storage.GetUserById(user.Id)).Equals(user)
+
+	//Deleting a cookie that doesn't exist is fine.
+	assert.For(t, testName).ThatActual(storage.ConnectCookieToUser("COOKIE", nil)).IsNil()
+
+}
@@ -1,10 +1,26 @@
 /*
 
-	test is a package that is used to run a boardgame/server.StorageManager
+	storagetest is a package that is used to run a boardgame/server.StorageManager
 	implementation through its paces and verify it does everything correctly.
+	It's importable so that storage managers that live outside of this repo can
+	be held to the same standard as the ones inside it.
+
+	To use it, add a test to your storage manager's package that passes a
+	factory for fresh, unconnected storage managers to Test:
+
+		func TestStorageManager(t *testing.T) {
+			storagetest.Test(func() storagetest.StorageManager {
+				return NewStorageManager()
+			}, "mystorage", "config string for Connect", t)
+		}
+
+	Test runs every one of the tests in this package. Each of them creates its
+	own storage manager from the factory, Connects it with the given config
+	string, and calls Close and CleanUp when it's done, so the factory must
+	return a storage manager that starts out empty every time.
 
 */
-package test
+package storagetest
 
 import (
 	"encoding/json"
@@ -14,45 +30,24 @@ import (
 	"github.com/jkomoros/boardgame/server/api/extendedgame"
 	"github.com/jkomoros/boardgame/server/api/listing"
 	"github.com/jkomoros/boardgame/server/api/users"
+	"github.com/jkomoros/boardgame/storage"
+	"github.com/jkomoros/boardgame/storage/retention"
 	"github.com/workfit/tester/assert"
 	"log"
 	"math"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 )
 
+//StorageManager is the interface a storage manager must implement to be
+//tested. It's storage.Manager, plus CleanUp.
 type StorageManager interface {
-	boardgame.StorageManager
+	storage.Manager
 
 	//CleanUp will be called when a given manager is done and can be dispoed of.
 	CleanUp()
-
-	//The methods past this point are the same ones that are included in Server.StorageManager
-	Name() string
-
-	Connect(config string) error
-
-	ExtendedGame(id string) (*extendedgame.StorageRecord, error)
-
-	CombinedGame(id string) (*extendedgame.CombinedStorageRecord, error)
-
-	UpdateExtendedGame(id string, eGame *extendedgame.StorageRecord) error
-
-	Close()
-	ListGames(max int, list listing.Type, userId string, gameType string) []*extendedgame.CombinedStorageRecord
-
-	UserIdsForGame(gameId string) []string
-
-	SetPlayerForGame(gameId string, playerIndex boardgame.PlayerIndex, userId string) error
-
-	UpdateUser(user *users.StorageRecord) error
-
-	GetUserById(uid string) *users.StorageRecord
-
-	GetUserByCookie(cookie string) *users.StorageRecord
-
-	ConnectCookieToUser(cookie string, user *users.StorageRecord) error
 }
 
 type managerMap map[string]*boardgame.GameManager
@@ -61,14 +56,24 @@ func (m managerMap) Get(name string) *boardgame.GameManager {
 	return m[name]
 }
 
+//StorageManagerFactory returns a new, empty storage manager that hasn't been
+//connected yet. testName passed to Test must be the storage manager's Name.
 type StorageManagerFactory func() StorageManager
 
+//Test runs all of the tests in this package.
 func Test(factory StorageManagerFactory, testName string, connectConfig string, t *testing.T) {
 
 	BasicTest(factory, testName, connectConfig, t)
 	UsersTest(factory, testName, connectConfig, t)
 	AgentsTest(factory, testName, connectConfig, t)
 	ListingTest(factory, testName, connectConfig, t)
+	QueryTest(factory, testName, connectConfig, t)
+	ChangesTest(factory, testName, connectConfig, t)
+	MatchesTest(factory, testName, connectConfig, t)
+	RetentionTest(factory, testName, connectConfig, t)
+	ServerMethodsTest(factory, testName, connectConfig, t)
+	EdgeCasesTest(factory, testName, connectConfig, t)
+	ConcurrencyTest(factory, testName, connectConfig, t)
 
 }
 
@@ -364,6 +369,209 @@ func AgentsTest(factory StorageManagerFactory, testName string, connectConfig st
 
 }
 
+func MatchesTest(factory StorageManagerFactory, testName string, connectConfig string, t *testing.T) {
+
+	storage := factory()
+
+	defer storage.Close()
+	defer storage.CleanUp()
+
+	if err := storage.Connect(connectConfig); err != nil {
+		t.Fatal("Err connecting to storage: ", err)
+	}
+
+	_, err := storage.Match("missing")
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	manager, _ := tictactoe.NewManager(storage)
+
+	match, err := manager.NewMatch(2, nil, nil, boardgame.MatchPolicy{BestOf: 3})
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+
+	gameRecord, err := storage.Game(match.GameIds()[0])
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(gameRecord.Match).Equals(match.Id())
+
+	record := match.StorageRecord()
+
+	record.Scores = []int{3, 1}
+	record.Wins = []int{1, 0}
+	record.Games = append(record.Games, "NEXTGAME")
+
+	err = storage.SaveMatch(record)
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+
+	refried, err := storage.Match(match.Id())
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(refried.Policy).Equals(record.Policy)
+	assert.For(t, testName).ThatActual(refried.Games).Equals(record.Games)
+	assert.For(t, testName).ThatActual(refried.Scores).Equals(record.Scores)
+	assert.For(t, testName).ThatActual(refried.Wins).Equals(record.Wins)
+	assert.For(t, testName).ThatActual(refried.Created.UnixNano()).Equals(record.Created.UnixNano())
+
+	refriedMatch := manager.Match(match.Id())
+
+	assert.For(t, testName).ThatActual(refriedMatch).IsNotNil()
+	assert.For(t, testName).ThatActual(refriedMatch.Wins()).Equals(record.Wins)
+
+}
+
+func RetentionTest(factory StorageManagerFactory, testName string, connectConfig string, t *testing.T) {
+
+	storage := factory()
+
+	defer storage.Close()
+	defer storage.CleanUp()
+
+	if err := storage.Connect(connectConfig); err != nil {
+		t.Fatal("Err connecting to storage: ", err)
+	}
+
+	retentionStorage, ok := storage.(retention.Storage)
+
+	if !ok {
+		//This storage manager doesn't support retention.
+		return
+	}
+
+	manager, _ := tictactoe.NewManager(storage)
+
+	//finishedGame is finished and old enough to be compacted.
+	finishedGame := manager.NewGame()
+
+	assert.For(t, testName).ThatActual(finishedGame.SetUp(0, nil, nil)).IsNil()
+
+	for i := 0; i < 20 && !finishedGame.Finished(); i++ {
+		move := finishedGame.PlayerMoveByName("Place Token")
+		assert.For(t, testName, i).ThatActual(<-finishedGame.ProposeMove(move, boardgame.AdminPlayerIndex)).IsNil()
+	}
+
+	assert.For(t, testName).ThatActual(finishedGame.Finished()).IsTrue()
+
+	//abandonedGame never finished, and is old enough to be purged.
+	abandonedGame := manager.NewGame()
+
+	assert.For(t, testName).ThatActual(abandonedGame.SetUp(0, nil, nil)).IsNil()
+
+	//recentGame is left alone.
+	recentGame := manager.NewGame()
+
+	assert.For(t, testName).ThatActual(recentGame.SetUp(0, nil, nil)).IsNil()
+
+	//matchGame is as old as abandonedGame, but is kept because it's part of
+	//a match.
+	match, err := manager.NewMatch(2, nil, nil, boardgame.MatchPolicy{BestOf: 3})
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+
+	matchGameId := match.GameIds()[0]
+
+	setLastActivity := func(gameId string, age time.Duration) {
+		eGame, err := storage.ExtendedGame(gameId)
+		assert.For(t, testName).ThatActual(err).IsNil()
+		eGame.LastActivity = time.Now().Add(-age).UnixNano()
+		assert.For(t, testName).ThatActual(storage.UpdateExtendedGame(gameId, eGame)).IsNil()
+	}
+
+	setLastActivity(finishedGame.Id(), 40*retention.Day)
+	setLastActivity(abandonedGame.Id(), 400*retention.Day)
+	setLastActivity(matchGameId, 400*retention.Day)
+
+	assert.For(t, testName).ThatActual(storage.SaveAgentState("MISSINGGAME", 0, []byte("{}"))).IsNil()
+
+	policies := &retention.Policies{
+		Default: retention.Policy{
+			CompactFinishedAfter: 30 * retention.Day,
+			PurgeInactiveAfter:   365 * retention.Day,
+			KeyframeInterval:     3,
+		},
+	}
+
+	originalVersions, err := retentionStorage.StateVersions(finishedGame.Id())
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(len(originalVersions)).Equals(finishedGame.Version() + 1)
+
+	var expectedVersions []int
+
+	for version := 0; version <= finishedGame.Version(); version++ {
+		if version%3 == 0 || version == finishedGame.Version() {
+			expectedVersions = append(expectedVersions, version)
+		}
+	}
+
+	report, err := retention.Apply(retentionStorage, policies, true)
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(report.DryRun).IsTrue()
+	assert.For(t, testName).ThatActual(report.PurgedGames).Equals([]string{abandonedGame.Id()})
+	assert.For(t, testName).ThatActual(report.CompactedStates).Equals(map[string]int{
+		finishedGame.Id(): len(originalVersions) - len(expectedVersions),
+	})
+	assert.For(t, testName).ThatActual(report.OrphanedAgentStates).Equals([]string{"MISSINGGAME"})
+
+	//A dry run doesn't change anything.
+	versions, err := retentionStorage.StateVersions(finishedGame.Id())
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(versions).Equals(originalVersions)
+
+	_, err = storage.Game(abandonedGame.Id())
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+
+	report, err = retention.Apply(retentionStorage, policies, false)
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(report.DryRun).IsFalse()
+	assert.For(t, testName).ThatActual(report.PurgedGames).Equals([]string{abandonedGame.Id()})
+
+	_, err = storage.Game(abandonedGame.Id())
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	_, err = storage.State(abandonedGame.Id(), 0)
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	versions, err = retentionStorage.StateVersions(finishedGame.Id())
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(versions).Equals(expectedVersions)
+
+	//The finished game can still be loaded, since its final state is kept.
+	refried := manager.Game(finishedGame.Id())
+
+	assert.For(t, testName).ThatActual(refried).IsNotNil()
+	assert.For(t, testName).ThatActual(refried.Finished()).IsTrue()
+
+	_, err = storage.Game(recentGame.Id())
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+
+	_, err = storage.Game(matchGameId)
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+
+	agentState, _ := storage.AgentState("MISSINGGAME", 0)
+
+	assert.For(t, testName).ThatActual(agentState).IsNil()
+
+	//Everything has been cleaned up, so there's nothing left to do.
+	report, err = retention.Apply(retentionStorage, policies, false)
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(len(report.PurgedGames)).Equals(0)
+	assert.For(t, testName).ThatActual(len(report.CompactedStates)).Equals(0)
+	assert.For(t, testName).ThatActual(len(report.OrphanedAgentStates)).Equals(0)
+
+}
+
 func ListingTest(factory StorageManagerFactory, testName string, connectConfig string, t *testing.T) {
 
 	storage := factory()
@@ -614,11 +822,23 @@ func ListingTest(factory StorageManagerFactory, testName string, connectConfig s
 				t.Fatal("Couldn't get game: " + err.Error())
 			}
 			gameRec.Finished = true
+			gameRec.Result = &boardgame.GameResult{
+				Reason: boardgame.EndReasonResignation,
+				Players: []*boardgame.PlayerResult{
+					{Player: 0, Rank: 1, Score: 3},
+					{Player: 1, Rank: 2, Score: 3, TieBreak: "Most cards in hand"},
+				},
+			}
 			gameRec.Version++
 			err = storage.SaveGameAndCurrentState(gameRec, game.CurrentState().StorageRecord(), nil)
 			if err != nil {
 				t.Fatal("Couldn't save the game: " + err.Error())
 			}
+			refetchedRec, err := storage.Game(game.Id())
+			if err != nil {
+				t.Fatal("Couldn't refetch game: " + err.Error())
+			}
+			assert.For(t, i).ThatActual(refetchedRec.Result).Equals(gameRec.Result)
 		}
 
 		goldenRecords[i], err = storage.CombinedGame(game.Id())
@@ -0,0 +1,303 @@
+package storagetest
+
+import (
+	"github.com/jkomoros/boardgame"
+	"github.com/jkomoros/boardgame/server/api/extendedgame"
+	"github.com/jkomoros/boardgame/server/api/query"
+	"github.com/jkomoros/boardgame/server/api/users"
+	"github.com/workfit/tester/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+//queryGame is a game saved by QueryTest.
+type queryGame struct {
+	name         string
+	finished     bool
+	numPlayers   int
+	agent        bool
+	created      int64
+	lastActivity int64
+	open         bool
+	visible      bool
+	owner        string
+	player       bool
+}
+
+//queryGames are saved as QUERY0, QUERY1, etc. Games 3 and 4 were active at
+//the same time, so they're ordered by Id.
+var queryGames = []queryGame{
+	{"tictactoe", false, 2, false, 100, 600, true, true, "", true},
+	{"tictactoe", true, 2, true, 200, 500, false, true, "OWNER", true},
+	{"blackjack", false, 3, false, 300, 400, true, false, "OWNER", false},
+	{"blackjack", false, 2, true, 400, 300, true, true, "", false},
+	{"tictactoe", false, 3, false, 500, 300, false, false, "", true},
+	{"blackjack", true, 2, false, 600, 100, true, true, "OTHER", false},
+}
+
+//QueryTest saves games with a variety of properties and checks that
+//QueryGames filters, sorts and pages through them correctly.
+func QueryTest(factory StorageManagerFactory, testName string, connectConfig string, t *testing.T) {
+
+	storage := factory()
+
+	defer storage.Close()
+	defer storage.CleanUp()
+
+	if err := storage.Connect(connectConfig); err != nil {
+		t.Fatal("Err connecting to storage: ", err)
+	}
+
+	user := &users.StorageRecord{Id: "QUERYUSER"}
+
+	assert.For(t, testName).ThatActual(storage.UpdateUser(user)).IsNil()
+
+	for i, game := range queryGames {
+
+		id := "QUERY" + strconv.Itoa(i)
+
+		record := &boardgame.GameStorageRecord{
+			Name:       game.name,
+			Id:         id,
+			SecretSalt: "SALT",
+			Finished:   game.finished,
+			NumPlayers: game.numPlayers,
+			Agents:     make([]string, game.numPlayers),
+			Created:    time.Unix(game.created, 0),
+		}
+
+		if game.agent {
+			record.Agents[1] = "Agent"
+		}
+
+		if err := storage.SaveGameAndCurrentState(record, rawState(0), nil); err != nil {
+			t.Fatal(testName, "Couldn't save game: ", err)
+		}
+
+		eGame := &extendedgame.StorageRecord{
+			LastActivity: time.Unix(game.lastActivity, 0).UnixNano(),
+			Open:         game.open,
+			Visible:      game.visible,
+			Owner:        game.owner,
+		}
+
+		assert.For(t, testName, i).ThatActual(storage.UpdateExtendedGame(id, eGame)).IsNil()
+
+		if game.player {
+			assert.For(t, testName, i).ThatActual(storage.SetPlayerForGame(id, 0, user.Id)).IsNil()
+		}
+	}
+
+	tests := []struct {
+		description string
+		q           query.Query
+		expected    []int
+	}{
+		{
+			"Everything",
+			query.Query{},
+			[]int{0, 1, 2, 4, 3, 5},
+		},
+		{
+			"Ascending",
+			query.Query{Ascending: true},
+			[]int{5, 3, 4, 2, 1, 0},
+		},
+		{
+			"Created",
+			query.Query{Sort: query.Created},
+			[]int{5, 4, 3, 2, 1, 0},
+		},
+		{
+			"Game type",
+			query.Query{GameType: "blackjack"},
+			[]int{2, 3, 5},
+		},
+		{
+			"Finished",
+			query.Query{Finished: query.Yes},
+			[]int{1, 5},
+		},
+		{
+			"Not finished",
+			query.Query{Finished: query.No},
+			[]int{0, 2, 4, 3},
+		},
+		{
+			"User",
+			query.Query{UserId: user.Id},
+			[]int{0, 1, 4},
+		},
+		{
+			"Missing user",
+			query.Query{UserId: "MISSINGUSER"},
+			[]int{},
+		},
+		{
+			"Agents",
+			query.Query{HasAgents: query.Yes},
+			[]int{1, 3},
+		},
+		{
+			"No agents",
+			query.Query{HasAgents: query.No},
+			[]int{0, 2, 4, 5},
+		},
+		{
+			"Created range",
+			query.Query{CreatedAfter: time.Unix(200, 0), CreatedBefore: time.Unix(500, 0)},
+			[]int{1, 2, 3},
+		},
+		{
+			"Activity range",
+			query.Query{ActiveAfter: time.Unix(300, 0), ActiveBefore: time.Unix(500, 0)},
+			[]int{2, 4, 3},
+		},
+		{
+			"Num players",
+			query.Query{NumPlayers: 3},
+			[]int{2, 4},
+		},
+		{
+			"Open",
+			query.Query{Open: query.Yes},
+			[]int{0, 2, 3, 5},
+		},
+		{
+			"Not visible",
+			query.Query{Visible: query.No},
+			[]int{2, 4},
+		},
+		{
+			"Owner",
+			query.Query{Owner: "OWNER"},
+			[]int{1, 2},
+		},
+		{
+			"Combined",
+			query.Query{UserId: user.Id, Finished: query.No, GameType: "tictactoe", Sort: query.Created, Ascending: true},
+			[]int{0, 4},
+		},
+		{
+			"Limit",
+			query.Query{Limit: 2},
+			[]int{0, 1},
+		},
+	}
+
+	for _, test := range tests {
+
+		result, err := storage.QueryGames(&test.q)
+
+		if !assert.For(t, testName, test.description).ThatActual(err).IsNil().Passed() {
+			continue
+		}
+
+		assert.For(t, testName, test.description).ThatActual(queryGameIndexes(result.Games)).Equals(test.expected)
+
+		if test.q.Limit == 0 {
+			assert.For(t, testName, test.description).ThatActual(result.NextCursor).Equals("")
+		}
+	}
+
+	game, err := storage.CombinedGame("QUERY0")
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(game.Id).Equals("QUERY0")
+
+	result, err := storage.QueryGames(&query.Query{Limit: 1})
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(result.Games).Equals([]*extendedgame.CombinedStorageRecord{game})
+
+	//Paging through with cursors returns every game once, in order, in both
+	//directions and for both sorts.
+	for _, q := range []query.Query{
+		{Limit: 2},
+		{Limit: 2, Ascending: true},
+		{Limit: 4, Sort: query.Created},
+		{Limit: 1, Sort: query.Created, Ascending: true, Finished: query.No},
+	} {
+
+		all, err := storage.QueryGames(&query.Query{Sort: q.Sort, Ascending: q.Ascending, Finished: q.Finished})
+
+		assert.For(t, testName, q).ThatActual(err).IsNil()
+
+		var paged []*extendedgame.CombinedStorageRecord
+
+		for page := 0; page < len(queryGames)+1; page++ {
+
+			result, err := storage.QueryGames(&q)
+
+			if !assert.For(t, testName, q, page).ThatActual(err).IsNil().Passed() {
+				break
+			}
+
+			assert.For(t, testName, q, page).ThatActual(len(result.Games) <= q.Limit).IsTrue()
+
+			paged = append(paged, result.Games...)
+
+			if result.NextCursor == "" {
+				break
+			}
+
+			q.Cursor = result.NextCursor
+		}
+
+		assert.For(t, testName, q).ThatActual(queryGameIndexes(paged)).Equals(queryGameIndexes(all.Games))
+	}
+
+	result, err = storage.QueryGames(&query.Query{Limit: 3})
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+
+	//A game that's newer than any other doesn't show up on later pages.
+	newest := &boardgame.GameStorageRecord{
+		Name:       "tictactoe",
+		Id:         "QUERYNEW",
+		SecretSalt: "SALT",
+		NumPlayers: 2,
+		Created:    time.Now(),
+	}
+
+	assert.For(t, testName).ThatActual(storage.SaveGameAndCurrentState(newest, rawState(0), nil)).IsNil()
+
+	result, err = storage.QueryGames(&query.Query{Cursor: result.NextCursor})
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+	assert.For(t, testName).ThatActual(queryGameIndexes(result.Games)).Equals([]int{4, 3, 5})
+
+	//The new game's LastActivity was indexed when it was saved.
+	result, err = storage.QueryGames(&query.Query{Limit: 1})
+
+	assert.For(t, testName).ThatActual(err).IsNil()
+
+	if assert.For(t, testName).ThatActual(len(result.Games)).Equals(1).Passed() {
+		assert.For(t, testName).ThatActual(result.Games[0].Id).Equals("QUERYNEW")
+	}
+
+	//Cursors must be well formed, and for the query's sort.
+	_, err = storage.QueryGames(&query.Query{Cursor: "not a cursor"})
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+	_, err = storage.QueryGames(&query.Query{Sort: query.Created, Cursor: result.NextCursor})
+
+	assert.For(t, testName).ThatActual(err).IsNotNil()
+
+}
+
+//queryGameIndexes returns the index in queryGames of each of the games, or -1
+//for games that aren't from it.
+func queryGameIndexes(games []*extendedgame.CombinedStorageRecord) []int {
+	result := make([]int, len(games))
+	for i, game := range games {
+		index, err := strconv.Atoi(game.Id[len("QUERY"):])
+		if err != nil {
+			index = -1
+		}
+		result[i] = index
+	}
+	return result
+}
@@ -10,18 +10,20 @@ import (
 //own package tests can run. The shim is basically storage/memory/StorageManager.
 
 type testStorageManager struct {
-	states map[string]map[int]StateStorageRecord
-	moves  map[string]map[int]*MoveStorageRecord
-	games  map[string]*GameStorageRecord
+	states  map[string]map[int]StateStorageRecord
+	moves   map[string]map[int]*MoveStorageRecord
+	games   map[string]*GameStorageRecord
+	matches map[string]*MatchStorageRecord
 }
 
 func newTestStorageManager() *testStorageManager {
 	//InMemoryStorageManager is an extremely simple StorageManager that just keeps
 	//track of the objects in memory.
 	return &testStorageManager{
-		states: make(map[string]map[int]StateStorageRecord),
-		moves:  make(map[string]map[int]*MoveStorageRecord),
-		games:  make(map[string]*GameStorageRecord),
+		states:  make(map[string]map[int]StateStorageRecord),
+		moves:   make(map[string]map[int]*MoveStorageRecord),
+		games:   make(map[string]*GameStorageRecord),
+		matches: make(map[string]*MatchStorageRecord),
 	}
 }
 
@@ -58,13 +60,13 @@ func (i *testStorageManager) State(gameId string, version int) (StateStorageReco
 	versionMap, ok := i.states[gameId]
 
 	if !ok {
-		return nil, errors.New("That game does not exist")
+		return nil, ErrGameNotFound
 	}
 
 	record, ok := versionMap[version]
 
 	if !ok {
-		return nil, errors.New("That version of that game doesn't exist")
+		return nil, ErrVersionNotFound
 	}
 
 	return record, nil
@@ -97,13 +99,13 @@ func (i *testStorageManager) Move(gameId string, version int) (*MoveStorageRecor
 	versionMap, ok := i.moves[gameId]
 
 	if !ok {
-		return nil, errors.New("That game does not exist")
+		return nil, ErrGameNotFound
 	}
 
 	record, ok := versionMap[version]
 
 	if !ok {
-		return nil, errors.New("That version of that game doesn't exist")
+		return nil, ErrVersionNotFound
 	}
 
 	return record, nil
@@ -113,7 +115,7 @@ func (i *testStorageManager) Game(id string) (*GameStorageRecord, error) {
 	record := i.games[id]
 
 	if record == nil {
-		return nil, errors.New("That game does not exist")
+		return nil, ErrGameNotFound
 	}
 
 	return record, nil
@@ -142,12 +144,12 @@ func (i *testStorageManager) SaveGameAndCurrentState(game *GameStorageRecord, st
 
 	if _, ok := versionMap[version]; ok {
 		//Wait, there was already a version stored there?
-		return errors.New("There was already a version for that game stored")
+		return ErrVersionConflict
 	}
 
 	if _, ok := moveMap[version]; ok {
 		//Wait, there was already a version stored there?
-		return errors.New("There was already a version for that game stored")
+		return ErrVersionConflict
 	}
 
 	versionMap[version] = state
@@ -172,3 +174,35 @@ func (i *testStorageManager) SaveAgentState(gameId string, player PlayerIndex, s
 	//TODO: implement
 	return nil
 }
+
+func (i *testStorageManager) Match(id string) (*MatchStorageRecord, error) {
+	record := i.matches[id]
+
+	if record == nil {
+		return nil, ErrMatchNotFound
+	}
+
+	//Return a copy, like a real storage layer would, so callers can't
+	//modify what's stored.
+	result := *record
+	result.Games = append([]string(nil), record.Games...)
+	result.Scores = append([]int(nil), record.Scores...)
+	result.Wins = append([]int(nil), record.Wins...)
+
+	return &result, nil
+}
+
+func (i *testStorageManager) SaveMatch(match *MatchStorageRecord) error {
+	if match == nil {
+		return errors.New("No match provided")
+	}
+
+	record := *match
+	record.Games = append([]string(nil), match.Games...)
+	record.Scores = append([]int(nil), match.Scores...)
+	record.Wins = append([]int(nil), match.Wins...)
+
+	i.matches[match.Id] = &record
+
+	return nil
+}